@@ -165,6 +165,7 @@ type MixOptions struct {
 	Address     string               // Well in destination to place result; if nil, select one later
 	PlateNum    int                  // which plate to stick these on
 	PlateName   string               // which (named) plate to stick these on
+	Layout      *wtype.OutputLayout  // how to choose the well if Address is not set
 }
 
 // GenericMix is the general mixing entry point
@@ -250,6 +251,10 @@ func GenericMix(opt MixOptions) *wtype.LHInstruction {
 		r.PlateName = opt.PlateName
 	}
 
+	if opt.Layout != nil {
+		r.OutputLayout = opt.Layout
+	}
+
 	// ensure results are given the correct final volumes
 	// ... by definition this is either the sum of the volumes
 	// or the total volume if specified
@@ -35,6 +35,7 @@ const (
 	LTDNAMIX                LiquidType = "dna_mix"
 	LTProtein               LiquidType = "protein"
 	LTMultiWater            LiquidType = "multiwater"
+	LTMultiDispense         LiquidType = "multidispense"
 	LTLoad                  LiquidType = "load"
 	LTVISCOUS               LiquidType = "viscous"
 	LTPEG                   LiquidType = "peg"
@@ -90,6 +90,9 @@ func MakePolicyItems() AParamSet {
 		"BLOWOUTVOLUME":               AParam{Name: "BLOWOUTVOLUME", Type: typemap[Float64], Desc: "how much to blow out"},
 		"BLOWOUTVOLUMEUNIT":           AParam{Name: "BLOWOUTVOLUMEUNIT", Type: typemap[String], Desc: "volume unit for blowout volume"},
 		"CAN_MULTI":                   AParam{Name: "CAN_MULTI", Type: typemap[Bool], Desc: "is multichannel operation allowed?"},
+		"CAN_MULTI_DISPENSE":          AParam{Name: "CAN_MULTI_DISPENSE", Type: typemap[Bool], Desc: "may one aspirate be dispensed to several wells in turn?"},
+		"MULTI_DSP_CONDITIONING_VOL":  AParam{Name: "MULTI_DSP_CONDITIONING_VOL", Type: typemap[Volume], Desc: "volume returned to the source after aspirating for a multi-dispense"},
+		"MULTI_DSP_EXCESS_VOLUME":     AParam{Name: "MULTI_DSP_EXCESS_VOLUME", Type: typemap[Volume], Desc: "volume left in the tip after the last dispense of a multi-dispense and returned to the source"},
 		"DSPENTRYSPEED":               AParam{Name: "DSPENTRYSPEED", Type: typemap[Float64], Desc: "allows slow moves into liquids"},
 		"DSPREFERENCE":                AParam{Name: "DSPREFERENCE", Type: typemap[Int], Desc: "where to be when dispensing: 0 well bottom, 1 well top, 2 liquid level (if known)"},
 		"DSPSPEED":                    AParam{Name: "DSPSPEED", Type: typemap[Float64], Desc: "dispense pipetting rate"},
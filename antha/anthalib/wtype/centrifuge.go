@@ -1,3 +0,0 @@
-package wtype
-
-type CentrifugeParameters map[string]interface{}
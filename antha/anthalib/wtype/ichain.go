@@ -291,6 +291,11 @@ func (bg ByColumn) Less(i, j int) bool {
 	if c != 0 {
 		return c < 0
 	}
+
+	if c := compareDilutionSteps(bg[i], bg[j]); c != 0 {
+		return c < 0
+	}
+
 	// compare the plate names (which must exist now)
 	//	 -- oops, I think this has ben violated by moving the sort
 	// 	 TODO check and fix
@@ -320,6 +325,10 @@ func (bg ByResultComponent) Less(i, j int) bool {
 		return c < 0
 	}
 
+	if c := compareDilutionSteps(bg[i], bg[j]); c != 0 {
+		return c < 0
+	}
+
 	// compare the names of the resultant components
 	c = strings.Compare(bg[i].Outputs[0].CName, bg[j].Outputs[0].CName)
 
@@ -339,6 +348,41 @@ func (bg ByResultComponent) Less(i, j int) bool {
 	return CompareStringWellCoordsCol(bg[i].Welladdress, bg[j].Welladdress) < 0
 }
 
+// dilutionStepWell returns the plate and well of a serial dilution step,
+// which are those of the liquid it is mixed into if not set explicitly
+func dilutionStepWell(ins *LHInstruction) (string, string) {
+	if ins.Welladdress != "" {
+		return ins.PlateID, ins.Welladdress
+	}
+	loc := ins.Outputs[0].PlateLocation()
+	return loc.ID, loc.Coords.FormatA1()
+}
+
+// compareDilutionSteps orders the steps of serial dilutions before other
+// instructions, and down the columns of each plate among themselves, so that
+// dilutions laid out along adjacent rows can be made with a multichannel head.
+// Other instructions, and steps in the same well, compare equal so that the
+// sorts which use this can go on to order them consistently.
+func compareDilutionSteps(a, b *LHInstruction) int {
+	switch {
+	case a.SerialDilution == nil && b.SerialDilution == nil:
+		return 0
+	case a.SerialDilution == nil:
+		return 1
+	case b.SerialDilution == nil:
+		return -1
+	}
+
+	aPlate, aWell := dilutionStepWell(a)
+	bPlate, bWell := dilutionStepWell(b)
+
+	if c := strings.Compare(aPlate, bPlate); c != 0 {
+		return c
+	}
+
+	return CompareStringWellCoordsCol(aWell, bWell)
+}
+
 //SortInstructions sort the instructions within each link of the chain
 func (ic *IChain) SortInstructions(byComponent bool) {
 	if ic == nil {
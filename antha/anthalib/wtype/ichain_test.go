@@ -0,0 +1,83 @@
+package wtype
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestSortSerialDilutionSteps(t *testing.T) {
+	// makeMix returns a mix in the given well, which is that of its output if
+	// the well of the instruction is not set
+	makeMix := func(name, plateID, well string, ofOutput bool) *LHInstruction {
+		out := NewLHComponent()
+		out.CName = name
+		ins := NewLHMixInstruction()
+		ins.Outputs = []*Liquid{out}
+		ins.PlateName = plateID
+		if ofOutput {
+			out.Loc = plateID + ":" + well
+		} else {
+			ins.PlateID = plateID
+			ins.Welladdress = well
+		}
+		return ins
+	}
+	makeStep := func(name, plateID, well string, ofOutput bool) *LHInstruction {
+		ins := makeMix(name, plateID, well, ofOutput)
+		ins.SerialDilution = &SerialDilutionStep{Series: "series", Step: 1, Steps: 1, Factor: 2.0}
+		return ins
+	}
+
+	order := func(insts []*LHInstruction) string {
+		var names string
+		for _, ins := range insts {
+			names += ins.Outputs[0].CName
+		}
+		return names
+	}
+
+	for _, test := range []struct {
+		Name     string
+		Sort     func([]*LHInstruction) sort.Interface
+		Expected string
+	}{
+		{
+			Name:     "by column",
+			Sort:     func(insts []*LHInstruction) sort.Interface { return ByColumn(insts) },
+			Expected: "edcbayxz",
+		},
+		{
+			Name:     "by result component",
+			Sort:     func(insts []*LHInstruction) sort.Interface { return ByResultComponent(insts) },
+			Expected: "edcbaxyz",
+		},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			// steps go down the columns of each plate, whatever their names
+			// or those of the other mixes
+			insts := []*LHInstruction{
+				makeMix("z", "plate1", "C1", false),
+				makeStep("a", "plate1", "B2", false),
+				makeMix("x", "plate1", "B1", false),
+				makeStep("b", "plate1", "A2", true),
+				makeStep("c", "plate1", "B1", false),
+				makeMix("y", "plate0", "A1", false),
+				makeStep("d", "plate1", "A1", true),
+				makeStep("e", "plate0", "H12", false),
+			}
+			sort.Sort(test.Sort(insts))
+			if got := order(insts); got != test.Expected {
+				t.Errorf("expected order %q, got %q", test.Expected, got)
+			}
+
+			// and the order is consistent whichever order the mixes start in
+			for i := range insts {
+				rotated := append(append([]*LHInstruction(nil), insts[i:]...), insts[:i]...)
+				sort.Sort(test.Sort(rotated))
+				if got := order(rotated); got != test.Expected {
+					t.Errorf("expected order %q starting from mix %d, got %q", test.Expected, i, got)
+				}
+			}
+		})
+	}
+}
@@ -51,7 +51,17 @@ type LHInstruction struct {
 	OutPlate         *Plate
 	Message          string
 	WaitTime         time.Duration
-	PassThrough      map[string]*Liquid // 1:1 pass through, only applies to prompts
+	PassThrough      map[string]*Liquid  // 1:1 pass through, only applies to prompts
+	SerialDilution   *SerialDilutionStep // set if the mix is a step of a serial dilution
+	OutputLayout     *OutputLayout       // how to choose the well if none is given, overriding the request
+}
+
+// A SerialDilutionStep identifies a mix as one step of a serial dilution
+type SerialDilutionStep struct {
+	Series string  // ID shared by all steps of the dilution
+	Step   int     // 1 for the first dilution of the stock
+	Steps  int     // number of steps in the dilution
+	Factor float64 // each step is diluted this much more than the last
 }
 
 func (ins LHInstruction) String() string {
@@ -99,6 +109,10 @@ func (ins *LHInstruction) Summarize(indent int) string {
 			lines = append(lines, fmt.Sprintf(indentStr+"Resulting volume: %v", ins.Outputs[0].Summarize()))
 		}
 
+		if sd := ins.SerialDilution; sd != nil {
+			lines = append(lines, fmt.Sprintf(indentStr+"Step %d of %d of a %gx serial dilution", sd.Step, sd.Steps, sd.Factor))
+		}
+
 	default:
 		return indentStr + ins.String()
 	}
@@ -70,6 +70,7 @@ func MakePolicies() map[string]LHPolicy {
 	add(MakeLLFPolicy(), "LiquidLevel")
 	add(MakeSmartMixLLFPolicy(), "SmartMixLiquidLevel")
 	add(MakeMultiWaterPolicy(), "multiwater")
+	add(MakeMultiDispensePolicy(), "multidispense")
 	add(MakeCulturePolicy(), "culture")
 	add(MakeCultureReusePolicy(), "culturereuse")
 	add(MakeGlycerolPolicy(), "glycerol")
@@ -156,6 +157,7 @@ BLOWOUTOFFSET,                    ,float64,     ,mm above BLOWOUTREFERENCE
 BLOWOUTREFERENCE,          ,int,             ,where to be when blowing out: 0 well bottom, 1 well top
 BLOWOUTVOLUME,                ,float64,      ,how much to blow out
 CAN_MULTI,                              ,bool,         ,is multichannel operation allowed?
+CAN_MULTI_DISPENSE,            ,bool,         ,may one aspirate be dispensed to several wells in turn?
 DSPENTRYSPEED,                    ,float64,     ,allows slow moves into liquids
 DSPREFERENCE,                      ,int,            ,where to be when dispensing: 0 well bottom, 1 well top
 DSPSPEED,                              ,float64,       ,dispense pipetting rate
@@ -164,6 +166,8 @@ DSP_WAIT,                               ,float64,        ,wait time in seconds p
 EXTRA_ASP_VOLUME,            ,wunit.Volume,       ,additional volume to take up when aspirating
 EXTRA_DISP_VOLUME,           ,wunit.Volume,       ,additional volume to dispense
 JUSTBLOWOUT,                      ,bool,            ,shortcut to get single transfer
+MULTI_DSP_CONDITIONING_VOL,    ,wunit.Volume,       ,volume returned to the source after aspirating for a multi-dispense
+MULTI_DSP_EXCESS_VOLUME,       ,wunit.Volume,       ,volume left in the tip after the last dispense of a multi-dispense and returned to the source
 POST_MIX,                               ,int,               ,number of mix cycles to do after dispense
 POST_MIX_RATE,                    ,float64,          ,pipetting rate when post mixing
 POST_MIX_VOL,                      ,float64,          ,volume to post mix (ul)
@@ -307,6 +311,20 @@ func MakeMultiWaterPolicy() LHPolicy {
 	return pol
 }
 
+// MakeMultiDispensePolicy is the water policy but aspirates once for several
+// destinations, dispensing from above the liquid so that tips need not be
+// changed between dispenses
+func MakeMultiDispensePolicy() LHPolicy {
+	pol := MakeWaterPolicy()
+	pol["DSPREFERENCE"] = 1
+	pol["DSPZOFFSET"] = 0.0
+	pol["CAN_MULTI_DISPENSE"] = true
+	pol["MULTI_DSP_CONDITIONING_VOL"] = wunit.NewVolume(5.0, "ul")
+	pol["MULTI_DSP_EXCESS_VOLUME"] = wunit.NewVolume(5.0, "ul")
+	pol["DESCRIPTION"] = "Designed for dispensing water-like reagents such as master mix to many wells: aspirates once and dispenses to each well in turn from above the liquid, returning a conditioning and an excess volume to the source."
+	return pol
+}
+
 func MakeSingleChannelPolicy() LHPolicy {
 	pol := MakeWaterPolicy()
 	pol["CAN_MULTI"] = false
@@ -0,0 +1,54 @@
+package wtype
+
+import "fmt"
+
+// An OutputLayoutStrategy chooses the order in which mixes are placed in the
+// free wells of an output plate
+type OutputLayoutStrategy string
+
+const (
+	// LayoutInOrder fills wells column by column in the order the mixes are made
+	LayoutInOrder OutputLayoutStrategy = ""
+	// LayoutRandom places mixes in a random order
+	LayoutRandom OutputLayoutStrategy = "random"
+	// LayoutRandomBlocks places each block of mixes in consecutive wells, in
+	// a random order within the block
+	LayoutRandomBlocks OutputLayoutStrategy = "randomBlocks"
+	// LayoutInterleave places one replicate of every mix before the next
+	// replicate of any of them
+	LayoutInterleave OutputLayoutStrategy = "interleave"
+)
+
+// OutputLayout describes how the planner places mixes on output plates when
+// no well is given for them. Placing them in a random order, or away from the
+// edge of the plate, avoids confounding what is in a well with where it is.
+type OutputLayout struct {
+	Strategy OutputLayoutStrategy `json:"strategy,omitempty"`
+	// Seed for the random strategies, the planner chooses one if zero
+	Seed int64 `json:"seed,omitempty"`
+	// BlockFactor names the component whose volume identifies the block of
+	// each mix for LayoutRandomBlocks, each replicate is a block if empty
+	BlockFactor string `json:"blockFactor,omitempty"`
+	// SkipOuterWells leaves the wells on the edge of the plate empty
+	SkipOuterWells bool `json:"skipOuterWells,omitempty"`
+}
+
+// IsDefault returns true if the layout places mixes in order in every well
+func (ol OutputLayout) IsDefault() bool {
+	return ol.Strategy == LayoutInOrder && !ol.SkipOuterWells
+}
+
+// IsRandom returns true if the layout needs a seed
+func (ol OutputLayout) IsRandom() bool {
+	return ol.Strategy == LayoutRandom || ol.Strategy == LayoutRandomBlocks
+}
+
+// Validate returns an error if the strategy is unknown
+func (ol OutputLayout) Validate() error {
+	switch ol.Strategy {
+	case LayoutInOrder, LayoutRandom, LayoutRandomBlocks, LayoutInterleave:
+		return nil
+	default:
+		return fmt.Errorf("unknown output layout strategy %q", ol.Strategy)
+	}
+}
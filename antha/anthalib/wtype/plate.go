@@ -64,6 +64,7 @@ type Plate struct {
 	WellYStart  float64            // offset (mm) to first well in Y direction
 	WellZStart  float64            // offset (mm) to bottom of well in Z direction
 	Bounds      BBox               // (relative) position of the plate (mm), set by parent
+	Cover       PlateCover         // seal or lid on the plate, if any
 	parent      LHObject
 }
 
@@ -670,6 +671,7 @@ func (lhp *Plate) dup(keep_ids bool) *Plate {
 	}
 
 	ret.PlateName = lhp.PlateName
+	ret.Cover = lhp.Cover
 
 	ret.HWells = make(map[string]*LHWell, len(ret.HWells))
 
@@ -0,0 +1,52 @@
+package wtype
+
+// A PlateCover is what, if anything, covers the wells of a plate
+type PlateCover string
+
+const (
+	// Uncovered is a plate with open wells
+	Uncovered PlateCover = ""
+	// Sealed is a plate covered by a seal, which must be peeled off before
+	// the wells can be accessed
+	Sealed PlateCover = "sealed"
+	// Lidded is a plate covered by a lid, which must be removed before the
+	// wells can be accessed
+	Lidded PlateCover = "lidded"
+)
+
+func (a PlateCover) String() string {
+	if a == Uncovered {
+		return "uncovered"
+	}
+	return string(a)
+}
+
+// EvaporationFactor returns the rate of evaporation from wells with this
+// cover as a fraction of the rate from open wells
+func (a PlateCover) EvaporationFactor() float64 {
+	switch a {
+	case Sealed:
+		return 0.0
+	case Lidded:
+		// Lids are not airtight but greatly reduce airflow over the wells
+		return 0.1
+	default:
+		return 1.0
+	}
+}
+
+// IsSealed returns true if the plate is sealed
+func (lhp *Plate) IsSealed() bool {
+	return lhp.Cover == Sealed
+}
+
+// IsLidded returns true if the plate has a lid on
+func (lhp *Plate) IsLidded() bool {
+	return lhp.Cover == Lidded
+}
+
+// IsCovered returns true if the wells of the plate cannot be accessed
+// without first removing a seal or lid
+func (lhp *Plate) IsCovered() bool {
+	return lhp.Cover != Uncovered
+}
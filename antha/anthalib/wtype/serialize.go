@@ -110,6 +110,7 @@ type SLHPlate struct {
 	WellXStart  float64 // offset (mm) to first well in X direction
 	WellYStart  float64 // offset (mm) to first well in Y direction
 	WellZStart  float64 // offset (mm) to bottom of well in Z direction
+	Cover       PlateCover
 }
 
 func (p *Plate) ToSLHPLate() SLHPlate {
@@ -131,6 +132,7 @@ func (p *Plate) ToSLHPLate() SLHPlate {
 		WellXStart:  p.WellXStart,
 		WellYStart:  p.WellYStart,
 		WellZStart:  p.WellZStart,
+		Cover:       p.Cover,
 	}
 }
 
@@ -156,6 +158,7 @@ func (slhp SLHPlate) FillPlate(plate *Plate) {
 	plate.WellXStart = slhp.WellXStart
 	plate.WellYStart = slhp.WellYStart
 	plate.WellZStart = slhp.WellZStart
+	plate.Cover = slhp.Cover
 	makeRows(plate)
 	makeCols(plate)
 	plate.HWells = make(map[string]*LHWell, len(plate.Wellcoords))
@@ -0,0 +1,125 @@
+package wtype
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+const (
+	// DefaultThermocycleRampRate is the rate in ℃ per second at which
+	// temperatures are assumed to change when a step does not give one
+	DefaultThermocycleRampRate = 3.0
+	// ambientTemp in ℃ is the assumed temperature at the start of a programme
+	ambientTemp = 25.0
+)
+
+// A ThermocycleStep holds a temperature for a time
+type ThermocycleStep struct {
+	// Temperature to hold
+	Temp wunit.Temperature
+	// Time for which to hold Temp
+	Time wunit.Time
+	// Rate in ℃ per second at which to change to Temp. If zero, the
+	// device maximum.
+	RampRate float64
+}
+
+// A ThermocycleStage is a sequence of steps repeated a number of times
+type ThermocycleStage struct {
+	// Number of times to run the steps. Zero is treated as one.
+	Cycles int
+	Steps  []ThermocycleStep
+}
+
+// A ThermocycleProgramme is a thermocycling protocol, e.g., for PCR
+type ThermocycleProgramme struct {
+	// Temperature of the heated lid, if any
+	LidTemp wunit.Temperature
+	Stages  []ThermocycleStage
+	// Temperature at which to hold samples after the last stage until they
+	// are removed, if any
+	HoldTemp wunit.Temperature
+}
+
+func (a ThermocycleStage) cycles() int {
+	if a.Cycles <= 0 {
+		return 1
+	}
+	return a.Cycles
+}
+
+// Validate returns an error if the programme cannot be run
+func (a ThermocycleProgramme) Validate() error {
+	if len(a.Stages) == 0 {
+		return fmt.Errorf("thermocycle programme has no stages")
+	}
+	for sidx, stage := range a.Stages {
+		if len(stage.Steps) == 0 {
+			return fmt.Errorf("stage %d of thermocycle programme has no steps", sidx+1)
+		}
+		for idx, step := range stage.Steps {
+			switch {
+			case step.Temp.IsNil():
+				return fmt.Errorf("step %d of stage %d of thermocycle programme has no temperature", idx+1, sidx+1)
+			case step.Time.IsNil() || step.Time.IsNegative():
+				return fmt.Errorf("step %d of stage %d of thermocycle programme has no time", idx+1, sidx+1)
+			case step.RampRate < 0:
+				return fmt.Errorf("step %d of stage %d of thermocycle programme has negative ramp rate", idx+1, sidx+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Duration returns an estimate of the time taken to run the programme,
+// excluding the final hold
+func (a ThermocycleProgramme) Duration() time.Duration {
+	var secs float64
+	temp := ambientTemp
+	for _, stage := range a.Stages {
+		for cycle := 0; cycle < stage.cycles(); cycle++ {
+			for _, step := range stage.Steps {
+				if step.Temp.IsNil() || step.Time.IsNil() {
+					continue
+				}
+				rate := step.RampRate
+				if rate <= 0 {
+					rate = DefaultThermocycleRampRate
+				}
+				next := step.Temp.SIValue()
+				secs += math.Abs(next-temp)/rate + step.Time.Seconds()
+				temp = next
+			}
+		}
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// String returns a human readable description of the programme
+func (a ThermocycleProgramme) String() string {
+	var stages []string
+	for _, stage := range a.Stages {
+		var steps []string
+		for _, step := range stage.Steps {
+			steps = append(steps, fmt.Sprintf("%s for %s", step.Temp.ToString(), step.Time.ToString()))
+		}
+		s := strings.Join(steps, ", ")
+		if stage.cycles() > 1 {
+			s = fmt.Sprintf("%d cycles of (%s)", stage.cycles(), s)
+		}
+		stages = append(stages, s)
+	}
+
+	s := strings.Join(stages, "; then ")
+	if !a.HoldTemp.IsNil() {
+		s += fmt.Sprintf("; then hold at %s", a.HoldTemp.ToString())
+	}
+	if !a.LidTemp.IsNil() {
+		s += fmt.Sprintf(" (lid at %s)", a.LidTemp.ToString())
+	}
+	return s
+}
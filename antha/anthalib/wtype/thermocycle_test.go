@@ -0,0 +1,61 @@
+package wtype
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+func makeThermocycleProgramme() ThermocycleProgramme {
+	return ThermocycleProgramme{
+		LidTemp: wunit.NewTemperature(105, "C"),
+		Stages: []ThermocycleStage{
+			{
+				Cycles: 2,
+				Steps: []ThermocycleStep{
+					{Temp: wunit.NewTemperature(85, "C"), Time: wunit.NewTime(10, "s")},
+					{Temp: wunit.NewTemperature(55, "C"), Time: wunit.NewTime(20, "s")},
+				},
+			},
+		},
+		HoldTemp: wunit.NewTemperature(4, "C"),
+	}
+}
+
+func TestThermocycleDuration(t *testing.T) {
+	prog := makeThermocycleProgramme()
+	// Ramps of 60, 30, 30 and 30 degrees at the default rate plus 60 s of steps
+	if expected, got := 110*time.Second, prog.Duration(); expected != got {
+		t.Errorf("expected %s got %s", expected, got)
+	}
+
+	prog.Stages[0].Steps[0].RampRate = 6.0
+	if expected, got := 95*time.Second, prog.Duration(); expected != got {
+		t.Errorf("expected %s got %s", expected, got)
+	}
+}
+
+func TestThermocycleValidate(t *testing.T) {
+	prog := makeThermocycleProgramme()
+	if err := prog.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	prog.Stages[0].Steps[1].Time = wunit.Time{}
+	if err := prog.Validate(); err == nil {
+		t.Error("expected error for step without time")
+	}
+
+	if err := (ThermocycleProgramme{}).Validate(); err == nil {
+		t.Error("expected error for programme without stages")
+	}
+}
+
+func TestThermocycleString(t *testing.T) {
+	prog := makeThermocycleProgramme()
+	expected := "2 cycles of (85 ℃ for 10 s, 55 ℃ for 20 s); then hold at 4 ℃ (lid at 105 ℃)"
+	if got := prog.String(); got != expected {
+		t.Errorf("expected %q got %q", expected, got)
+	}
+}
@@ -279,6 +279,22 @@ func (m *Force) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+func (m Acceleration) MarshalJSON() ([]byte, error) {
+	return marshal(m)
+}
+
+func (m *Acceleration) UnmarshalJSON(b []byte) error {
+	if value, unit, err := unmarshal(b); err != nil {
+		return err
+	} else if unit != "" {
+		*m = NewAcceleration(value, unit)
+	} else {
+		cm := ConcreteMeasurement{0, nil}
+		*m = Acceleration{&cm}
+	}
+	return nil
+}
+
 func (m Velocity) MarshalJSON() ([]byte, error) {
 	return marshal(m)
 }
@@ -166,6 +166,12 @@ func getSystemUnits() baseUnits {
 				Exponent: 1,
 			},
 		},
+		"Acceleration": {
+			{
+				Name:   "meters per second squared",
+				Symbol: "m/s^2",
+			},
+		},
 		"Velocity": {
 			{
 				Name:     "meters per second",
@@ -363,6 +369,14 @@ func getSystemDerivedUnits() derivedUnits {
 				TargetScale:  2.0 * math.Pi / 60.0,
 			},
 		},
+		"Acceleration": {
+			{
+				Name:         "standard gravities",
+				Symbol:       "xg",
+				TargetSymbol: "m/s^2",
+				TargetScale:  9.80665,
+			},
+		},
 		"Pressure": {
 			{
 				Name:         "bar",
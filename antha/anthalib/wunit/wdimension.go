@@ -396,6 +396,16 @@ func NewForce(v float64, unit string) Force {
 	return Force{NewTypedMeasurement("Force", v, unit)}
 }
 
+// an Acceleration
+type Acceleration struct {
+	*ConcreteMeasurement
+}
+
+// a new acceleration in m/s^2 or multiples of standard gravity (xg)
+func NewAcceleration(v float64, unit string) Acceleration {
+	return Acceleration{NewTypedMeasurement("Acceleration", v, unit)}
+}
+
 // a Pressure structure
 type Pressure struct {
 	*ConcreteMeasurement
@@ -409,6 +409,32 @@ func TestNewForce(t *testing.T) {
 	})
 }
 
+func TestNewAcceleration(t *testing.T) {
+	NewMeasurementTests{
+		{
+			Value:            1.0,
+			Unit:             "m/s^2",
+			ExpectedSIValue:  1.0,
+			ExpectedBaseUnit: "m/s^2",
+			ExpectedPrefix:   "",
+		},
+		{
+			Value:            1000.0,
+			Unit:             "xg",
+			ExpectedSIValue:  9806.65,
+			ExpectedBaseUnit: "m/s^2",
+			ExpectedPrefix:   "",
+		},
+		{
+			Value:       1.0,
+			Unit:        "g",
+			ShouldPanic: true,
+		},
+	}.Run(t, func(v float64, u string) Measurement {
+		return NewAcceleration(v, u)
+	})
+}
+
 func TestNewPressure(t *testing.T) {
 	NewMeasurementTests{
 		{
@@ -25,9 +25,11 @@ package compile
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/user"
 	"path"
@@ -52,6 +54,7 @@ const (
 	elementFilename     = "element.go"
 	modelPackage        = "model"
 	modelFilename       = "model.go"
+	metadataFilename    = "metadata.json"
 )
 
 const (
@@ -166,6 +169,8 @@ type Antha struct {
 	messages []*Message
 	// Protocol name as given in Antha file
 	protocolName string
+	// Version of element as given in its metadata, if any
+	version string
 
 	root *AnthaRoot
 
@@ -204,36 +209,46 @@ func NewAntha(root *AnthaRoot) *Antha {
 	}
 
 	p.intrinsics = map[string]string{
-		"Centrifuge":    "execute.Centrifuge",
-		"Electroshock":  "execute.Electroshock",
-		"ExecuteMixes":  "execute.ExecuteMixes",
-		"Errorf":        "execute.Errorf",
-		"Incubate":      "execute.Incubate",
-		"Mix":           "execute.Mix",
-		"MixInto":       "execute.MixInto",
-		"MixNamed":      "execute.MixNamed",
-		"MixTo":         "execute.MixTo",
-		"MixerPrompt":   "execute.MixerPrompt",
-		"MixerWait":     "execute.MixerWait",
-		"NewComponent":  "execute.NewComponent",
-		"NewPlate":      "execute.NewPlate",
-		"Prompt":        "execute.Prompt",
-		"ReadEM":        "execute.ReadEM",
-		"Sample":        "execute.Sample",
-		"SetInputPlate": "execute.SetInputPlate",
-		"SplitSample":   "execute.SplitSample",
+		"Centrifuge":     "execute.Centrifuge",
+		"Electroshock":   "execute.Electroshock",
+		"ExecuteMixes":   "execute.ExecuteMixes",
+		"Errorf":         "execute.Errorf",
+		"Incubate":       "execute.Incubate",
+		"Lid":            "execute.Lid",
+		"Mix":            "execute.Mix",
+		"MixInto":        "execute.MixInto",
+		"MixIntoLayout":  "execute.MixIntoLayout",
+		"MixNamed":       "execute.MixNamed",
+		"MixTo":          "execute.MixTo",
+		"MixerPrompt":    "execute.MixerPrompt",
+		"MixerWait":      "execute.MixerWait",
+		"NewComponent":   "execute.NewComponent",
+		"NewPlate":       "execute.NewPlate",
+		"Peel":           "execute.Peel",
+		"Prompt":         "execute.Prompt",
+		"ReadEM":         "execute.ReadEM",
+		"Sample":         "execute.Sample",
+		"Seal":           "execute.Seal",
+		"SerialDilution": "execute.SerialDilution",
+		"SetInputPlate":  "execute.SetInputPlate",
+		"SplitSample":    "execute.SplitSample",
+		"Thermocycle":    "execute.Thermocycle",
+		"Unlid":          "execute.Unlid",
 	}
 
 	p.types = map[string]string{
+		"Acceleration":         "wunit.Acceleration",
 		"Amount":               "wunit.Amount",
 		"Angle":                "wunit.Angle",
 		"AngularVelocity":      "wunit.AngularVelocity",
 		"Area":                 "wunit.Area",
 		"Capacitance":          "wunit.Capacitance",
+		"CentrifugeOpt":        "execute.CentrifugeOpt",
 		"Concentration":        "wunit.Concentration",
 		"DNASequence":          "wtype.DNASequence",
 		"Density":              "wunit.Density",
 		"DeviceMetadata":       "api.DeviceMetadata",
+		"DilutionLayout":       "execute.DilutionLayout",
 		"Energy":               "wunit.Energy",
 		"File":                 "wtype.File",
 		"FlowRate":             "wunit.FlowRate",
@@ -250,6 +265,7 @@ func NewAntha(root *AnthaRoot) *Antha {
 		"LiquidType":           "wtype.LiquidType",
 		"Mass":                 "wunit.Mass",
 		"Moles":                "wunit.Moles",
+		"OutputLayout":         "wtype.OutputLayout",
 		"PolicyName":           "wtype.PolicyName",
 		"Plate":                "wtype.Plate",
 		"Pressure":             "wunit.Pressure",
@@ -258,6 +274,9 @@ func NewAntha(root *AnthaRoot) *Antha {
 		"SpecificHeatCapacity": "wunit.SpecificHeatCapacity",
 		"SubstanceQuantity":    "wunit.SubstanceQuantity",
 		"Temperature":          "wunit.Temperature",
+		"ThermocycleProgramme": "wtype.ThermocycleProgramme",
+		"ThermocycleStage":     "wtype.ThermocycleStage",
+		"ThermocycleStep":      "wtype.ThermocycleStep",
 		"Time":                 "wunit.Time",
 		"Velocity":             "wunit.Velocity",
 		"Voltage":              "wunit.Voltage",
@@ -553,6 +572,10 @@ func (p *Antha) Transform(fileSet *token.FileSet, src *ast.File) (err error) {
 		return fmt.Errorf("%s: expecting protocol %s to be in directory %s", file.Name(), e, f)
 	}
 
+	if p.version, err = readElementVersion(filepath.Dir(p.elementPath)); err != nil {
+		return err
+	}
+
 	p.recordImports(src.Decls)
 	p.recordBlocks(src.Decls)
 	p.recordMessages(src.Decls)
@@ -567,6 +590,26 @@ func (p *Antha) Transform(fileSet *token.FileSet, src *ast.File) (err error) {
 	return
 }
 
+// readElementVersion returns the version given in the metadata of the element
+// in dir, or the empty string if the element is unversioned
+func readElementVersion(dir string) (string, error) {
+	filename := filepath.Join(dir, metadataFilename)
+	bs, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	var md struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(bs, &md); err != nil {
+		return "", fmt.Errorf("%s: %s", filename, err)
+	}
+	return md.Version, nil
+}
+
 func (p *Antha) addImportReq(req *importReq) {
 	name := req.Name
 	if len(name) == 0 {
@@ -1221,6 +1264,7 @@ func _newRunner() interface{} {
 		},
 		In: &{{ .ModelPackage }}.Input{},
 		Out: &{{ .ModelPackage }}.Output{},
+		Meta: _metadata,
 	}
 }
 
@@ -1245,6 +1289,7 @@ func GetComponent() []*component.Component{
 	return []*component.Component{ 
 			&component.Component{
 			Name: {{ .ElementName }},
+			Tag: {{ .Tag }},
 			Stage: api.ElementStage_STEPS,
 			Constructor: _newRunner,
 			Description: component.Description{
@@ -1262,6 +1307,7 @@ func GetComponent() []*component.Component{
 		},
 			&component.Component{
 			Name: {{ .ElementName }},
+			Tag: {{ .Tag }},
 			Stage: api.ElementStage_ANALYSIS,
 			Constructor: _newAVRunner,
 			Description: component.Description{
@@ -1299,6 +1345,7 @@ func init() {
 		GeneratedPath string
 		ModelPackage  string
 		ElementName   string
+		Tag           string
 		SHA256        string
 		Desc          string
 		Path          string
@@ -1316,6 +1363,7 @@ func init() {
 		GeneratedPath: path.Join(p.protocolName, elementPackage, elementFilename),
 		ModelPackage:  modelPackage,
 		ElementName:   strconv.Quote(p.protocolName),
+		Tag:           strconv.Quote(p.version),
 		SHA256:        encodeByteArray(p.SourceSHA256),
 		Desc:          strconv.Quote(p.description),
 		Path:          strconv.Quote(elementPath),
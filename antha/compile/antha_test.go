@@ -24,12 +24,20 @@ package compile
 
 import (
 	"bytes"
+	"context"
+	goast "go/ast"
+	goparser "go/parser"
+	gotoken "go/token"
+	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/antha-lang/antha/antha/ast"
 	"github.com/antha-lang/antha/antha/parser"
 	"github.com/antha-lang/antha/antha/token"
+	"github.com/antha-lang/antha/inject"
 )
 
 func TestTypeSugaring(t *testing.T) {
@@ -97,3 +105,116 @@ func TestRelativeToGoPath(t *testing.T) {
 		}
 	}
 }
+
+const versionedElement = `protocol Versioned
+
+Parameters {
+	X int
+}
+
+Steps {
+}
+`
+
+// compileVersionedElement compiles an element with the given version in its
+// metadata and returns the tags of the components it generates
+func compileVersionedElement(t *testing.T, version string) []string {
+	dir, err := ioutil.TempDir("", "antha-compile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	elemDir := filepath.Join(dir, "Versioned")
+	if err := os.Mkdir(elemDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if version != "" {
+		md := []byte(`{"name": "Versioned", "version": ` + strconv.Quote(version) + `}`)
+		if err := ioutil.WriteFile(filepath.Join(elemDir, metadataFilename), md, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, filepath.Join(elemDir, "Versioned.an"), versionedElement, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	antha := NewAntha(NewAnthaRoot("elements"))
+	if err := antha.Transform(fileSet, file); err != nil {
+		t.Fatal(err)
+	}
+	files, err := antha.Generate(fileSet, file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tags []string
+	for _, f := range files.Files() {
+		if f.Name != "Versioned/element/element.go" {
+			continue
+		}
+		gofile, err := goparser.ParseFile(gotoken.NewFileSet(), f.Name, f.Data, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goast.Inspect(gofile, func(n goast.Node) bool {
+			kv, ok := n.(*goast.KeyValueExpr)
+			if !ok {
+				return true
+			}
+			if key, ok := kv.Key.(*goast.Ident); !ok || key.Name != "Tag" {
+				return true
+			}
+			if lit, ok := kv.Value.(*goast.BasicLit); ok {
+				tag, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				tags = append(tags, tag)
+			}
+			return false
+		})
+	}
+	return tags
+}
+
+func TestElementVersion(t *testing.T) {
+	ctx := inject.NewContext(context.Background())
+	for _, version := range []string{"1.0.0", "1.2.0", "2.0.0", ""} {
+		tags := compileVersionedElement(t, version)
+		if len(tags) != 2 {
+			t.Fatalf("version %q: expecting steps and analysis components but found tags %q", version, tags)
+		}
+		for _, tag := range tags {
+			if tag != version {
+				t.Errorf("expecting component with version %q but found %q", version, tag)
+			}
+		}
+
+		tag := tags[0]
+		if err := inject.Add(ctx, inject.Name{Repo: "Versioned", Tag: tag}, &inject.FuncRunner{
+			RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+				return inject.Value{"Tag": tag}, nil
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for query, golden := range map[string]string{
+		"":       "2.0.0",
+		"latest": "2.0.0",
+		"^1.0.0": "1.2.0",
+		"~1.0.0": "1.0.0",
+		"1.2.0":  "1.2.0",
+	} {
+		if out, err := inject.Call(ctx, inject.NameQuery{Repo: "Versioned", Tag: query}, nil); err != nil {
+			t.Errorf("%q: %s", query, err)
+		} else if out["Tag"] != golden {
+			t.Errorf("%q: expecting %q but got %q", query, golden, out["Tag"])
+		}
+	}
+}
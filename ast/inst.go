@@ -2,7 +2,9 @@ package ast
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 )
 
@@ -62,6 +64,84 @@ type IncubateInst struct {
 	PreShakeRadius wunit.Length
 }
 
+// A CentrifugeInst is a high-level command to centrifuge a component
+type CentrifugeInst struct {
+	// Relative centrifugal force at which to spin component
+	Force wunit.Acceleration
+	// Time for which to spin component at speed
+	Time wunit.Time
+	// Temperature at which to spin component, if any
+	Temp wunit.Temperature
+	// Time to reach speed, if not the device default
+	AccelTime wunit.Time
+	// Time to stop, if not the device default
+	DecelTime wunit.Time
+}
+
+// String returns a human readable description of the command
+func (a *CentrifugeInst) String() string {
+	s := fmt.Sprintf("spin at %s for %s", a.Force.ToString(), a.Time.ToString())
+	if !a.Temp.IsNil() {
+		s += fmt.Sprintf(" at %s", a.Temp.ToString())
+	}
+	return s
+}
+
+// A ThermocycleInst is a high-level command to run a thermocycling programme
+// on a component
+type ThermocycleInst struct {
+	// Component to thermocycle
+	ComponentIn *wtype.Liquid
+	// Programme to run
+	Programme wtype.ThermocycleProgramme
+}
+
+// A CoverAction is a change to the seal or lid of a plate
+type CoverAction string
+
+// Changes to the seal or lid of a plate
+const (
+	SealAction  CoverAction = "seal"
+	PeelAction  CoverAction = "peel"
+	LidAction   CoverAction = "lid"
+	UnlidAction CoverAction = "unlid"
+)
+
+// Cover returns the cover of a plate after the action
+func (a CoverAction) Cover() wtype.PlateCover {
+	switch a {
+	case SealAction:
+		return wtype.Sealed
+	case LidAction:
+		return wtype.Lidded
+	default:
+		return wtype.Uncovered
+	}
+}
+
+// A CoverInst is a high-level command to seal, peel, lid or unlid the plate
+// holding a component
+type CoverInst struct {
+	// Component on the plate
+	ComponentIn *wtype.Liquid
+	// Change to make to the plate
+	Action CoverAction
+}
+
+func (a *CoverInst) String() string {
+	switch a.Action {
+	case SealAction:
+		return fmt.Sprintf("seal the plate holding %s", a.ComponentIn.CName)
+	case PeelAction:
+		return fmt.Sprintf("peel the seal from the plate holding %s", a.ComponentIn.CName)
+	case LidAction:
+		return fmt.Sprintf("put a lid on the plate holding %s", a.ComponentIn.CName)
+	case UnlidAction:
+		return fmt.Sprintf("remove the lid from the plate holding %s", a.ComponentIn.CName)
+	}
+	return fmt.Sprintf("%s the plate holding %s", a.Action, a.ComponentIn.CName)
+}
+
 // A PromptInst is a high-level command to prompt a human
 type PromptInst struct {
 	Message string
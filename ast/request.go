@@ -1,5 +1,14 @@
 package ast
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
 // A NameValue is a name-value pair
 type NameValue struct {
 	Name  string
@@ -9,6 +18,31 @@ type NameValue struct {
 // A Request is set of required device capabilities
 type Request struct {
 	Selector []NameValue
+	// Typed capabilities that a device must have, e.g., to reach a
+	// temperature
+	Capabilities []Capability
+}
+
+// UnmarshalJSON implements a json.Unmarshaler. Capabilities are decoded by
+// their kind.
+func (reqA *Request) UnmarshalJSON(bs []byte) error {
+	var r struct {
+		Selector     []NameValue
+		Capabilities []json.RawMessage
+	}
+	if err := json.Unmarshal(bs, &r); err != nil {
+		return err
+	}
+
+	*reqA = Request{Selector: r.Selector}
+	for _, bs := range r.Capabilities {
+		c, err := unmarshalCapability(bs)
+		if err != nil {
+			return err
+		}
+		reqA.Capabilities = append(reqA.Capabilities, c)
+	}
+	return nil
 }
 
 func makeNameValueMap(vs []NameValue) map[interface{}]int {
@@ -37,6 +71,334 @@ func (reqA Request) Contains(reqB Request) bool {
 func Meet(reqs ...Request) (req Request) {
 	for _, r := range reqs {
 		req.Selector = append(req.Selector, r.Selector...)
+		req.Capabilities = append(req.Capabilities, r.Capabilities...)
 	}
 	return
 }
+
+// A Capability is a typed property of a device, e.g., the range of
+// temperatures that it can reach. Devices advertise the capabilities that
+// they have, and requests hold the capabilities that they need.
+type Capability interface {
+	// CapabilityName returns the name of the property, e.g., "temperature"
+	CapabilityName() string
+	// Covers returns an error if this capability, as advertised by a device,
+	// does not cover the needed capability of the same name
+	Covers(need Capability) error
+	String() string
+}
+
+var (
+	_ Capability = RangeCapability{}
+	_ Capability = SetCapability{}
+	_ Capability = BoolCapability{}
+)
+
+// Kinds of capability in their serialized form
+const (
+	rangeKind = "range"
+	setKind   = "set"
+	boolKind  = "bool"
+)
+
+// A savedMeasurement is the serialized form of a measurement
+type savedMeasurement struct {
+	Value float64
+	Unit  string
+}
+
+func saveMeasurement(m wunit.Measurement) *savedMeasurement {
+	if !isSet(m) {
+		return nil
+	}
+	return &savedMeasurement{
+		Value: m.RawValue(),
+		Unit:  m.Unit().PrefixedSymbol(),
+	}
+}
+
+func (a *savedMeasurement) load() (wunit.Measurement, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return wunit.GetGlobalUnitRegistry().NewMeasurement(a.Value, a.Unit)
+}
+
+// unmarshalCapability decodes a capability according to its kind
+func unmarshalCapability(bs []byte) (Capability, error) {
+	var c struct {
+		Kind   string
+		Name   string
+		Min    *savedMeasurement
+		Max    *savedMeasurement
+		Values []string
+		Value  bool
+	}
+	if err := json.Unmarshal(bs, &c); err != nil {
+		return nil, err
+	}
+
+	switch c.Kind {
+	case rangeKind:
+		min, err := c.Min.load()
+		if err != nil {
+			return nil, err
+		}
+		max, err := c.Max.load()
+		if err != nil {
+			return nil, err
+		}
+		return RangeCapability{Name: c.Name, Min: min, Max: max}, nil
+	case setKind:
+		return SetCapability{Name: c.Name, Values: c.Values}, nil
+	case boolKind:
+		return BoolCapability{Name: c.Name, Value: c.Value}, nil
+	default:
+		return nil, fmt.Errorf("unknown kind of capability %q", c.Kind)
+	}
+}
+
+// isSet returns true if a measurement has a value
+func isSet(m wunit.Measurement) bool {
+	if m == nil {
+		return false
+	}
+	if n, ok := m.(interface {
+		IsNil() bool
+	}); ok {
+		return !n.IsNil()
+	}
+	return true
+}
+
+// A RangeCapability is an inclusive range of measurements. Either end may be
+// unset, in which case the range is open at that end. A single value is a
+// range with equal ends.
+type RangeCapability struct {
+	Name string
+	Min  wunit.Measurement
+	Max  wunit.Measurement
+}
+
+// NewValueCapability returns the capability to reach a single value
+func NewValueCapability(name string, v wunit.Measurement) RangeCapability {
+	return RangeCapability{Name: name, Min: v, Max: v}
+}
+
+// NewValueCapabilities returns the capabilities to reach each of a set of
+// values. Unset values need nothing.
+func NewValueCapabilities(name string, vs ...wunit.Measurement) (caps []Capability) {
+	for _, v := range vs {
+		if isSet(v) {
+			caps = append(caps, NewValueCapability(name, v))
+		}
+	}
+	return
+}
+
+// CapabilityName implements a Capability
+func (a RangeCapability) CapabilityName() string {
+	return a.Name
+}
+
+// MarshalJSON implements a json.Marshaler
+func (a RangeCapability) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind string
+		Name string
+		Min  *savedMeasurement `json:",omitempty"`
+		Max  *savedMeasurement `json:",omitempty"`
+	}{
+		Kind: rangeKind,
+		Name: a.Name,
+		Min:  saveMeasurement(a.Min),
+		Max:  saveMeasurement(a.Max),
+	})
+}
+
+func (a RangeCapability) String() string {
+	toString := func(m wunit.Measurement) string {
+		if !isSet(m) {
+			return ""
+		}
+		return m.ToString()
+	}
+
+	min, max := toString(a.Min), toString(a.Max)
+	if min == max {
+		return fmt.Sprintf("%s %s", a.Name, min)
+	}
+	return fmt.Sprintf("%s %s-%s", a.Name, min, max)
+}
+
+// compare returns -1, 0 or 1 as x is less than, equal to or greater than y
+func compare(x, y wunit.Measurement) (int, error) {
+	xInY, err := x.InUnit(y.Unit())
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case xInY.EqualTo(y):
+		return 0, nil
+	case xInY.RawValue() < y.RawValue():
+		return -1, nil
+	default:
+		return 1, nil
+	}
+}
+
+// Covers implements a Capability
+func (a RangeCapability) Covers(need Capability) error {
+	r, ok := need.(RangeCapability)
+	if !ok {
+		return fmt.Errorf("%s: expecting a range found %s", a.Name, need)
+	}
+
+	for _, v := range []wunit.Measurement{r.Min, r.Max} {
+		if !isSet(v) {
+			continue
+		}
+		if isSet(a.Min) {
+			if c, err := compare(v, a.Min); err != nil {
+				return fmt.Errorf("%s: %s", a.Name, err)
+			} else if c < 0 {
+				return fmt.Errorf("%s outside of %s", need, a)
+			}
+		}
+		if isSet(a.Max) {
+			if c, err := compare(v, a.Max); err != nil {
+				return fmt.Errorf("%s: %s", a.Name, err)
+			} else if c > 0 {
+				return fmt.Errorf("%s outside of %s", need, a)
+			}
+		}
+	}
+	return nil
+}
+
+// A SetCapability is a set of allowed values, e.g., the plate footprints that
+// a device accepts
+type SetCapability struct {
+	Name   string
+	Values []string
+}
+
+// CapabilityName implements a Capability
+func (a SetCapability) CapabilityName() string {
+	return a.Name
+}
+
+// MarshalJSON implements a json.Marshaler
+func (a SetCapability) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind   string
+		Name   string
+		Values []string
+	}{
+		Kind:   setKind,
+		Name:   a.Name,
+		Values: a.Values,
+	})
+}
+
+func (a SetCapability) String() string {
+	return fmt.Sprintf("%s {%s}", a.Name, strings.Join(a.Values, ", "))
+}
+
+// Covers implements a Capability
+func (a SetCapability) Covers(need Capability) error {
+	s, ok := need.(SetCapability)
+	if !ok {
+		return fmt.Errorf("%s: expecting a set found %s", a.Name, need)
+	}
+
+	have := make(map[string]bool)
+	for _, v := range a.Values {
+		have[v] = true
+	}
+	var missing []string
+	for _, v := range s.Values {
+		if !have[v] {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("%s %s not in %s", a.Name, strings.Join(missing, ", "), a)
+	}
+	return nil
+}
+
+// A BoolCapability is a feature that a device has or not, e.g., a heated
+// lid. Needing a false feature is always covered.
+type BoolCapability struct {
+	Name  string
+	Value bool
+}
+
+// CapabilityName implements a Capability
+func (a BoolCapability) CapabilityName() string {
+	return a.Name
+}
+
+// MarshalJSON implements a json.Marshaler
+func (a BoolCapability) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind  string
+		Name  string
+		Value bool
+	}{
+		Kind:  boolKind,
+		Name:  a.Name,
+		Value: a.Value,
+	})
+}
+
+func (a BoolCapability) String() string {
+	return fmt.Sprintf("%s %t", a.Name, a.Value)
+}
+
+// Covers implements a Capability
+func (a BoolCapability) Covers(need Capability) error {
+	b, ok := need.(BoolCapability)
+	if !ok {
+		return fmt.Errorf("%s: expecting a boolean found %s", a.Name, need)
+	}
+	if b.Value && !a.Value {
+		return fmt.Errorf("%s not available", a.Name)
+	}
+	return nil
+}
+
+// Satisfies returns an error if a set of advertised capabilities does not
+// cover the capabilities needed by a request. Capabilities that are not
+// advertised are assumed to be available.
+func Satisfies(have []Capability, req Request) error {
+	byName := make(map[string][]Capability)
+	for _, c := range have {
+		byName[c.CapabilityName()] = append(byName[c.CapabilityName()], c)
+	}
+
+	var errs []string
+	for _, need := range req.Capabilities {
+		cs := byName[need.CapabilityName()]
+		if len(cs) == 0 {
+			continue
+		}
+		// Any advertised capability of the name will do
+		var err error
+		for _, c := range cs {
+			if err = c.Covers(need); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
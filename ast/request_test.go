@@ -1,6 +1,10 @@
 package ast
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
 
 func TestSelector(t *testing.T) {
 	reqA := Request{
@@ -34,3 +38,86 @@ func TestSelector(t *testing.T) {
 		t.Errorf("%v should contain %v", reqAB, reqB)
 	}
 }
+
+func TestCapabilities(t *testing.T) {
+	rpm := func(v float64) wunit.Rate {
+		r, err := wunit.NewRate(v, "/min")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	incubator := []Capability{
+		RangeCapability{
+			Name: "temperature",
+			Min:  wunit.NewTemperature(4, "C"),
+			Max:  wunit.NewTemperature(95, "C"),
+		},
+		RangeCapability{
+			Name: "shake rate",
+			Max:  rpm(1200),
+		},
+		SetCapability{
+			Name:   "footprint",
+			Values: []string{"SBS"},
+		},
+		BoolCapability{
+			Name: "lid",
+		},
+	}
+
+	type testCase struct {
+		Needs []Capability
+		Error bool
+	}
+
+	for _, tc := range []testCase{
+		{
+			Needs: []Capability{NewValueCapability("temperature", wunit.NewTemperature(37, "C"))},
+		},
+		{
+			Needs: []Capability{NewValueCapability("temperature", wunit.NewTemperature(95, "C"))},
+		},
+		{
+			Needs: []Capability{NewValueCapability("temperature", wunit.NewTemperature(99, "C"))},
+			Error: true,
+		},
+		{
+			Needs: []Capability{NewValueCapability("temperature", wunit.NewTemperature(0, "C"))},
+			Error: true,
+		},
+		{
+			Needs: []Capability{NewValueCapability("shake rate", rpm(600))},
+		},
+		{
+			Needs: []Capability{NewValueCapability("shake rate", rpm(1500))},
+			Error: true,
+		},
+		{
+			Needs: []Capability{SetCapability{Name: "footprint", Values: []string{"SBS"}}},
+		},
+		{
+			Needs: []Capability{SetCapability{Name: "footprint", Values: []string{"tube"}}},
+			Error: true,
+		},
+		{
+			Needs: []Capability{BoolCapability{Name: "lid"}},
+		},
+		{
+			Needs: []Capability{BoolCapability{Name: "lid", Value: true}},
+			Error: true,
+		},
+		{
+			// Capabilities that are not advertised are assumed available
+			Needs: []Capability{BoolCapability{Name: "barcode reader", Value: true}},
+		},
+	} {
+		err := Satisfies(incubator, Request{Capabilities: tc.Needs})
+		if tc.Error && err == nil {
+			t.Errorf("%v: expecting error", tc.Needs)
+		} else if !tc.Error && err != nil {
+			t.Errorf("%v: unexpected error %s", tc.Needs, err)
+		}
+	}
+}
@@ -45,17 +45,10 @@ func convertProcesses(in map[string]workflow.Process, newElementNames Conversion
 	ret := make(map[string]workflow.Process)
 
 	for k, v := range in {
-		var comp string
-
 		if v.Component == newElementNames.OldElementName {
-			comp = newElementNames.NewElementName
-		} else {
-			comp = v.Component
-		}
-		ret[k] = workflow.Process{
-			Component: comp,
-			Metadata:  v.Metadata,
+			v.Component = newElementNames.NewElementName
 		}
+		ret[k] = v
 	}
 
 	return ret
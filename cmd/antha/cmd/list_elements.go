@@ -26,9 +26,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/antha-lang/antha/cmd/antha/comp"
 	"github.com/antha-lang/antha/cmd/antha/pretty"
+	"github.com/antha-lang/antha/inject"
 	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -45,16 +47,30 @@ func listElements(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	paths := make(map[string]string)
+	type version struct {
+		Name, Tag string
+	}
+	paths := make(map[version]string)
 	comps := runComponents()
 	for _, c := range comps {
-		p, seen := paths[c.Name]
-		if seen {
+		v := version{Name: c.Name, Tag: c.Tag}
+		p, seen := paths[v]
+		if seen && c.Tag != "" {
+			return fmt.Errorf("protocol %q version %q defined in more than one file %q and %q", c.Name, c.Tag, p, c.Description.Path)
+		} else if seen {
 			return fmt.Errorf("protocol %q defined in more than one file %q and %q", c.Name, p, c.Description.Path)
 		}
-		paths[c.Name] = c.Description.Path
+		paths[v] = c.Description.Path
 	}
 
+	// Show every version of a protocol together, latest first
+	sort.SliceStable(comps, func(i, j int) bool {
+		if comps[i].Name != comps[j].Name {
+			return comps[i].Name < comps[j].Name
+		}
+		return inject.LessTag(comps[j].Tag, comps[i].Tag)
+	})
+
 	cs, err := comp.New(comps)
 	if err != nil {
 		return err
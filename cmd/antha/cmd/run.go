@@ -26,10 +26,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"os/signal"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
@@ -40,6 +43,7 @@ import (
 	"github.com/antha-lang/antha/execute/executeutil"
 	"github.com/antha-lang/antha/inject"
 	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/profile"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/auto"
 	"github.com/antha-lang/antha/target/mixer"
@@ -121,7 +125,7 @@ func makeContext() (context.Context, error) {
 		if !ok {
 			return nil, fmt.Errorf("component %q has unexpected type %T", desc.Name, obj)
 		}
-		if err := inject.Add(ctx, inject.Name{Repo: desc.Name, Stage: desc.Stage}, runner); err != nil {
+		if err := inject.Add(ctx, inject.Name{Repo: desc.Name, Tag: desc.Tag, Stage: desc.Stage}, runner); err != nil {
 			return nil, fmt.Errorf("adding protocol %q: %s", desc.Name, err)
 		}
 	}
@@ -131,7 +135,7 @@ func makeContext() (context.Context, error) {
 
 type runOpt struct {
 	MixerOpt               mixer.Opt
-	Drivers                []string
+	Drivers                []auto.Endpoint
 	BundleFile             string
 	ParametersFile         string
 	WorkflowFile           string
@@ -139,7 +143,15 @@ type runOpt struct {
 	TestBundleFileName     string
 	LayoutSummaryFile      string
 	MixSummaryFile         string
+	GanttFile              string
+	ProtocolFile           string
+	ProgressFile           string
 	RunTest                bool
+	MaxConcurrency         int
+	CheckpointFile         string
+	ResumeFile             string
+	CacheDir               string
+	ProfileFile            string
 }
 
 func (a *runOpt) Run() error {
@@ -151,11 +163,9 @@ func (a *runOpt) Run() error {
 	mixerOpt := mixer.DefaultOpt.Merge(bundle.RawParams.Config).Merge(&a.MixerOpt)
 
 	opt := auto.Opt{
+		Endpoints: a.Drivers,
 		MaybeArgs: []interface{}{mixerOpt},
 	}
-	for _, uri := range a.Drivers {
-		opt.Endpoints = append(opt.Endpoints, auto.Endpoint{URI: uri})
-	}
 
 	// Auto detect gRPC devices on network interfaces
 	t, err := auto.New(opt)
@@ -168,12 +178,42 @@ func (a *runOpt) Run() error {
 		return err
 	}
 
+	var resume *execute.Checkpoint
+	checkpointFile := a.CheckpointFile
+	if a.ResumeFile != "" {
+		if resume, err = execute.ReadCheckpoint(a.ResumeFile); err != nil {
+			return err
+		}
+		if checkpointFile == "" {
+			checkpointFile = a.ResumeFile
+		}
+	}
+
+	var cache *execute.Cache
+	if a.CacheDir != "" {
+		if cache, err = execute.NewCache(a.CacheDir); err != nil {
+			return err
+		}
+	}
+
+	ctx = profile.NewContext(ctx)
 	rout, err := execute.Run(ctx, execute.Opt{
 		Target:                     t.Target,
 		Workflow:                   &bundle.Desc,
 		Params:                     &bundle.RawParams,
 		TransitionalReadLocalFiles: true,
+		MaxConcurrency:             a.MaxConcurrency,
+		CheckpointFile:             checkpointFile,
+		Resume:                     resume,
+		Cache:                      cache,
 	})
+	if a.ProfileFile != "" {
+		// Save the profile even if execution failed to show where time
+		// was spent up to the failure
+		if perr := writeProfile(a.ProfileFile, profile.FromContext(ctx)); perr != nil && err == nil {
+			err = perr
+		}
+	}
 	if err != nil {
 		return err
 	}
@@ -211,6 +251,18 @@ func (a *runOpt) Run() error {
 		}
 	}
 
+	if a.GanttFile != "" {
+		if err := writeGantt(a.GanttFile, t, rout); err != nil {
+			return err
+		}
+	}
+
+	if a.ProtocolFile != "" {
+		if err := writeProtocol(a.ProtocolFile, t, rout); err != nil {
+			return err
+		}
+	}
+
 	// if option is set, add liquid handling instruction output
 	if a.MixInstructionFileName != "" {
 		countFiles := 1
@@ -268,13 +320,87 @@ func (a *runOpt) Run() error {
 		return err
 	}
 
-	if err := pretty.Run(os.Stdout, os.Stdin, t, rout); err != nil {
+	if err := a.runInsts(t, rout); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// runInsts runs the instructions of a workflow, resuming from the progress
+// file if there is one. Interrupts pause the run.
+func (a *runOpt) runInsts(t *auto.Auto, rout *execute.Result) error {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	if a.ProgressFile == "" {
+		return pretty.Run(os.Stdout, os.Stdin, t, rout, pretty.RunOpt{
+			Pause: interrupts,
+		})
+	}
+
+	f, err := os.OpenFile(a.ProgressFile, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	previous, err := auto.ReadProgress(f)
+	if err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	if err := pretty.Run(os.Stdout, os.Stdin, t, rout, pretty.RunOpt{
+		Log:      f,
+		Previous: previous,
+		Pause:    interrupts,
+	}); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
+
+func writeProtocol(filename string, a *auto.Auto, result *execute.Result) error {
+	format := pretty.ProtocolMarkdown
+	if ext := strings.ToLower(filepath.Ext(filename)); ext == ".html" || ext == ".htm" {
+		format = pretty.ProtocolHTML
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := pretty.Protocol(f, a, result, format); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
+
+func writeGantt(filename string, a *auto.Auto, result *execute.Result) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := pretty.GanttJSON(f, a, result); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
+
+func writeProfile(filename string, p *profile.Profile) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := p.WriteChromeTrace(f); err != nil {
+		f.Close() // nolint: errcheck
+		return err
+	}
+	return f.Close()
+}
+
 func runWorkflow(cmd *cobra.Command, args []string) error {
 	if err := viper.BindPFlags(cmd.Flags()); err != nil {
 		return err
@@ -282,13 +408,20 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 
 	ctx := testinventory.NewContext(context.Background())
 
-	var drivers []string
+	var drivers []auto.Endpoint
 	for idx, uri := range GetStringSlice("driver") {
 		u, err := url.Parse(uri)
 		if err != nil {
 			return err
 		}
 
+		// Options of the endpoint are limits of its device
+		devOpt, err := auto.ParseDeviceOpt(u.Query())
+		if err != nil {
+			return fmt.Errorf("cannot configure driver %s: %s", uri, err)
+		}
+		u.RawQuery = ""
+
 		switch u.Scheme {
 		case "go":
 			p := u.Host + u.Path
@@ -305,11 +438,11 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return fmt.Errorf("cannot parse port for package %s: %s", p, err)
 			}
-			drivers = append(drivers, uri)
+			drivers = append(drivers, auto.Endpoint{URI: uri, Arg: devOpt})
 		case "tcp":
-			drivers = append(drivers, u.Host)
+			drivers = append(drivers, auto.Endpoint{URI: u.Host, Arg: devOpt})
 		default:
-			drivers = append(drivers, u.String())
+			drivers = append(drivers, auto.Endpoint{URI: u.String(), Arg: devOpt})
 		}
 	}
 
@@ -329,9 +462,48 @@ func runWorkflow(cmd *cobra.Command, args []string) error {
 		RunTest:                viper.GetBool("runTest"),
 		LayoutSummaryFile:      viper.GetString("layoutSummary"),
 		MixSummaryFile:         viper.GetString("mixSummary"),
+		GanttFile:              viper.GetString("gantt"),
+		ProtocolFile:           viper.GetString("protocol"),
+		ProgressFile:           viper.GetString("progress"),
+		MaxConcurrency:         viper.GetInt("maxConcurrency"),
+		CheckpointFile:         viper.GetString("checkpoint"),
+		ResumeFile:             viper.GetString("resume"),
+		ProfileFile:            viper.GetString("profile"),
+	}
+	if !viper.GetBool("no-cache") {
+		opt.CacheDir = viper.GetString("cacheDir")
 	}
 
-	return opt.Run()
+	output := viper.GetString("output")
+	switch output {
+	case textOutput:
+		return opt.Run()
+	case jsonOutput:
+		err := opt.Run()
+		if err != nil {
+			if jerr := writeJSONError(os.Stdout, err); jerr != nil {
+				return jerr
+			}
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
+
+// writeJSONError writes the errors of a failed run in machine-readable form
+func writeJSONError(out io.Writer, err error) error {
+	errs := execute.ElementErrors(err)
+	if len(errs) == 0 {
+		errs = append(errs, &execute.ElementError{Message: err.Error(), Err: err})
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Errors []*execute.ElementError `json:"errors"`
+	}{
+		Errors: errs,
+	})
 }
 
 func init() {
@@ -347,6 +519,13 @@ func init() {
 	flags.Float64("residualVolumeWeight", 0.0, "Residual volume weight")
 	flags.Int("maxPlates", 0, "Maximum number of plates")
 	flags.Int("maxWells", 0, "Maximum number of wells on a plate")
+	flags.Int("maxConcurrency", 0, "Maximum number of workflow processes to run concurrently (default number of CPUs)")
+	flags.String("checkpoint", "", "Save a checkpoint to the given filename after each workflow process completes")
+	flags.String("cacheDir", "", "Reuse the results of elements with the same inputs from previous runs, saving results to the given directory")
+	flags.Bool("no-cache", false, "Do not use the cache of element results, even if cacheDir is set")
+	flags.String("output", textOutput, fmt.Sprintf("Output format of errors: one of {%s}", strings.Join([]string{textOutput, jsonOutput}, ",")))
+	flags.String("profile", "", "Save the time spent in each phase of execution to the given filename in Chrome trace event format")
+	flags.String("resume", "", "Resume a workflow from the given checkpoint (continues saving checkpoints to it unless --checkpoint is given)")
 	flags.String("bundle", "", "Input bundle with parameters and workflow together (overrides parameter and workflow arguments)")
 	flags.String("makeTestBundle", "", "Generate json format bundle for testing and put it here")
 	flags.String("mixInstructionFileName", "", "Name of instructions files to output to for mixes")
@@ -354,8 +533,11 @@ func init() {
 	flags.String("workflow", "", "Workflow definition file")
 	flags.String("mixSummary", "", "save a summary of the generated liquidhandling actions to the given filename")
 	flags.String("layoutSummary", "", "save a summary of the generated deck layout to the given filename")
+	flags.String("gantt", "", "save the estimated schedule of the workflow to the given filename as a JSON Gantt chart")
+	flags.String("protocol", "", "save a step-by-step protocol for running the workflow by hand to the given filename (HTML if it ends in .html, otherwise Markdown)")
+	flags.String("progress", "", "Append the progress of running the workflow to the given filename, skipping instructions that it records as done")
 	flags.StringSlice("component", nil, "Uris of remote components ({tcp,go}://...); use multiple flags for multiple components")
-	flags.StringSlice("driver", nil, "Uris of remote drivers ({tcp,go}://...); use multiple flags for multiple drivers")
+	flags.StringSlice("driver", nil, "Uris of remote drivers ({tcp,go}://...?option=value); use multiple flags for multiple drivers. Options are device limits: minTemp, maxTemp, maxShakeRate and maxForce, e.g., tcp://localhost:50051?maxTemp=95C")
 	flags.StringSlice("inputPlateTypes", nil, "Default input plate types (in order of preference)")
 	flags.StringSlice("inputPlates", nil, "File containing input plates")
 	flags.StringSlice("outputPlateTypes", nil, "Default output plate types (in order of preference)")
@@ -0,0 +1,78 @@
+// validate.go: Part of the Antha language
+// Copyright (C) 2018 The Antha authors. All rights reserved.
+//
+// This program is free software; you can redistribute it and/or
+// modify it under the terms of the GNU General Public License
+// as published by the Free Software Foundation; either version 2
+// of the License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program; if not, write to the Free Software
+// Foundation, Inc., 51 Franklin Street, Fifth Floor, Boston, MA  02110-1301, USA.
+//
+// For more information relating to the software or licensing issues please
+// contact license@antha-lang.org or write to the Antha team c/o
+// Synthace Ltd. The London Bioscience Innovation Centre
+// 2 Royal College St, London NW1 0NH UK
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/execute/executeutil"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the connections and parameters of an antha workflow without running it",
+	RunE:  validateWorkflow,
+}
+
+func validateWorkflow(cmd *cobra.Command, args []string) error {
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	bundle, err := executeutil.UnmarshalSingle(
+		viper.GetString("bundle"),
+		viper.GetString("workflow"),
+		viper.GetString("parameters"),
+	)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := makeContext()
+	if err != nil {
+		return err
+	}
+
+	if err := execute.Validate(ctx, execute.Opt{
+		Workflow:                   &bundle.Desc,
+		Params:                     &bundle.RawParams,
+		TransitionalReadLocalFiles: true,
+	}); err != nil {
+		return fmt.Errorf("invalid workflow:\n%s", err)
+	}
+
+	fmt.Println("OK")
+	return nil
+}
+
+func init() {
+	c := validateCmd
+	flags := c.Flags()
+	RootCmd.AddCommand(c)
+	flags.String("bundle", "", "Input bundle with parameters and workflow together (overrides parameter and workflow arguments)")
+	flags.String("parameters", "", "Parameters to workflow")
+	flags.String("workflow", "", "Workflow definition file")
+}
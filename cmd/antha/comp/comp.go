@@ -20,6 +20,7 @@ type Port struct {
 type Component struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
 	Description string `json:"description"`
 	Path        string `json:"path"`
 	InPorts     []Port `json:"in_ports"`
@@ -33,6 +34,7 @@ func New(lib []component.Component) ([]Component, error) {
 		c := Component{
 			ID:          v.Name,
 			Name:        v.Name,
+			Version:     v.Tag,
 			Description: v.Description.Desc,
 			Path:        v.Description.Path,
 		}
@@ -52,6 +54,9 @@ func New(lib []component.Component) ([]Component, error) {
 				return nil, fmt.Errorf("unknown parameter kind %q", p.Kind)
 			}
 		}
+		if v.Tag != "" {
+			c.ID = v.Name + "@" + v.Tag
+		}
 		cs = append(cs, c)
 	}
 	return cs, nil
@@ -13,7 +13,11 @@ func Components(out io.Writer, cs []comp.Component) error {
 	var lines []string
 
 	for _, c := range cs {
-		lines = append(lines, fmt.Sprintf("%s:\n", c.Name))
+		if c.Version != "" {
+			lines = append(lines, fmt.Sprintf("%s %s:\n", c.Name, c.Version))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s:\n", c.Name))
+		}
 		lines = append(lines, fmt.Sprintf("\tInputs:\n"))
 		for _, p := range c.InPorts {
 			lines = append(lines, fmt.Sprintf("\t\t%s %s\n", p.Name, p.Type))
@@ -0,0 +1,79 @@
+package pretty
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/auto"
+)
+
+// A GanttTask is a scheduled instruction. Times are in seconds from the start
+// of the schedule.
+type GanttTask struct {
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Device    string  `json:"device,omitempty"`
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+	DependsOn []int   `json:"dependsOn,omitempty"`
+	Critical  bool    `json:"critical,omitempty"`
+}
+
+// A Gantt is a chart of the estimated schedule of a workflow
+type Gantt struct {
+	// Time in seconds until the last task ends
+	Makespan float64 `json:"makespan"`
+	// IDs of the tasks on the critical path, in order
+	CriticalPath []int       `json:"criticalPath"`
+	Tasks        []GanttTask `json:"tasks"`
+}
+
+// MakeGantt returns a Gantt chart of a schedule
+func MakeGantt(a *auto.Auto, schedule *target.Schedule) *Gantt {
+	ids := make(map[*target.Job]int)
+	for i, j := range schedule.Jobs {
+		ids[j] = i
+	}
+
+	g := &Gantt{
+		Makespan:     schedule.Makespan.Seconds(),
+		CriticalPath: []int{},
+		Tasks:        []GanttTask{},
+	}
+	for i, j := range schedule.Jobs {
+		task := GanttTask{
+			ID:       i,
+			Name:     a.Pretty(j.Inst),
+			Start:    j.Start.Seconds(),
+			End:      j.End.Seconds(),
+			Critical: j.Critical,
+		}
+		if j.Device != nil {
+			task.Device = a.Target.Name(j.Device)
+		}
+		for _, dep := range j.Inst.DependsOn() {
+			if dj := schedule.Job(dep); dj != nil {
+				task.DependsOn = append(task.DependsOn, ids[dj])
+			}
+		}
+		g.Tasks = append(g.Tasks, task)
+	}
+	for _, j := range schedule.CriticalPath {
+		g.CriticalPath = append(g.CriticalPath, ids[j])
+	}
+	return g
+}
+
+// GanttJSON writes the estimated schedule of an execute.Result as a JSON
+// Gantt chart
+func GanttJSON(out io.Writer, a *auto.Auto, result *execute.Result) error {
+	schedule, err := getSchedule(result)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(MakeGantt(a, schedule))
+}
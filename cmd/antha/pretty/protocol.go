@@ -0,0 +1,362 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/auto"
+)
+
+// Formats of protocols
+const (
+	ProtocolMarkdown = "markdown"
+	ProtocolHTML     = "html"
+)
+
+// A protocolTable is a table of text
+type protocolTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+// A protocolStep is a numbered step of a protocol
+type protocolStep struct {
+	Number int
+	// Estimated start from the beginning of the protocol
+	Start string
+	Title string
+	Text  string
+	// Device that performs the step if it is automated
+	Device string
+	// Time to set a timer for, if any
+	Timer string
+	Table *protocolTable
+}
+
+// A plateMap shows the contents of the wells of a plate
+type plateMap struct {
+	Name  string
+	Type  string
+	Rows  int
+	Cols  int
+	wells map[wtype.WellCoords]string
+}
+
+// Grid returns the plate map as a table with a row for each row of wells
+func (a *plateMap) Grid() *protocolTable {
+	t := &protocolTable{
+		Header: []string{""},
+	}
+	for x := 0; x < a.Cols; x++ {
+		t.Header = append(t.Header, wtype.WellCoords{X: x}.ColNumString())
+	}
+	for y := 0; y < a.Rows; y++ {
+		row := []string{wtype.WellCoords{Y: y}.RowLettString()}
+		for x := 0; x < a.Cols; x++ {
+			row = append(row, a.wells[wtype.WellCoords{X: x, Y: y}])
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func (a *plateMap) add(wc wtype.WellCoords, what string) {
+	if wc.X < 0 || wc.Y < 0 {
+		return
+	}
+	if wc.X >= a.Cols {
+		a.Cols = wc.X + 1
+	}
+	if wc.Y >= a.Rows {
+		a.Rows = wc.Y + 1
+	}
+	if old := a.wells[wc]; old != "" && old != what {
+		what = old + "; " + what
+	}
+	a.wells[wc] = what
+}
+
+// A reagent is a liquid to prepare before starting a protocol
+type reagent struct {
+	Name   string
+	Volume string
+	Where  []string
+}
+
+// A protocol is a document for people to follow to run a workflow
+type protocol struct {
+	Title    string
+	Time     string
+	Reagents []*reagent
+	Plates   []*plateMap
+	Steps    []*protocolStep
+
+	plates   map[string]*plateMap
+	reagents map[string]*reagentTotal
+	made     map[string]bool // IDs of the outputs of earlier manual mixes
+}
+
+type reagentTotal struct {
+	Name   string
+	Unit   string
+	Volume float64
+	Where  []string
+}
+
+func (a *protocol) plate(name, typ string) *plateMap {
+	if p, ok := a.plates[name]; ok {
+		return p
+	}
+	p := &plateMap{
+		Name:  name,
+		Type:  typ,
+		wells: make(map[wtype.WellCoords]string),
+	}
+	a.plates[name] = p
+	a.Plates = append(a.Plates, p)
+	return p
+}
+
+func (a *protocol) addReagent(name string, volume float64, unit, where string) {
+	key := name + "\x00" + unit
+	r, ok := a.reagents[key]
+	if !ok {
+		r = &reagentTotal{Name: name, Unit: unit}
+		a.reagents[key] = r
+	}
+	r.Volume += volume
+	if where != "" {
+		r.Where = append(r.Where, where)
+	}
+}
+
+// madeEarlier returns whether a component is, or is sampled from, the output
+// of an earlier manual mix
+func (a *protocol) madeEarlier(c *wtype.Liquid) bool {
+	if a.made[c.ID] {
+		return true
+	}
+	for id := range a.made {
+		if c.HasParent(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// manualMix adds the pipetting table of a mix done by hand. Inputs that are
+// made by earlier mixes are not reagents.
+func (a *protocol) manualMix(step *protocolStep, mix *wtype.LHInstruction) {
+	plateName := mix.PlateName
+	plateType := mix.Platetype
+	if mix.OutPlate != nil {
+		plateName = mix.OutPlate.PlateName
+		plateType = mix.OutPlate.Type
+	}
+
+	step.Table = &protocolTable{
+		Header: []string{"Component", "Volume", "Plate", "Well"},
+	}
+	for _, in := range mix.Inputs {
+		vol := in.Volume()
+		volume := vol.ToString()
+		if in.Vol == 0 && in.Tvol > 0 {
+			volume = "up to " + in.TotalVolume().ToString()
+		} else if !a.madeEarlier(in) {
+			a.addReagent(in.CName, vol.RawValue(), vol.Unit().PrefixedSymbol(), "")
+		}
+		step.Table.Rows = append(step.Table.Rows, []string{in.CName, volume, plateName, mix.Welladdress})
+	}
+	for _, out := range mix.Outputs {
+		a.made[out.ID] = true
+	}
+
+	if plateName == "" || mix.Welladdress == "" {
+		return
+	}
+	p := a.plate(plateName, plateType)
+	if mix.OutPlate != nil {
+		p.Cols, p.Rows = mix.OutPlate.WellsX(), mix.OutPlate.WellsY()
+	}
+	var names []string
+	for _, out := range mix.Outputs {
+		names = append(names, out.CName)
+	}
+	p.add(wtype.MakeWellCoords(mix.Welladdress), strings.Join(names, ", "))
+}
+
+// robotMix adds the pipetting table and plates of a mix done by a liquid
+// handler
+func (a *protocol) robotMix(step *protocolStep, mix *target.Mix) error {
+	if mix.Summary == nil {
+		return nil
+	}
+	s, err := decodeMixSummary(mix.Summary.Layout, mix.Summary.Actions)
+	if err != nil {
+		return err
+	}
+
+	step.Table = &protocolTable{
+		Header: []string{"Liquid", "Volume", "From", "To", "Policy"},
+		Rows:   s.pipettingRows(),
+	}
+
+	// Inputs are what is on the deck before the mix
+	for _, item := range s.Layout.Before.plates() {
+		for col, rows := range item.Contents {
+			for row, l := range rows {
+				if l == nil || l.TotalVolume == nil {
+					continue
+				}
+				wc := wtype.WellCoords{X: col, Y: row}
+				a.addReagent(l.Name, l.TotalVolume.Value, l.TotalVolume.Unit, fmt.Sprintf("%s %s", item.Name, wc.FormatA1()))
+			}
+		}
+	}
+
+	// Outputs are what is on the deck after
+	for _, item := range s.Layout.After.plates() {
+		p := a.plate(item.Name, item.Type)
+		p.Cols, p.Rows = item.Columns, item.Rows
+		for col, rows := range item.Contents {
+			for row, l := range rows {
+				if l == nil {
+					continue
+				}
+				what := l.Name
+				if l.TotalVolume != nil {
+					what = fmt.Sprintf("%s (%s)", l.Name, l.TotalVolume)
+				}
+				p.add(wtype.WellCoords{X: col, Y: row}, what)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *protocol) addStep(machine *auto.Auto, j *target.Job) error {
+	step := &protocolStep{
+		Start: formatOffset(j.Start),
+	}
+
+	manual := func(m *target.Manual) {
+		step.Title = m.Label
+		step.Text = m.Details
+	}
+
+	switch inst := j.Inst.(type) {
+	case *target.Wait:
+		return nil
+	case *target.Manual:
+		manual(inst)
+		switch cmd := inst.Inst.(type) {
+		case *wtype.LHInstruction:
+			a.manualMix(step, cmd)
+		case *ast.IncubateInst:
+			if !cmd.Time.IsNil() {
+				step.Timer = cmd.Time.ToString()
+			}
+		}
+	case *target.Order:
+		manual(&inst.Manual)
+	case *target.PlatePrep:
+		manual(&inst.Manual)
+	case *target.SetupMixer:
+		manual(&inst.Manual)
+	case *target.SetupIncubator:
+		manual(&inst.Manual)
+	case *target.Prompt:
+		step.Title = "prompt"
+		step.Text = inst.Message
+	case *target.TimedWait:
+		step.Title = "wait"
+		step.Timer = inst.Duration.String()
+	case *target.Mix:
+		step.Title = "mix"
+		step.Device = machine.Target.Name(inst.Dev)
+		if err := a.robotMix(step, inst); err != nil {
+			return err
+		}
+	default:
+		step.Title = "run"
+		step.Text = machine.Pretty(inst)
+		if d := inst.Device(); d != nil {
+			step.Device = machine.Target.Name(d)
+		}
+	}
+
+	step.Number = len(a.Steps) + 1
+	a.Steps = append(a.Steps, step)
+	return nil
+}
+
+func (a *protocol) finish() {
+	var totals []*reagentTotal
+	for _, r := range a.reagents {
+		totals = append(totals, r)
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Name != totals[j].Name {
+			return totals[i].Name < totals[j].Name
+		}
+		return totals[i].Unit < totals[j].Unit
+	})
+	for _, r := range totals {
+		sort.Strings(r.Where)
+		a.Reagents = append(a.Reagents, &reagent{
+			Name:   r.Name,
+			Volume: fmt.Sprintf("%g %s", r.Volume, r.Unit),
+			Where:  r.Where,
+		})
+	}
+}
+
+func makeProtocol(a *auto.Auto, result *execute.Result) (*protocol, error) {
+	schedule, err := getSchedule(result)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &protocol{
+		Title:    "Protocol",
+		plates:   make(map[string]*plateMap),
+		reagents: make(map[string]*reagentTotal),
+		made:     make(map[string]bool),
+	}
+	if schedule.Makespan > 0 {
+		p.Time = schedule.Makespan.Round(time.Second).String()
+	}
+
+	for _, j := range schedule.Jobs {
+		if err := p.addStep(a, j); err != nil {
+			return nil, err
+		}
+	}
+	p.finish()
+	return p, nil
+}
+
+// Protocol writes a step-by-step protocol for people to follow to run an
+// execute.Result in the given format
+func Protocol(out io.Writer, a *auto.Auto, result *execute.Result, format string) error {
+	p, err := makeProtocol(a, result)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case ProtocolMarkdown:
+		return markdownProtocol.Execute(out, p)
+	case ProtocolHTML:
+		return htmlProtocol.Execute(out, p)
+	default:
+		return fmt.Errorf("unknown protocol format %q", format)
+	}
+}
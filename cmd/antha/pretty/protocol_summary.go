@@ -0,0 +1,172 @@
+package pretty
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// The types below decode the parts of the layout and actions summaries of
+// a mix (see target.MixSummary) that protocols show
+
+type measurementJSON struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+func (a *measurementJSON) String() string {
+	if a == nil {
+		return ""
+	}
+	return fmt.Sprintf("%g %s", a.Value, a.Unit)
+}
+
+type liquidJSON struct {
+	Name        string           `json:"name"`
+	TotalVolume *measurementJSON `json:"total_volume"`
+}
+
+type itemJSON struct {
+	ID       string                      `json:"id"`
+	Name     string                      `json:"name"`
+	Type     string                      `json:"type"`
+	Kind     string                      `json:"kind"`
+	Rows     int                         `json:"rows"`
+	Columns  int                         `json:"columns"`
+	Contents map[int]map[int]*liquidJSON `json:"contents"`
+}
+
+type deckJSON struct {
+	Positions map[string]*struct {
+		Item *itemJSON `json:"item"`
+	} `json:"positions"`
+}
+
+// plates returns the plates on a deck in order of position
+func (a *deckJSON) plates() (ret []*itemJSON) {
+	var names []string
+	for name := range a.Positions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if pos := a.Positions[name]; pos != nil && pos.Item != nil && pos.Item.Kind == "plate" {
+			ret = append(ret, pos.Item)
+		}
+	}
+	return
+}
+
+type layoutJSON struct {
+	Device string   `json:"device"`
+	Before deckJSON `json:"before"`
+	After  deckJSON `json:"after"`
+}
+
+type locationJSON struct {
+	DeckItemID string `json:"deck_item_id"`
+	Row        int    `json:"row"`
+	Column     int    `json:"col"`
+}
+
+// well returns the name of the well at a location
+func (a locationJSON) well() string {
+	return wtype.WellCoords{X: a.Column, Y: a.Row}.FormatA1()
+}
+
+type contentUpdateJSON struct {
+	Location   locationJSON `json:"loc"`
+	NewContent *liquidJSON  `json:"new_content"`
+}
+
+type transferJSON struct {
+	From   *contentUpdateJSON   `json:"from"`
+	To     []*contentUpdateJSON `json:"to"`
+	Volume *measurementJSON     `json:"volume"`
+	Policy string               `json:"policy"`
+}
+
+type actionJSON struct {
+	Kind     string                `json:"kind"`
+	Message  string                `json:"message"`
+	Children []*actionJSON         `json:"children"`
+	Channels map[int]*transferJSON `json:"channels"`
+}
+
+type actionsJSON struct {
+	Actions []*actionJSON `json:"actions"`
+}
+
+// A mixSummary is a decoded target.MixSummary
+type mixSummary struct {
+	Layout  layoutJSON
+	Actions actionsJSON
+	// Names of deck items by ID
+	names map[string]string
+}
+
+func decodeMixSummary(layout, actions []byte) (*mixSummary, error) {
+	s := &mixSummary{
+		names: make(map[string]string),
+	}
+	if err := json.Unmarshal(layout, &s.Layout); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actions, &s.Actions); err != nil {
+		return nil, err
+	}
+	for _, deck := range []deckJSON{s.Layout.Before, s.Layout.After} {
+		for _, pos := range deck.Positions {
+			if pos != nil && pos.Item != nil {
+				s.names[pos.Item.ID] = pos.Item.Name
+			}
+		}
+	}
+	return s, nil
+}
+
+// pipettingRows returns a row for each transfer of the mix
+func (a *mixSummary) pipettingRows() (rows [][]string) {
+	var visit func(act *actionJSON)
+	visit = func(act *actionJSON) {
+		switch act.Kind {
+		case "prompt":
+			rows = append(rows, []string{act.Message, "", "", "", ""})
+		case "parallel_transfer":
+			var chs []int
+			for ch := range act.Channels {
+				chs = append(chs, ch)
+			}
+			sort.Ints(chs)
+			for _, ch := range chs {
+				t := act.Channels[ch]
+				if t.From == nil {
+					continue
+				}
+				var what string
+				if t.From.NewContent != nil {
+					what = t.From.NewContent.Name
+				}
+				from := fmt.Sprintf("%s %s", a.names[t.From.Location.DeckItemID], t.From.Location.well())
+				for _, to := range t.To {
+					rows = append(rows, []string{
+						what,
+						t.Volume.String(),
+						from,
+						fmt.Sprintf("%s %s", a.names[to.Location.DeckItemID], to.Location.well()),
+						t.Policy,
+					})
+				}
+			}
+		}
+		for _, kid := range act.Children {
+			visit(kid)
+		}
+	}
+	for _, act := range a.Actions.Actions {
+		visit(act)
+	}
+	return
+}
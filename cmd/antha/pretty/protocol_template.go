@@ -0,0 +1,114 @@
+package pretty
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+var protocolFuncs = map[string]interface{}{
+	"join": strings.Join,
+	// cell escapes text for a cell of a Markdown table
+	"cell": func(s string) string {
+		s = strings.Replace(s, "|", "\\|", -1)
+		return strings.Replace(s, "\n", " ", -1)
+	},
+	"rule": func(n int) string {
+		return strings.TrimSuffix(strings.Repeat("---|", n), "|")
+	},
+}
+
+var markdownProtocol = template.Must(template.New("markdown").Funcs(protocolFuncs).Parse(`
+{{- define "table" -}}
+| {{range $i, $h := .Header}}{{if $i}} | {{end}}{{cell $h}}{{end}} |
+|{{rule (len .Header)}}|
+{{range .Rows}}| {{range $i, $c := .}}{{if $i}} | {{end}}{{cell $c}}{{end}} |
+{{end}}
+{{- end -}}
+# {{.Title}}
+{{if .Time}}
+Estimated time: {{.Time}}
+{{end}}
+{{- if .Reagents}}
+## Reagents
+
+| Reagent | Volume | Location |
+|---|---|---|
+{{range .Reagents}}| [ ] {{cell .Name}} | {{.Volume}} | {{cell (join .Where ", ")}} |
+{{end}}
+{{- end}}
+{{- if .Plates}}
+## Plates
+{{range .Plates}}
+### {{.Name}}{{if .Type}} ({{.Type}}){{end}}
+
+{{template "table" .Grid}}
+{{end}}
+{{- end}}
+## Steps
+{{range .Steps}}
+### {{.Number}}. {{.Title}}
+
+- [ ] Done (estimated start {{.Start}}{{if .Device}}, automated by {{.Device}}{{end}})
+{{- if .Timer}}
+- [ ] Start a timer for {{.Timer}}
+{{- end}}
+{{if .Text}}
+{{.Text}}
+{{end}}
+{{- if .Table}}
+{{template "table" .Table}}
+{{- end}}
+{{end -}}
+`))
+
+var htmlProtocol = htmltemplate.Must(htmltemplate.New("html").Funcs(protocolFuncs).Parse(`
+{{- define "table" -}}
+<table>
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end -}}
+</table>
+{{- end -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #888; padding: 0.2em 0.5em; font-size: small; }
+.step { page-break-inside: avoid; }
+.meta { color: #555; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Time}}<p>Estimated time: {{.Time}}</p>{{end}}
+{{- if .Reagents}}
+<h2>Reagents</h2>
+<table>
+<tr><th></th><th>Reagent</th><th>Volume</th><th>Location</th></tr>
+{{range .Reagents}}<tr><td><input type="checkbox"></td><td>{{.Name}}</td><td>{{.Volume}}</td><td>{{join .Where ", "}}</td></tr>
+{{end -}}
+</table>
+{{- end}}
+{{- if .Plates}}
+<h2>Plates</h2>
+{{range .Plates}}<h3>{{.Name}}{{if .Type}} ({{.Type}}){{end}}</h3>
+{{template "table" .Grid}}
+{{end}}
+{{- end}}
+<h2>Steps</h2>
+{{range .Steps}}<div class="step">
+<h3><input type="checkbox"> {{.Number}}. {{.Title}}</h3>
+<p class="meta">Estimated start {{.Start}}{{if .Device}}, automated by {{.Device}}{{end}}</p>
+{{if .Timer}}<p><input type="checkbox"> Start a timer for {{.Timer}}</p>{{end}}
+{{if .Text}}<p>{{.Text}}</p>{{end}}
+{{if .Table}}{{template "table" .Table}}{{end}}
+</div>
+{{end -}}
+</body>
+</html>
+`))
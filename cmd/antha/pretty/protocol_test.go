@@ -0,0 +1,162 @@
+package pretty
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/auto"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type mixer struct{}
+
+func (a *mixer) String() string {
+	return "mixer"
+}
+
+func (a *mixer) CanCompile(req ast.Request) bool {
+	return false
+}
+
+func (a *mixer) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	return nil, nil
+}
+
+func makeComponent(name string, vol float64) *wtype.Liquid {
+	c := wtype.NewLHComponent()
+	c.CName = name
+	c.Vol = vol
+	c.Vunit = "ul"
+	return c
+}
+
+// Summaries of a mix that transfers water from an input plate to an output
+// plate
+const (
+	testLayout = `{
+  "device": "mixer",
+  "before": {"positions": {
+    "position_1": {"item": {"id": "in", "name": "input", "type": "pcrplate", "kind": "plate", "rows": 2, "columns": 2,
+      "contents": {"0": {"0": {"name": "water", "total_volume": {"value": 100, "unit": "ul"}}}}}},
+    "position_2": {"item": {"id": "out", "name": "output", "type": "pcrplate", "kind": "plate", "rows": 2, "columns": 2}}
+  }},
+  "after": {"positions": {
+    "position_1": {"item": {"id": "in", "name": "input", "type": "pcrplate", "kind": "plate", "rows": 2, "columns": 2,
+      "contents": {"0": {"0": {"name": "water", "total_volume": {"value": 80, "unit": "ul"}}}}}},
+    "position_2": {"item": {"id": "out", "name": "output", "type": "pcrplate", "kind": "plate", "rows": 2, "columns": 2,
+      "contents": {"1": {"1": {"name": "water", "total_volume": {"value": 20, "unit": "ul"}}}}}}
+  }}
+}`
+	testActions = `{"actions": [
+  {"kind": "prompt", "message": "load the deck"},
+  {"kind": "parallel_transfer", "channels": {"0": {
+    "from": {"loc": {"deck_item_id": "in", "row": 0, "col": 0}, "new_content": {"name": "water"}},
+    "to": [{"loc": {"deck_item_id": "out", "row": 1, "col": 1}}],
+    "volume": {"value": 20, "unit": "ul"},
+    "policy": "water"
+  }}}
+]}`
+)
+
+// makeTestResult returns a manual mix, followed by a prompt and a mix by a
+// liquid handler
+func makeTestResult() *execute.Result {
+	mix := wtype.NewLHMixInstruction()
+	mix.Inputs = []*wtype.Liquid{
+		makeComponent("salt | sugar", 50),
+		makeComponent("dye\nblue", 10),
+	}
+	mix.Outputs = []*wtype.Liquid{makeComponent("sweet", 60)}
+	mix.PlateName = "bench plate"
+	mix.Platetype = "pcrplate"
+	mix.Welladdress = "B2"
+
+	manual := &target.Manual{
+		Label:   "mix",
+		Details: "mix by hand",
+		Inst:    mix,
+	}
+	prompt := &target.Prompt{
+		Message: "check that the mix is blue",
+	}
+	robot := &target.Mix{
+		Dev: &mixer{},
+		Summary: &target.MixSummary{
+			Layout:  []byte(testLayout),
+			Actions: []byte(testActions),
+		},
+	}
+
+	insts := ast.Insts{manual, prompt, robot}
+	insts.SequentialOrder()
+	return &execute.Result{Insts: insts}
+}
+
+func TestProtocol(t *testing.T) {
+	machine := &auto.Auto{Target: target.New()}
+
+	for _, format := range []string{ProtocolMarkdown, ProtocolHTML} {
+		var buf bytes.Buffer
+		if err := Protocol(&buf, machine, makeTestResult(), format); err != nil {
+			t.Fatal(err)
+		}
+
+		golden := filepath.Join("testdata", "protocol."+format)
+		if *update {
+			if err := ioutil.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(expected, buf.Bytes()) {
+			t.Errorf("%s protocol differs from %s:\n%s", format, golden, buf.String())
+		}
+	}
+}
+
+func TestManualMixReagents(t *testing.T) {
+	first := wtype.NewLHMixInstruction()
+	first.Inputs = []*wtype.Liquid{makeComponent("salt", 50), makeComponent("water", 50)}
+	brine := makeComponent("brine", 100)
+	first.Outputs = []*wtype.Liquid{brine}
+
+	sample := makeComponent("brine", 20)
+	sample.ParentID = brine.ID
+	second := wtype.NewLHMixInstruction()
+	second.Inputs = []*wtype.Liquid{sample, makeComponent("water", 80)}
+	second.Outputs = []*wtype.Liquid{makeComponent("dilute brine", 100)}
+
+	insts := ast.Insts{
+		&target.Manual{Label: "mix", Inst: first},
+		&target.Manual{Label: "mix", Inst: second},
+	}
+	insts.SequentialOrder()
+
+	p, err := makeProtocol(&auto.Auto{Target: target.New()}, &execute.Result{Insts: insts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found []string
+	for _, r := range p.Reagents {
+		found = append(found, r.Name+" "+r.Volume)
+	}
+	expected := []string{"salt 50 ul", "water 130 ul"}
+	if !reflect.DeepEqual(expected, found) {
+		t.Errorf("expecting reagents %q found %q", expected, found)
+	}
+}
@@ -4,57 +4,140 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"sync"
 
-	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/execute"
-	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/auto"
 	"golang.org/x/net/context"
 )
 
-func shouldWait(inst ast.Inst) bool {
-	switch inst.(type) {
-	case *target.Run:
-		return true
+// A consoleOperator asks for acknowledgements and confirmations on a terminal
+type consoleOperator struct {
+	mu  sync.Mutex
+	out io.Writer
+	in  *bufio.Reader
+}
+
+// ask asks a question until the answer starts with one of the given options
+// and returns that option. The caller must hold the lock.
+func (a *consoleOperator) ask(message, question string, options ...string) (string, error) {
+	for {
+		if _, err := fmt.Fprintf(a.out, "    %s (%s? [%s]) ", message, question, strings.Join(options, ",")); err != nil {
+			return "", err
+		}
+		s, err := a.in.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		s = strings.ToLower(strings.TrimSpace(s))
+		for _, option := range options {
+			if strings.HasPrefix(s, option) {
+				return option, nil
+			}
+		}
 	}
-	return false
 }
 
-// Run executes an execute.Result against the given auto target.
-func Run(out io.Writer, in io.Reader, a *auto.Auto, result *execute.Result) error {
-	if _, err := fmt.Fprintf(out, "== Running Workflow:\n"); err != nil {
+// Acknowledge implements an auto.Operator
+func (a *consoleOperator) Acknowledge(ctx context.Context, message string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, err := a.ask("? "+message, "Done", "yes", "abort")
+	if err != nil {
 		return err
+	} else if s == "abort" {
+		return auto.ErrAborted
 	}
+	return nil
+}
 
-	bin := bufio.NewReader(in)
-	ctx := context.Background()
-	for _, inst := range result.Insts {
-		if _, err := fmt.Fprintf(out, "    * %s", a.Pretty(inst)); err != nil {
-			return err
-		}
+// Confirm implements an auto.Confirmer
+func (a *consoleOperator) Confirm(ctx context.Context, message string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s, err := a.ask("* "+message, "Run", "yes", "skip", "abort")
+	if err != nil {
+		return err
+	}
+	switch s {
+	case "skip":
+		return auto.ErrSkipped
+	case "abort":
+		return auto.ErrAborted
+	}
+	return nil
+}
 
-		run := true
-		if shouldWait(inst) {
-			if _, err := fmt.Fprintf(out, " (Run? [yes,skip]) "); err != nil {
-				return err
-			} else if s, err := bin.ReadString('\n'); err != nil {
-				return err
-			} else {
-				run = strings.HasPrefix(strings.ToLower(s), "yes")
+// pause pauses an execution until the user resumes or aborts it. The user is
+// asked once any question being asked has been answered.
+func (a *consoleOperator) pause(e *auto.Engine) {
+	e.Pause()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if s, err := a.ask("! paused", "Continue", "resume", "abort"); err != nil || s == "abort" {
+		e.Abort()
+		return
+	}
+	e.Resume()
+}
+
+// RunOpt are options to Run
+type RunOpt struct {
+	// If not nil, write progress to Log
+	Log io.Writer
+	// Progress of a previous run of the same workflow to resume from
+	Previous []auto.Event
+	// If not nil, execution is paused on each signal received, e.g., an
+	// interrupt, and the user asked whether to resume or abort it
+	Pause <-chan os.Signal
+}
+
+// Run executes an execute.Result against the given auto target, asking for
+// acknowledgements of manual steps and confirmations of device steps on the
+// terminal.
+func Run(out io.Writer, in io.Reader, a *auto.Auto, result *execute.Result, opt RunOpt) error {
+	if _, err := fmt.Fprintf(out, "== Running Workflow:\n"); err != nil {
+		return err
+	}
+
+	op := &consoleOperator{
+		out: out,
+		in:  bufio.NewReader(in),
+	}
+	e := a.NewEngine(result.Insts, auto.EngineOpt{
+		Operator: op,
+		Log:      opt.Log,
+		Previous: opt.Previous,
+		Notify: func(e auto.Event) {
+			switch e.State {
+			case auto.EventDone:
+				fmt.Fprintf(out, "    * %s [OK]\n", e.Name) // nolint
+			case auto.EventSkipped:
+				fmt.Fprintf(out, "    * %s [SKIP]\n", e.Name) // nolint
+			case auto.EventFailed:
+				fmt.Fprintf(out, "    * %s [FAIL]\n", e.Name) // nolint
 			}
-		}
+		},
+	})
 
-		if run {
-			if err := a.Execute(ctx, inst); err != nil {
-				fmt.Fprintf(out, " [FAIL]\n") // nolint
-				return err
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-opt.Pause:
+				op.pause(e)
+			case <-done:
+				return
 			}
 		}
+	}()
 
-		if _, err := fmt.Fprintf(out, " [OK]\n"); err != nil {
-			return err
-		}
-	}
-	return nil
+	return e.Run(context.Background())
 }
@@ -0,0 +1,58 @@
+package pretty
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/execute"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/auto"
+	"github.com/antha-lang/antha/target/human"
+)
+
+func TestRunSkip(t *testing.T) {
+	// Running the instruction would fail as the target has no such device
+	run := &target.Run{Dev: human.New(human.Opt{}), Label: "spin"}
+	prompt := &target.Prompt{Message: "check"}
+	prompt.SetDependsOn(run)
+
+	var out bytes.Buffer
+	in := strings.NewReader("maybe\nskip\nyes\n")
+	machine := &auto.Auto{Target: target.New()}
+	if err := Run(&out, in, machine, &execute.Result{Insts: ast.Insts{run, prompt}}, RunOpt{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"(Run? [yes,skip,abort])", "[SKIP]", "? check (Done? [yes,abort])"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expecting %q in output found %q", expected, out.String())
+		}
+	}
+}
+
+func TestConsolePause(t *testing.T) {
+	machine := &auto.Auto{Target: target.New()}
+	for input, expected := range map[string]error{
+		"later\nresume\nyes\n": nil,
+		"abort\n":              auto.ErrAborted,
+	} {
+		var out bytes.Buffer
+		op := &consoleOperator{
+			out: &out,
+			in:  bufio.NewReader(strings.NewReader(input)),
+		}
+		e := machine.NewEngine([]ast.Inst{&target.Prompt{Message: "check"}}, auto.EngineOpt{Operator: op})
+
+		op.pause(e)
+		if err := e.Run(context.Background()); err != expected {
+			t.Errorf("%q: expecting %v found %v", input, expected, err)
+		}
+		if !strings.Contains(out.String(), "! paused (Continue? [resume,abort])") {
+			t.Errorf("%q: expecting pause question found %q", input, out.String())
+		}
+	}
+}
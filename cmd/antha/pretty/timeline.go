@@ -1,36 +1,52 @@
 package pretty
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/execute"
-	"github.com/antha-lang/antha/graph"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/auto"
 )
 
 // Timeline creates a pretty printed timeline for an execute.Result
 func Timeline(out io.Writer, a *auto.Auto, result *execute.Result) error {
-	g := &target.Graph{
-		Insts: result.Insts,
-	}
-
-	dag := graph.Schedule(graph.Reverse(g))
-	var lines []string
-	for round := 1; len(dag.Roots) != 0; round++ {
-		lines = append(lines, fmt.Sprintf("== Round %2d:\n", round))
-		var next []graph.Node
-		for _, n := range dag.Roots {
-			inst := n.(ast.Inst)
-			lines = append(lines, fmt.Sprintf("    * %s\n", a.Pretty(inst)))
-			next = append(next, dag.Visit(n)...)
+	schedule, err := getSchedule(result)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 1, ' ', 0)
+	for _, j := range schedule.Jobs {
+		mark := "-"
+		if j.Critical {
+			mark = "*"
 		}
+		fmt.Fprintf(w, "    %s %s\t- %s\t%s\n", mark, formatOffset(j.Start), formatOffset(j.End), a.Pretty(j.Inst)) // nolint: errcheck
+	}
+	w.Flush() // nolint: errcheck
+
+	lines := []string{
+		"== Schedule (* on critical path):\n",
+		buf.String(),
+	}
+
+	if schedule.Makespan > 0 {
+		lines = append(lines, fmt.Sprintf("== Estimated time: %s\n", schedule.Makespan.Round(time.Second)))
+	}
 
-		dag.Roots = next
+	if devices, busy := deviceEstimates(schedule); len(devices) > 1 {
+		lines = append(lines, "== Estimated device time:\n")
+		for _, d := range devices {
+			lines = append(lines, fmt.Sprintf("    - %s: %s\n", a.Target.Name(d), busy[d].Round(time.Second)))
+		}
 	}
 
 	lines = append(lines, "== Workflow Outputs:\n")
@@ -46,6 +62,57 @@ func Timeline(out io.Writer, a *auto.Auto, result *execute.Result) error {
 		lines = append(lines, fmt.Sprintf("    - %s: %s\n", k, s))
 	}
 
-	_, err := fmt.Fprint(out, strings.Join(lines, ""))
+	if result.Profile != nil {
+		lines = append(lines, "== Profile:\n", profileSummary(result))
+	}
+
+	_, err = fmt.Fprint(out, strings.Join(lines, ""))
 	return err
 }
+
+// getSchedule returns the schedule of a result, scheduling its instructions
+// if they have not been already
+func getSchedule(result *execute.Result) (*target.Schedule, error) {
+	if result.Schedule != nil {
+		return result.Schedule, nil
+	}
+	return target.NewSchedule(result.Insts)
+}
+
+// formatOffset formats a time from the start of a schedule as h:mm:ss
+func formatOffset(d time.Duration) string {
+	s := int64(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+}
+
+// deviceEstimates returns the devices with scheduled work, in order of first
+// use, and the estimated time that each is busy
+func deviceEstimates(schedule *target.Schedule) ([]ast.Device, map[ast.Device]time.Duration) {
+	var devices []ast.Device
+	busy := make(map[ast.Device]time.Duration)
+	for _, j := range schedule.Jobs {
+		d := j.Device
+		est := j.End - j.Start
+		if d == nil || est == 0 {
+			continue
+		}
+		if _, seen := busy[d]; !seen {
+			devices = append(devices, d)
+		}
+		busy[d] += est
+	}
+	return devices, busy
+}
+
+// profileSummary returns a table of the time spent in each phase of execution
+func profileSummary(result *execute.Result) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "    Category\tName\tCount\tTotal\tMax\n") // nolint: errcheck
+	for _, st := range result.Profile.Summary() {
+		fmt.Fprintf(w, "    %s\t%s\t%d\t%s\t%s\n", st.Category, st.Name, st.Count, // nolint: errcheck
+			st.Total.Round(time.Millisecond), st.Max.Round(time.Millisecond))
+	}
+	w.Flush() // nolint: errcheck
+	return buf.String()
+}
@@ -0,0 +1,191 @@
+package codegen
+
+import (
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/target"
+)
+
+// isMixer returns true if the device is a liquid handler rather than a human
+func isMixer(dev ast.Device) bool {
+	return !isHuman(dev) && dev.CanCompile(ast.Request{
+		Selector: []ast.NameValue{
+			target.DriverSelectorV1Mixer,
+		},
+	})
+}
+
+// mixers returns the liquid handlers among a set of devices
+func mixers(devices []ast.Device) (ret []ast.Device) {
+	for _, d := range devices {
+		if isMixer(d) {
+			ret = append(ret, d)
+		}
+	}
+	return
+}
+
+// estimateMixes returns the estimated time for a device to perform a set of
+// mixes. Devices that cannot estimate their own times are charged for the
+// number of transfers instead.
+func estimateMixes(d ast.Device, mixes []*wtype.LHInstruction) float64 {
+	if e, ok := d.(target.MixEstimator); ok {
+		return e.EstimateMixTime(mixes)
+	}
+	var est float64
+	for _, mix := range mixes {
+		est += float64(len(mix.Inputs))
+	}
+	return est
+}
+
+// A mixGroup is a set of mixes that must be performed by the same liquid
+// handler
+type mixGroup struct {
+	Nodes   []ast.Node
+	Mixes   []*wtype.LHInstruction
+	Devices []ast.Device // Liquid handlers that can perform every mix
+}
+
+// mixGroups returns the groups of mix commands that can be performed by
+// more than one liquid handler. Mixes that depend on each other directly or
+// that share an output plate are in the same group.
+func (a *ir) mixGroups(colors map[ast.Node][]ast.Device) []*mixGroup {
+	var nodes []ast.Node
+	parent := make(map[ast.Node]ast.Node)
+	for i, inum := 0, a.Commands.NumNodes(); i < inum; i++ {
+		n := a.Commands.Node(i).(ast.Node)
+		c, ok := n.(*ast.Command)
+		if !ok {
+			continue
+		}
+		if _, ok := c.Inst.(*wtype.LHInstruction); !ok {
+			continue
+		}
+		if len(mixers(colors[n])) < 2 {
+			continue
+		}
+		nodes = append(nodes, n)
+		parent[n] = n
+	}
+
+	var find func(n ast.Node) ast.Node
+	find = func(n ast.Node) ast.Node {
+		if parent[n] != n {
+			parent[n] = find(parent[n])
+		}
+		return parent[n]
+	}
+	union := func(x, y ast.Node) {
+		parent[find(x)] = find(y)
+	}
+
+	plates := make(map[string]ast.Node)
+	for _, n := range nodes {
+		for i, inum := 0, a.Commands.NumOuts(n); i < inum; i++ {
+			kid := a.Commands.Out(n, i).(ast.Node)
+			if _, ok := parent[kid]; ok {
+				union(n, kid)
+			}
+		}
+
+		mix := n.(*ast.Command).Inst.(*wtype.LHInstruction)
+		id := mix.PlateID
+		if mix.OutPlate != nil {
+			id = mix.OutPlate.ID
+		}
+		if len(id) == 0 {
+			continue
+		}
+		if other, seen := plates[id]; seen {
+			union(n, other)
+		} else {
+			plates[id] = n
+		}
+	}
+
+	var groups []*mixGroup
+	byRoot := make(map[ast.Node]*mixGroup)
+	for _, n := range nodes {
+		root := find(n)
+		g, ok := byRoot[root]
+		if !ok {
+			g = &mixGroup{Devices: mixers(colors[n])}
+			byRoot[root] = g
+			groups = append(groups, g)
+		}
+		g.Nodes = append(g.Nodes, n)
+		g.Mixes = append(g.Mixes, n.(*ast.Command).Inst.(*wtype.LHInstruction))
+
+		// Keep the devices that can perform every mix of the group
+		can := make(map[ast.Device]bool)
+		for _, d := range colors[n] {
+			can[d] = true
+		}
+		var devices []ast.Device
+		for _, d := range g.Devices {
+			if can[d] {
+				devices = append(devices, d)
+			}
+		}
+		g.Devices = devices
+	}
+
+	return groups
+}
+
+// balanceMixes spreads groups of mixes over the liquid handlers that can
+// perform them to reduce the time until the last liquid handler finishes.
+// Groups are placed, largest first, on the device that would finish them
+// earliest. Dependencies between groups are kept by the command graph, so
+// dependent groups on different devices run one after the other.
+func (a *ir) balanceMixes(colors map[ast.Node][]ast.Device, device map[ast.Node]ast.Device) {
+	groups := a.mixGroups(colors)
+
+	type costedGroup struct {
+		*mixGroup
+		Cost map[ast.Device]float64
+		Max  float64
+	}
+
+	var costed []*costedGroup
+	for _, g := range groups {
+		if len(g.Devices) == 0 {
+			continue
+		}
+		cg := &costedGroup{
+			mixGroup: g,
+			Cost:     make(map[ast.Device]float64),
+		}
+		for _, d := range g.Devices {
+			c := estimateMixes(d, g.Mixes)
+			cg.Cost[d] = c
+			if c > cg.Max {
+				cg.Max = c
+			}
+		}
+		costed = append(costed, cg)
+	}
+
+	sort.SliceStable(costed, func(i, j int) bool {
+		return costed[i].Max > costed[j].Max
+	})
+
+	load := make(map[ast.Device]float64)
+	for _, g := range costed {
+		var best ast.Device
+		var bestEnd float64
+		for _, d := range g.Devices {
+			if end := load[d] + g.Cost[d]; best == nil || end < bestEnd {
+				best = d
+				bestEnd = end
+			}
+		}
+		load[best] = bestEnd
+		for _, n := range g.Nodes {
+			device[n] = best
+		}
+	}
+}
@@ -27,6 +27,9 @@ type ir struct {
 	output       map[*drun][]ast.Inst        // Output of device-specific planners
 	initializers []ast.Inst                  // Intializers
 	finalizers   []ast.Inst                  // Finalizers in reverse order
+	uncovered    map[*drun][]string          // Plates incubated without a seal or lid
+	mover        target.PlateMover           // Device to move plates between runs, if any
+	target       *target.Target              // Target configuration being compiled for
 }
 
 // Print out IR for debugging
@@ -183,7 +186,7 @@ func (a *ir) assignDevices(t *target.Target) error {
 			if isBundle {
 				devices = append(devices, human.New(human.Opt{}))
 			} else {
-				return fmt.Errorf("no device can handle constraints %v", ast.Meet(reqs...))
+				return t.Unsatisfiable(reqs...)
 			}
 		}
 		sort.Stable(partitionByHuman(devices))
@@ -223,6 +226,7 @@ func (a *ir) assignDevices(t *target.Target) error {
 		ret[n.(ast.Node)] = devices[idx]
 	}
 
+	a.balanceMixes(colors, ret)
 	a.coalesceDevices(ret)
 
 	return nil
@@ -318,7 +322,12 @@ func (a *ir) tryPlan(ctx context.Context) error {
 	}
 
 	a.output = make(map[*drun][]ast.Inst)
+	a.uncovered = make(map[*drun][]string)
 	for _, d := range runs {
+		if len(cmds[d]) == 0 {
+			// Nothing to compile for a run of just the root
+			continue
+		}
 		insts, err := d.Device.Compile(ctx, cmds[d])
 		if err != nil {
 			return err
@@ -330,9 +339,10 @@ func (a *ir) tryPlan(ctx context.Context) error {
 		}
 
 		a.output[d] = insts
+		a.trackCovers(ctx, d, cmds[d])
 	}
 
-	return a.addImplicitInsts(runs)
+	return a.addImplicitInsts(ctx, runs)
 }
 
 func (a *ir) sortDevices(ctx context.Context, t *target.Target) error {
@@ -433,6 +443,8 @@ func Compile(ctx context.Context, t *target.Target, roots []ast.Node) ([]ast.Ins
 	if err := ir.assignDevices(t); err != nil {
 		return nil, fmt.Errorf("error assigning devices with target configuration %s: %s", t, err)
 	}
+	ir.mover = t.PlateMover()
+	ir.target = t
 	if err := ir.tryPlan(ctx); err != nil {
 		return nil, fmt.Errorf("error planning: %s", err)
 	}
@@ -446,20 +458,27 @@ func Compile(ctx context.Context, t *target.Target, roots []ast.Node) ([]ast.Ins
 		return nil, fmt.Errorf("error generating instructions: %s", err)
 	}
 
-	// TODO: discard programs that create multiple setups until we get their
-	// semantics correct; also true of incubating components under multiple
-	// conditions
-	var setupMixes int
+	// TODO: discard programs that create multiple setups of the same mixer
+	// until we get their semantics correct; also true of incubating
+	// components under multiple conditions
+	setupMixes := make(map[ast.Device]int)
 	var setupIncubators int
 	for _, inst := range insts {
-		switch inst.(type) {
+		switch inst := inst.(type) {
 		case *target.SetupMixer:
-			setupMixes++
+			for _, mix := range inst.Mixes {
+				setupMixes[mix.Dev]++
+			}
 		case *target.SetupIncubator:
 			setupIncubators++
 		}
 	}
-	if setupMixes > 1 || setupIncubators > 1 {
+	for _, n := range setupMixes {
+		if n > 1 {
+			return nil, fmt.Errorf("multiple incubates or multiple mixes not supported")
+		}
+	}
+	if setupIncubators > 1 {
 		return nil, fmt.Errorf("multiple incubates or multiple mixes not supported")
 	}
 
@@ -3,12 +3,17 @@ package codegen
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/microArch/sampletracker"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/target/human"
+	"github.com/antha-lang/antha/target/platemover"
 )
 
 type incubateInst struct {
@@ -51,7 +56,7 @@ func (a *incubator) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst,
 }
 
 func TestWellFormed(t *testing.T) {
-	ctx := context.Background()
+	ctx := sampletracker.NewContext(context.Background())
 
 	var nodes []ast.Node
 	for idx := 0; idx < 4; idx++ {
@@ -98,3 +103,480 @@ func TestWellFormed(t *testing.T) {
 		t.Errorf("expected %d dependencies found %d", 1, n)
 	}
 }
+
+func TestCentrifugePrompts(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+
+	var nodes []ast.Node
+	for _, name := range []string{"cells", "lysate", "media"} {
+		nodes = append(nodes, &ast.Command{
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1Centrifuge,
+				},
+			},
+			Inst: &ast.CentrifugeInst{
+				Force: wunit.NewAcceleration(500, "xg"),
+				Time:  wunit.NewTime(5, "min"),
+			},
+			From: []ast.Node{
+				&ast.UseComp{Value: &wtype.Liquid{ID: name, CName: name}},
+			},
+		})
+	}
+
+	machine := target.New()
+	machine.AddDevice(human.New(human.Opt{CanCentrifuge: true}))
+
+	insts, err := Compile(ctx, machine, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prompts []string
+	var spins int
+	for _, inst := range insts {
+		switch inst := inst.(type) {
+		case *target.Prompt:
+			prompts = append(prompts, inst.Message)
+			if inst.Transfer != nil {
+				t.Errorf("expected no transfer of components not on plates found %v", inst.Transfer)
+			}
+		case *target.Manual:
+			if inst.Label != "centrifuge" {
+				t.Errorf("expected centrifuge found %q", inst.Label)
+			} else if e, f := "spin at 500 xg for 5 min", inst.Details; e != f {
+				t.Errorf("expected %q found %q", e, f)
+			}
+			spins++
+		}
+	}
+
+	if spins != 1 {
+		t.Errorf("expected %d spins found %d", 1, spins)
+	}
+	expected := []string{
+		`move "cells", "lysate", "media" to the centrifuge`,
+		`balance the centrifuge by placing "cells", "lysate", "media" in opposite pairs of equal mass and a counterweight of equal mass opposite "media"`,
+		`remove "cells", "lysate", "media" from the centrifuge`,
+	}
+	if !reflect.DeepEqual(expected, prompts) {
+		t.Errorf("expected %q found %q", expected, prompts)
+	}
+}
+
+func TestCentrifugeTransferPrompts(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+	st := sampletracker.FromContext(ctx)
+	st.SetLocationOf("cells", "plate1:A1")
+	st.SetLocationOf("lysate", "plate1:B1")
+
+	var nodes []ast.Node
+	for _, name := range []string{"cells", "lysate"} {
+		nodes = append(nodes, &ast.Command{
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1Centrifuge,
+				},
+			},
+			Inst: &ast.CentrifugeInst{},
+			From: []ast.Node{
+				&ast.UseComp{Value: &wtype.Liquid{ID: name, CName: name}},
+			},
+		})
+	}
+
+	machine := target.New()
+	machine.AddDevice(human.New(human.Opt{CanCentrifuge: true}))
+
+	insts, err := Compile(ctx, machine, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var transfers []target.PlateTransfer
+	for _, inst := range insts {
+		if p, ok := inst.(*target.Prompt); ok && p.Transfer != nil {
+			transfers = append(transfers, *p.Transfer)
+		}
+	}
+
+	expected := []target.PlateTransfer{
+		{PlateIDs: []string{"plate1"}},
+		{Unload: true, PlateIDs: []string{"plate1"}},
+	}
+	if !reflect.DeepEqual(expected, transfers) {
+		t.Errorf("expected %v found %v", expected, transfers)
+	}
+}
+
+func TestIncubateCoverPrompts(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+	st := sampletracker.FromContext(ctx)
+	st.SetLocationOf("cells", "plate1:A1")
+	st.SetLocationOf("media", "plate2:A1")
+	st.SetLocationOf("broth", "plate3:A1")
+	st.SetCoverOf("plate3", wtype.Lidded)
+	st.UpdateIDOf("cells", "sealed cells")
+
+	seal := &ast.Command{
+		Request: ast.Request{
+			Selector: []ast.NameValue{
+				target.DriverSelectorV1Sealer,
+			},
+		},
+		Inst: &ast.CoverInst{
+			ComponentIn: &wtype.Liquid{ID: "cells", CName: "cells"},
+			Action:      ast.SealAction,
+		},
+		From: []ast.Node{
+			&ast.UseComp{Value: &wtype.Liquid{ID: "cells", CName: "cells"}},
+		},
+	}
+	sealed := &ast.UseComp{Value: &wtype.Liquid{ID: "sealed cells", CName: "cells"}}
+	sealed.From = append(sealed.From, seal)
+
+	var nodes []ast.Node
+	for _, u := range []*ast.UseComp{sealed, {Value: &wtype.Liquid{ID: "media", CName: "media"}}, {Value: &wtype.Liquid{ID: "broth", CName: "broth"}}} {
+		nodes = append(nodes, &ast.Command{
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1ShakerIncubator,
+				},
+			},
+			Inst: &ast.IncubateInst{},
+			From: []ast.Node{u},
+		})
+	}
+
+	machine := target.New()
+	machine.AddDevice(human.New(human.Opt{CanSeal: true}))
+	machine.AddDevice(&incubator{})
+
+	insts, err := Compile(ctx, machine, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prompts []string
+	for _, inst := range insts {
+		if p, ok := inst.(*target.Prompt); ok {
+			prompts = append(prompts, p.Message)
+		}
+	}
+
+	expected := []string{
+		`seal or lid plate "plate2" to limit evaporation during incubation`,
+	}
+	if !reflect.DeepEqual(expected, prompts) {
+		t.Errorf("expected %q found %q", expected, prompts)
+	}
+	if cover, _ := st.GetCoverOf("plate1"); cover != wtype.Sealed {
+		t.Errorf("expected plate1 to be %s found %s", wtype.Sealed, cover)
+	}
+}
+
+type reader struct{}
+
+func (a *reader) CanCompile(req ast.Request) bool {
+	can := ast.Request{}
+	can.Selector = append(can.Selector, target.DriverSelectorV1WriteOnlyPlateReader)
+	return can.Contains(req)
+}
+
+func (a *reader) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	st := sampletracker.FromContext(ctx)
+	var plateIDs []string
+	for _, n := range nodes {
+		for _, from := range n.(*ast.Command).From {
+			if u, ok := from.(*ast.UseComp); ok {
+				if id, ok := st.GetPlateOf(u.Value.ID); ok {
+					plateIDs = append(plateIDs, id)
+				}
+			}
+		}
+	}
+
+	insts := ast.Insts{
+		&target.Prompt{
+			Message:  "put plate into reader",
+			Transfer: &target.PlateTransfer{PlateIDs: plateIDs},
+		},
+		&target.Run{Dev: a, Label: "read"},
+		&target.Prompt{
+			Message:  "remove plate from reader",
+			Transfer: &target.PlateTransfer{Unload: true, PlateIDs: plateIDs},
+		},
+	}
+	insts.SequentialOrder()
+	return insts, nil
+}
+
+func makeMoveNodes(ctx context.Context) []ast.Node {
+	cone := wtype.NewShape(wtype.CylinderShape, "mm", 5.5, 5.5, 20.4)
+	welltype := wtype.NewLHWell("ul", 200, 5, cone, wtype.UWellBottom, 5.5, 5.5, 20.4, 1.4, "mm")
+	plate := wtype.NewLHPlate("pcrplate", "Unknown", 8, 12, wtype.Coordinates3D{X: 127.76, Y: 85.48, Z: 25.7}, welltype, 9, 9, 0.0, 0.0, 38.5)
+	plate.PlateName = "samples"
+
+	st := sampletracker.FromContext(ctx)
+	st.SetInputPlate(plate)
+	st.SetLocationOf("cells", plate.ID+":A1")
+	st.UpdateIDOf("cells", "incubated cells")
+
+	incubate := &ast.Command{
+		Request: ast.Request{
+			Selector: []ast.NameValue{
+				target.DriverSelectorV1ShakerIncubator,
+			},
+		},
+		Inst: &ast.IncubateInst{},
+		From: []ast.Node{
+			&ast.UseComp{Value: &wtype.Liquid{ID: "cells", CName: "cells"}},
+		},
+	}
+	incubated := &ast.UseComp{Value: &wtype.Liquid{ID: "incubated cells", CName: "cells"}}
+	incubated.From = append(incubated.From, incubate)
+
+	return []ast.Node{
+		&ast.Command{
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1WriteOnlyPlateReader,
+				},
+			},
+			Inst: &wtype.PRInstruction{},
+			From: []ast.Node{incubated},
+		},
+	}
+}
+
+func TestPlateMoves(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+	nodes := makeMoveNodes(ctx)
+
+	machine := target.New()
+	machine.AddDevice(&incubator{})
+	machine.AddDevice(&reader{})
+	machine.AddDevice(platemover.New([]platemover.Nest{
+		{Name: "incubator", Device: target.DriverSelectorV1ShakerIncubator.Value},
+		{Name: "reader", Device: target.DriverSelectorV1WriteOnlyPlateReader.Value, Labware: []string{"pcrplate"}},
+	}))
+
+	insts, err := Compile(ctx, machine, nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var moves []*target.Move
+	for idx, inst := range insts {
+		if m, ok := inst.(*target.Move); ok {
+			moves = append(moves, m)
+			if idx == 0 || idx == len(insts)-1 {
+				t.Errorf("expected move between runs found it at %d of %d", idx, len(insts))
+			}
+		}
+	}
+
+	if len(moves) != 1 {
+		t.Fatalf("expected %d moves found %d", 1, len(moves))
+	}
+	m := moves[0]
+	if m.PlateName != "samples" || m.Labware != "pcrplate" || m.From != "incubator" || m.To != "reader" {
+		t.Errorf("unexpected move of %s (%s) from %s to %s", m.PlateName, m.Labware, m.From, m.To)
+	}
+}
+
+func TestPlateTransferPrompts(t *testing.T) {
+	transfers := func(withMover bool) (ret []*target.PlateTransfer) {
+		ctx := sampletracker.NewContext(context.Background())
+		nodes := makeMoveNodes(ctx)
+
+		machine := target.New()
+		machine.AddDevice(&incubator{})
+		machine.AddDevice(&reader{})
+		if withMover {
+			machine.AddDevice(platemover.New([]platemover.Nest{
+				{Name: "incubator", Device: target.DriverSelectorV1ShakerIncubator.Value},
+				{Name: "reader", Device: target.DriverSelectorV1WriteOnlyPlateReader.Value},
+			}))
+		}
+
+		insts, err := Compile(ctx, machine, nodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, inst := range insts {
+			if p, ok := inst.(*target.Prompt); ok && p.Transfer != nil {
+				ret = append(ret, p.Transfer)
+			}
+		}
+		return
+	}
+
+	if ts := transfers(false); len(ts) != 2 {
+		t.Errorf("expected %d transfer prompts without a plate mover found %d", 2, len(ts))
+	}
+
+	// The mover puts the plate into the reader, but nothing takes it out
+	ts := transfers(true)
+	if len(ts) != 1 {
+		t.Fatalf("expected %d transfer prompts with a plate mover found %d", 1, len(ts))
+	}
+	if !ts[0].Unload {
+		t.Error("expected prompt to load the moved plate to be removed")
+	}
+}
+
+func TestPlateMoveLabware(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+	nodes := makeMoveNodes(ctx)
+
+	machine := target.New()
+	machine.AddDevice(&incubator{})
+	machine.AddDevice(&reader{})
+	machine.AddDevice(platemover.New([]platemover.Nest{
+		{Name: "incubator", Device: target.DriverSelectorV1ShakerIncubator.Value},
+		{Name: "reader", Device: target.DriverSelectorV1WriteOnlyPlateReader.Value, Labware: []string{"deepwell"}},
+	}))
+
+	if _, err := Compile(ctx, machine, nodes); err == nil {
+		t.Error("expected error moving plate to nest that does not accept it")
+	}
+}
+
+type mixer struct {
+	name string
+}
+
+func (a *mixer) String() string {
+	return a.name
+}
+
+func (a *mixer) CanCompile(req ast.Request) bool {
+	can := ast.Request{}
+	can.Selector = append(can.Selector, target.DriverSelectorV1Mixer)
+	return can.Contains(req)
+}
+
+func (a *mixer) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	return []ast.Inst{&target.Mix{Dev: a}}, nil
+}
+
+func makeMix(from ...ast.Node) *ast.Command {
+	inst := &wtype.LHInstruction{}
+	for range from {
+		inst.Inputs = append(inst.Inputs, wtype.NewLHComponent())
+	}
+	return &ast.Command{
+		Request: ast.Request{
+			Selector: []ast.NameValue{
+				target.DriverSelectorV1Mixer,
+			},
+		},
+		Inst: inst,
+		From: from,
+	}
+}
+
+func TestBalanceMixes(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+
+	// A chain of two mixes and two independent mixes
+	first := makeMix(&ast.UseComp{}, &ast.UseComp{}, &ast.UseComp{})
+	u := &ast.UseComp{}
+	u.From = append(u.From, first)
+	second := makeMix(u, &ast.UseComp{}, &ast.UseComp{})
+	third := makeMix(&ast.UseComp{}, &ast.UseComp{}, &ast.UseComp{})
+	fourth := makeMix(&ast.UseComp{}, &ast.UseComp{}, &ast.UseComp{})
+
+	m1 := &mixer{name: "mixer1"}
+	m2 := &mixer{name: "mixer2"}
+	machine := target.New()
+	machine.AddDevice(m1)
+	machine.AddDevice(m2)
+
+	if _, err := Compile(ctx, machine, []ast.Node{second, third, fourth}); err != nil {
+		t.Fatal(err)
+	}
+
+	device := func(c *ast.Command) ast.Device {
+		for _, inst := range c.Output {
+			if mix, ok := inst.(*target.Mix); ok {
+				return mix.Dev
+			}
+		}
+		return nil
+	}
+
+	if d1, d2 := device(first), device(second); d1 == nil || d1 != d2 {
+		t.Errorf("expected dependent mixes on the same device found %v and %v", d1, d2)
+	}
+	if d1, d3, d4 := device(first), device(third), device(fourth); d3 != d4 || d3 == d1 {
+		t.Errorf("expected independent mixes on the other device found %v, %v and %v", d1, d3, d4)
+	}
+}
+
+func TestTargetNames(t *testing.T) {
+	m1 := &mixer{name: "mixer"}
+	m2 := &mixer{name: "mixer"}
+	inc := &incubator{}
+	machine := target.New()
+	machine.AddDevice(m1)
+	machine.AddDevice(inc)
+	machine.AddDevice(m2)
+
+	for d, expected := range map[ast.Device]string{
+		m1:  "mixer 1",
+		m2:  "mixer 2",
+		inc: "*codegen.incubator",
+	} {
+		if name := machine.Name(d); name != expected {
+			t.Errorf("expected %q found %q", expected, name)
+		}
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	makeIncubate := func(celsius float64) []ast.Node {
+		temp := wunit.NewTemperature(celsius, "C")
+		return []ast.Node{
+			&ast.Command{
+				Request: ast.Request{
+					Selector: []ast.NameValue{
+						target.DriverSelectorV1ShakerIncubator,
+					},
+					Capabilities: []ast.Capability{
+						ast.NewValueCapability(target.CapabilityTemperature, temp),
+					},
+				},
+				Inst: &ast.IncubateInst{Temp: temp},
+				From: []ast.Node{
+					&ast.UseComp{Value: &wtype.Liquid{ID: "cells", CName: "cells"}},
+				},
+			},
+		}
+	}
+
+	inc := &incubator{}
+	machine := target.New()
+	machine.AddDevice(inc)
+	machine.Advertise(inc, ast.RangeCapability{
+		Name: target.CapabilityTemperature,
+		Min:  wunit.NewTemperature(4, "C"),
+		Max:  wunit.NewTemperature(95, "C"),
+	})
+
+	ctx := sampletracker.NewContext(context.Background())
+	if _, err := Compile(ctx, machine, makeIncubate(37)); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = sampletracker.NewContext(context.Background())
+	_, err := Compile(ctx, machine, makeIncubate(99))
+	if err == nil {
+		t.Fatal("expecting error for unreachable temperature")
+	}
+	if e, f := "temperature 99", err.Error(); !strings.Contains(f, e) {
+		t.Errorf("expecting error containing %q found %q", e, f)
+	}
+}
@@ -1,13 +1,16 @@
 package codegen
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/graph"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/microArch/sampletracker"
 	"github.com/antha-lang/antha/target"
 )
 
@@ -120,6 +123,218 @@ func (a *ir) addImplicitMixInsts() error {
 	return nil
 }
 
+// centrifugeItems returns descriptions of the plates, or components if they
+// are not on plates, that are centrifuged by the given commands
+func centrifugeItems(mixes []*target.Mix, cmds []*ast.Command) []string {
+	ids := make(map[string]bool)
+	names := make(map[string]string)
+	for _, c := range cmds {
+		for _, n := range c.From {
+			if u, ok := n.(*ast.UseComp); ok && u.Value != nil {
+				ids[u.Value.ID] = true
+				names[u.Value.ID] = u.Value.CName
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var items []string
+	add := func(item string) {
+		if !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+
+	// Components that end up on plates laid out by earlier mixes are named by
+	// their plate; those that do not are named themselves
+	found := make(map[string]bool)
+	for _, mix := range mixes {
+		for _, plate := range mix.FinalProperties.Plates {
+			for _, well := range plate.Wellcoords {
+				for id := range ids {
+					if strings.Contains(well.WContents.ParentID, id) {
+						found[id] = true
+						add(fmt.Sprintf("plate %q", plate.PlateName))
+					}
+				}
+			}
+		}
+	}
+	for id := range ids {
+		if !found[id] {
+			add(fmt.Sprintf("%q", names[id]))
+		}
+	}
+
+	sort.Strings(items)
+	return items
+}
+
+// balanceMessage returns the message to balance a centrifuge loaded with the
+// given items
+func balanceMessage(items []string) string {
+	if len(items)%2 == 0 {
+		return fmt.Sprintf("balance the centrifuge by placing %s in opposite pairs of equal mass", strings.Join(items, ", "))
+	}
+	return fmt.Sprintf("balance the centrifuge by placing %s in opposite pairs of equal mass and a counterweight of equal mass opposite %s",
+		strings.Join(items, ", "), items[len(items)-1])
+}
+
+// onPlates returns whether every component used by the given commands is on
+// a plate
+func onPlates(st *sampletracker.SampleTracker, cmds []*ast.Command) bool {
+	for _, c := range cmds {
+		for _, n := range c.From {
+			u, ok := n.(*ast.UseComp)
+			if !ok || u.Value == nil {
+				continue
+			}
+			if _, ok := st.GetPlateOf(u.Value.ID); !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// addImplicitCentrifugeInsts adds prompts to move things into and out of
+// centrifuges and to balance them. The prompts to load and unload plates are
+// dropped if the plate mover moves them; those for components that are not on
+// plates are always kept.
+func (a *ir) addImplicitCentrifugeInsts(ctx context.Context) {
+	st := sampletracker.FromContext(ctx)
+	plates := a.runPlates(st)
+	spins := make(map[*drun][]*ast.Command)
+	for n, d := range a.assignment {
+		c, ok := n.(*ast.Command)
+		if !ok {
+			continue
+		}
+		if _, ok := c.Inst.(*ast.CentrifugeInst); ok {
+			spins[d] = append(spins[d], c)
+		}
+	}
+
+	mixes := a.getMixes()
+	for d, cmds := range spins {
+		insts := a.output[d]
+		items := centrifugeItems(mixes, cmds)
+		if len(insts) == 0 || len(items) == 0 {
+			continue
+		}
+
+		load := &target.Prompt{
+			Message: fmt.Sprintf("move %s to the centrifuge", strings.Join(items, ", ")),
+		}
+		balance := &target.Prompt{
+			Message: balanceMessage(items),
+		}
+		unload := &target.Prompt{
+			Message: fmt.Sprintf("remove %s from the centrifuge", strings.Join(items, ", ")),
+		}
+		if onPlates(st, cmds) {
+			load.Transfer = &target.PlateTransfer{PlateIDs: plates[d]}
+			unload.Transfer = &target.PlateTransfer{Unload: true, PlateIDs: plates[d]}
+		}
+
+		// The prompts become the new entry and exit of the run
+		balance.SetDependsOn(load)
+		insts[0].AppendDependsOn(balance)
+		unload.SetDependsOn(insts[len(insts)-1])
+
+		var newInsts []ast.Inst
+		newInsts = append(newInsts, load, balance)
+		newInsts = append(newInsts, insts...)
+		newInsts = append(newInsts, unload)
+		a.output[d] = newInsts
+	}
+}
+
+// plateName returns the name of the plate with the given ID
+func (a *ir) plateName(st *sampletracker.SampleTracker, id string) string {
+	for _, mix := range a.getMixes() {
+		for _, plate := range mix.FinalProperties.Plates {
+			if plate.ID == id {
+				return plate.PlateName
+			}
+		}
+	}
+	for _, plate := range st.GetInputPlates() {
+		if plate.ID == id {
+			return plate.PlateName
+		}
+	}
+	return id
+}
+
+// incubationEvaporationLimit is the largest rate of evaporation, as a fraction
+// of the rate from open wells, that is allowed from plates being incubated
+const incubationEvaporationLimit = 0.5
+
+// trackCovers records the changes that a run makes to the seals and lids of
+// plates. Runs are compiled in dependency order, so later runs, in particular
+// those of liquid handlers, see the covers as they will be when they execute.
+// Plates that are incubated without a cover that limits evaporation are noted
+// too.
+func (a *ir) trackCovers(ctx context.Context, d *drun, cmds []ast.Node) {
+	st := sampletracker.FromContext(ctx)
+	seen := make(map[string]bool)
+	for _, n := range cmds {
+		c, ok := n.(*ast.Command)
+		if !ok {
+			continue
+		}
+
+		switch inst := c.Inst.(type) {
+		case *ast.CoverInst:
+			if id, ok := st.GetPlateOf(inst.ComponentIn.ID); ok {
+				st.SetCoverOf(id, inst.Action.Cover())
+			}
+
+		case *ast.IncubateInst:
+			for _, from := range c.From {
+				u, ok := from.(*ast.UseComp)
+				if !ok || u.Value == nil {
+					continue
+				}
+				id, ok := st.GetPlateOf(u.Value.ID)
+				if !ok || seen[id] {
+					continue
+				}
+				seen[id] = true
+				if cover, _ := st.GetCoverOf(id); cover.EvaporationFactor() > incubationEvaporationLimit {
+					a.uncovered[d] = append(a.uncovered[d], a.plateName(st, id))
+				}
+			}
+		}
+	}
+}
+
+// addImplicitIncubateInsts adds prompts to cover plates that would otherwise
+// be incubated with open wells
+func (a *ir) addImplicitIncubateInsts() {
+	for d, names := range a.uncovered {
+		insts := a.output[d]
+		if len(insts) == 0 || len(names) == 0 {
+			continue
+		}
+
+		sort.Strings(names)
+		var quoted []string
+		for _, name := range names {
+			quoted = append(quoted, fmt.Sprintf("%q", name))
+		}
+		cover := &target.Prompt{
+			Message: fmt.Sprintf("seal or lid plate %s to limit evaporation during incubation", strings.Join(quoted, ", ")),
+		}
+
+		// The prompt becomes the new entry of the run
+		insts[0].AppendDependsOn(cover)
+		a.output[d] = append([]ast.Inst{cover}, insts...)
+	}
+}
+
 // addIzers adds device-specific initializers and finalizers
 func (a *ir) addIzers(deviceOrder []*drun) error {
 	for _, d := range deviceOrder {
@@ -150,12 +365,229 @@ func (a *ir) addIzers(deviceOrder []*drun) error {
 	return nil
 }
 
+// isHuman returns true if the device is operated by a human
+func isHuman(dev ast.Device) bool {
+	return dev.CanCompile(ast.Request{
+		Selector: []ast.NameValue{
+			target.DriverSelectorV1Human,
+		},
+	})
+}
+
+// runPlates returns the IDs of the plates holding the inputs of the commands
+// of each run
+func (a *ir) runPlates(st *sampletracker.SampleTracker) map[*drun][]string {
+	seen := make(map[*drun]map[string]bool)
+	ret := make(map[*drun][]string)
+	for n, d := range a.assignment {
+		c, ok := n.(*ast.Command)
+		if !ok {
+			continue
+		}
+		if seen[d] == nil {
+			seen[d] = make(map[string]bool)
+		}
+		for _, from := range c.From {
+			u, ok := from.(*ast.UseComp)
+			if !ok || u.Value == nil {
+				continue
+			}
+			if id, ok := st.GetPlateOf(u.Value.ID); ok && !seen[d][id] {
+				seen[d][id] = true
+				ret[d] = append(ret[d], id)
+			}
+		}
+	}
+	for _, ids := range ret {
+		sort.Strings(ids)
+	}
+	return ret
+}
+
+// makeMove returns an instruction to move a plate from the run of one device
+// to the run of another. The plate mover moves the plate if it can reach both
+// devices. Otherwise, plates handed off between liquid handlers are moved by
+// the user, and other moves are left to the user implicitly.
+func (a *ir) makeMove(plate *wtype.Plate, src, dst *drun) (ast.Inst, error) {
+	if a.mover != nil {
+		from, err := a.mover.Nest(src.Device, plate.Type)
+		if err != nil && err != target.ErrNoNest {
+			return nil, fmt.Errorf("cannot move plate %q: %s", plate.PlateName, err)
+		}
+		var to string
+		if err == nil {
+			to, err = a.mover.Nest(dst.Device, plate.Type)
+			if err != nil && err != target.ErrNoNest {
+				return nil, fmt.Errorf("cannot move plate %q: %s", plate.PlateName, err)
+			}
+		}
+		if err == nil {
+			return &target.Move{
+				Dev:       a.mover,
+				PlateID:   plate.ID,
+				PlateName: plate.PlateName,
+				Labware:   plate.Type,
+				From:      from,
+				To:        to,
+			}, nil
+		}
+	}
+
+	if !isMixer(src.Device) || !isMixer(dst.Device) {
+		return nil, nil
+	}
+
+	name := func(d ast.Device) string {
+		if a.target == nil {
+			return fmt.Sprint(d)
+		}
+		return a.target.Name(d)
+	}
+	return &target.Prompt{
+		Message: fmt.Sprintf("move plate %q from %s to %s", plate.PlateName, name(src.Device), name(dst.Device)),
+	}, nil
+}
+
+// addImplicitMoveInsts adds moves of plates between the runs of devices.
+// Plates are moved to a run from the device that last used them.
+func (a *ir) addImplicitMoveInsts(ctx context.Context, deviceOrder []*drun) error {
+	st := sampletracker.FromContext(ctx)
+	plates := make(map[string]*wtype.Plate)
+	for _, p := range st.GetInputPlates() {
+		plates[p.ID] = p
+	}
+
+	used := a.runPlates(st)
+	at := make(map[string]*drun) // Run that last used each plate
+	moved := make(map[plateTransfer]bool)
+	for _, d := range deviceOrder {
+		insts := a.output[d]
+
+		var moves []ast.Inst
+		for _, id := range used[d] {
+			src, ok := at[id]
+			at[id] = d
+			plate := plates[id]
+			if !ok || plate == nil || src.Device == d.Device || isHuman(src.Device) || isHuman(d.Device) {
+				continue
+			}
+
+			move, err := a.makeMove(plate, src, d)
+			if err != nil {
+				return err
+			} else if move == nil {
+				continue
+			}
+			moves = append(moves, move)
+			if _, ok := move.(*target.Move); ok {
+				moved[plateTransfer{Run: src, PlateID: id, Unload: true}] = true
+				moved[plateTransfer{Run: d, PlateID: id}] = true
+			}
+		}
+
+		// Plates laid out by a mixer are where it left them
+		for _, inst := range insts {
+			mix, ok := inst.(*target.Mix)
+			if !ok {
+				continue
+			}
+			for _, props := range []*liquidhandling.LHProperties{mix.Properties, mix.FinalProperties} {
+				if props == nil {
+					continue
+				}
+				for _, p := range props.Plates {
+					plates[p.ID] = p
+					at[p.ID] = d
+				}
+			}
+		}
+
+		if len(moves) == 0 || len(insts) == 0 {
+			continue
+		}
+
+		// The moves become the new entry of the run
+		ast.Insts(moves).SequentialOrder()
+		insts[0].AppendDependsOn(moves[len(moves)-1])
+		a.output[d] = append(moves, insts...)
+	}
+
+	a.removeTransferPrompts(moved)
+
+	return nil
+}
+
+// A plateTransfer is a plate being put into or taken out of the device of a
+// run
+type plateTransfer struct {
+	Run     *drun
+	PlateID string
+	Unload  bool
+}
+
+// removeTransferPrompts removes the prompts asking the user to put plates
+// into or take them out of a device when the plate mover moves all of them.
+// Instructions that depended on a removed prompt depend on its dependencies
+// instead.
+func (a *ir) removeTransferPrompts(moved map[plateTransfer]bool) {
+	covered := func(d *drun, t *target.PlateTransfer) bool {
+		for _, id := range t.PlateIDs {
+			if !moved[plateTransfer{Run: d, PlateID: id, Unload: t.Unload}] {
+				return false
+			}
+		}
+		return len(t.PlateIDs) != 0
+	}
+
+	removed := make(map[ast.Inst][]ast.Inst)
+	for d, insts := range a.output {
+		var kept []ast.Inst
+		for _, inst := range insts {
+			if p, ok := inst.(*target.Prompt); ok && p.Transfer != nil && covered(d, p.Transfer) {
+				removed[inst] = p.DependsOn()
+				continue
+			}
+			kept = append(kept, inst)
+		}
+		a.output[d] = kept
+	}
+
+	if len(removed) == 0 {
+		return
+	}
+
+	var replace func(deps []ast.Inst) []ast.Inst
+	replace = func(deps []ast.Inst) (ret []ast.Inst) {
+		for _, dep := range deps {
+			if rdeps, ok := removed[dep]; ok {
+				ret = append(ret, replace(rdeps)...)
+			} else {
+				ret = append(ret, dep)
+			}
+		}
+		return
+	}
+
+	for _, insts := range a.output {
+		for _, inst := range insts {
+			inst.SetDependsOn(replace(inst.DependsOn())...)
+		}
+	}
+}
+
 // addImplicitInstrs is a cleanup pass to add implicit instructions
-func (a *ir) addImplicitInsts(deviceOrder []*drun) error {
+func (a *ir) addImplicitInsts(ctx context.Context, deviceOrder []*drun) error {
 	if err := a.addImplicitMixInsts(); err != nil {
 		return err
 	}
 
+	a.addImplicitCentrifugeInsts(ctx)
+	a.addImplicitIncubateInsts()
+
+	if err := a.addImplicitMoveInsts(ctx, deviceOrder); err != nil {
+		return err
+	}
+
 	if err := a.addIzers(deviceOrder); err != nil {
 		return err
 	}
@@ -39,6 +39,7 @@ type Description struct {
 // Component is an antha component / element.
 type Component struct {
 	Name        string
+	Tag         string // Version of component, if any
 	Stage       api.ElementStage
 	Constructor func() interface{}
 	Description Description
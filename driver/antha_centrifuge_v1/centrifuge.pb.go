@@ -0,0 +1,494 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/antha-lang/antha/driver/antha_centrifuge_v1/centrifuge.proto
+
+/*
+Package antha_centrifuge_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+	github.com/antha-lang/antha/driver/antha_centrifuge_v1/centrifuge.proto
+
+It has these top-level messages:
+	BoolReply
+	SpinSettings
+	TemperatureSettings
+	Blank
+*/
+package antha_centrifuge_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BoolReply struct {
+	Result bool `protobuf:"varint,1,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *BoolReply) Reset()                    { *m = BoolReply{} }
+func (m *BoolReply) String() string            { return proto.CompactTextString(m) }
+func (*BoolReply) ProtoMessage()               {}
+func (*BoolReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BoolReply) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SpinSettings struct {
+	// Relative centrifugal force in multiples of standard gravity
+	Rcf float64 `protobuf:"fixed64,1,opt,name=rcf" json:"rcf,omitempty"`
+	// Time at speed in seconds
+	Time float64 `protobuf:"fixed64,2,opt,name=time" json:"time,omitempty"`
+	// Time to reach speed in seconds; zero for the device default
+	AccelerationTime float64 `protobuf:"fixed64,3,opt,name=acceleration_time,json=accelerationTime" json:"acceleration_time,omitempty"`
+	// Time to stop in seconds; zero for the device default
+	DecelerationTime float64 `protobuf:"fixed64,4,opt,name=deceleration_time,json=decelerationTime" json:"deceleration_time,omitempty"`
+}
+
+func (m *SpinSettings) Reset()                    { *m = SpinSettings{} }
+func (m *SpinSettings) String() string            { return proto.CompactTextString(m) }
+func (*SpinSettings) ProtoMessage()               {}
+func (*SpinSettings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SpinSettings) GetRcf() float64 {
+	if m != nil {
+		return m.Rcf
+	}
+	return 0
+}
+
+func (m *SpinSettings) GetTime() float64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *SpinSettings) GetAccelerationTime() float64 {
+	if m != nil {
+		return m.AccelerationTime
+	}
+	return 0
+}
+
+func (m *SpinSettings) GetDecelerationTime() float64 {
+	if m != nil {
+		return m.DecelerationTime
+	}
+	return 0
+}
+
+type TemperatureSettings struct {
+	Temperature float64 `protobuf:"fixed64,1,opt,name=temperature" json:"temperature,omitempty"`
+}
+
+func (m *TemperatureSettings) Reset()                    { *m = TemperatureSettings{} }
+func (m *TemperatureSettings) String() string            { return proto.CompactTextString(m) }
+func (*TemperatureSettings) ProtoMessage()               {}
+func (*TemperatureSettings) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *TemperatureSettings) GetTemperature() float64 {
+	if m != nil {
+		return m.Temperature
+	}
+	return 0
+}
+
+type Blank struct {
+}
+
+func (m *Blank) Reset()                    { *m = Blank{} }
+func (m *Blank) String() string            { return proto.CompactTextString(m) }
+func (*Blank) ProtoMessage()               {}
+func (*Blank) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func init() {
+	proto.RegisterType((*BoolReply)(nil), "antha.centrifuge.v1.BoolReply")
+	proto.RegisterType((*SpinSettings)(nil), "antha.centrifuge.v1.SpinSettings")
+	proto.RegisterType((*TemperatureSettings)(nil), "antha.centrifuge.v1.TemperatureSettings")
+	proto.RegisterType((*Blank)(nil), "antha.centrifuge.v1.Blank")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Centrifuge service
+
+type CentrifugeClient interface {
+	Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	LidOpen(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	LidClose(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	SpinStart(ctx context.Context, in *SpinSettings, opts ...grpc.CallOption) (*BoolReply, error)
+	SpinStop(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	TemperatureSet(ctx context.Context, in *TemperatureSettings, opts ...grpc.CallOption) (*BoolReply, error)
+	TemperatureReset(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+}
+
+type centrifugeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCentrifugeClient(cc *grpc.ClientConn) CentrifugeClient {
+	return &centrifugeClient{cc}
+}
+
+func (c *centrifugeClient) Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/Connect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/Disconnect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/Test", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) LidOpen(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/LidOpen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) LidClose(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/LidClose", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) SpinStart(ctx context.Context, in *SpinSettings, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/SpinStart", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) SpinStop(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/SpinStop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) TemperatureSet(ctx context.Context, in *TemperatureSettings, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/TemperatureSet", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *centrifugeClient) TemperatureReset(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.centrifuge.v1.Centrifuge/TemperatureReset", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Centrifuge service
+
+type CentrifugeServer interface {
+	Connect(context.Context, *Blank) (*BoolReply, error)
+	Disconnect(context.Context, *Blank) (*BoolReply, error)
+	Test(context.Context, *Blank) (*BoolReply, error)
+	LidOpen(context.Context, *Blank) (*BoolReply, error)
+	LidClose(context.Context, *Blank) (*BoolReply, error)
+	SpinStart(context.Context, *SpinSettings) (*BoolReply, error)
+	SpinStop(context.Context, *Blank) (*BoolReply, error)
+	TemperatureSet(context.Context, *TemperatureSettings) (*BoolReply, error)
+	TemperatureReset(context.Context, *Blank) (*BoolReply, error)
+}
+
+func RegisterCentrifugeServer(s *grpc.Server, srv CentrifugeServer) {
+	s.RegisterService(&_Centrifuge_serviceDesc, srv)
+}
+
+func _Centrifuge_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).Connect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).Disconnect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).Test(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_LidOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).LidOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/LidOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).LidOpen(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_LidClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).LidClose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/LidClose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).LidClose(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_SpinStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpinSettings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).SpinStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/SpinStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).SpinStart(ctx, req.(*SpinSettings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_SpinStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).SpinStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/SpinStop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).SpinStop(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_TemperatureSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemperatureSettings)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).TemperatureSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/TemperatureSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).TemperatureSet(ctx, req.(*TemperatureSettings))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Centrifuge_TemperatureReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CentrifugeServer).TemperatureReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.centrifuge.v1.Centrifuge/TemperatureReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CentrifugeServer).TemperatureReset(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Centrifuge_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "antha.centrifuge.v1.Centrifuge",
+	HandlerType: (*CentrifugeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Centrifuge_Connect_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _Centrifuge_Disconnect_Handler,
+		},
+		{
+			MethodName: "Test",
+			Handler:    _Centrifuge_Test_Handler,
+		},
+		{
+			MethodName: "LidOpen",
+			Handler:    _Centrifuge_LidOpen_Handler,
+		},
+		{
+			MethodName: "LidClose",
+			Handler:    _Centrifuge_LidClose_Handler,
+		},
+		{
+			MethodName: "SpinStart",
+			Handler:    _Centrifuge_SpinStart_Handler,
+		},
+		{
+			MethodName: "SpinStop",
+			Handler:    _Centrifuge_SpinStop_Handler,
+		},
+		{
+			MethodName: "TemperatureSet",
+			Handler:    _Centrifuge_TemperatureSet_Handler,
+		},
+		{
+			MethodName: "TemperatureReset",
+			Handler:    _Centrifuge_TemperatureReset_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "github.com/antha-lang/antha/driver/antha_centrifuge_v1/centrifuge.proto",
+}
+
+func init() {
+	proto.RegisterFile("github.com/antha-lang/antha/driver/antha_centrifuge_v1/centrifuge.proto", fileDescriptor0)
+}
+
+var fileDescriptor0 = []byte{
+	// 354 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcf, 0x4a, 0xc3, 0x40,
+	0x10, 0xc6, 0x8d, 0xfd, 0x3f, 0x8a, 0xd4, 0x2d, 0x48, 0xe9, 0x41, 0x6a, 0xbc, 0x14, 0xc4, 0x94,
+	0xea, 0xc1, 0x7b, 0x5b, 0xa8, 0x48, 0x41, 0x49, 0x7b, 0x14, 0x4a, 0xba, 0x99, 0xa6, 0x8b, 0xdb,
+	0xdd, 0xb0, 0xd9, 0x14, 0x7c, 0x09, 0x1f, 0xcb, 0xe7, 0x92, 0x6e, 0x6a, 0x9b, 0x4a, 0x0e, 0x85,
+	0x78, 0x9b, 0xf9, 0xe6, 0x37, 0x1f, 0x93, 0xc9, 0x2c, 0x8c, 0x02, 0xa6, 0x97, 0xf1, 0xdc, 0xa1,
+	0x72, 0xd5, 0xf5, 0x84, 0x5e, 0x7a, 0xf7, 0xdc, 0x13, 0x41, 0x12, 0x76, 0x7d, 0xc5, 0xd6, 0xa8,
+	0x92, 0x64, 0x46, 0x51, 0x68, 0xc5, 0x16, 0x71, 0x80, 0xb3, 0x75, 0xaf, 0xbb, 0xcf, 0x9c, 0x50,
+	0x49, 0x2d, 0x49, 0xc3, 0x50, 0x4e, 0x4a, 0x5f, 0xf7, 0xec, 0x5b, 0xa8, 0xf5, 0xa5, 0xe4, 0x2e,
+	0x86, 0xfc, 0x93, 0x5c, 0x41, 0x59, 0x61, 0x14, 0x73, 0xdd, 0xb4, 0xda, 0x56, 0xa7, 0xea, 0x6e,
+	0x33, 0xfb, 0xcb, 0x82, 0xf3, 0x49, 0xc8, 0xc4, 0x04, 0xb5, 0x66, 0x22, 0x88, 0x48, 0x1d, 0x0a,
+	0x8a, 0x2e, 0x0c, 0x65, 0xb9, 0x9b, 0x90, 0x10, 0x28, 0x6a, 0xb6, 0xc2, 0xe6, 0xa9, 0x91, 0x4c,
+	0x4c, 0xee, 0xe0, 0xd2, 0xa3, 0x14, 0x39, 0x2a, 0x4f, 0x33, 0x29, 0x66, 0x06, 0x28, 0x18, 0xa0,
+	0x9e, 0x2e, 0x4c, 0xb7, 0xb0, 0x8f, 0x7f, 0xe1, 0x62, 0x02, 0xfb, 0x78, 0x08, 0xdb, 0x4f, 0xd0,
+	0x98, 0xe2, 0x2a, 0xdc, 0x48, 0xb1, 0xc2, 0xdd, 0x58, 0x6d, 0x38, 0xd3, 0x7b, 0x79, 0x3b, 0x5e,
+	0x5a, 0xb2, 0x2b, 0x50, 0xea, 0x73, 0x4f, 0x7c, 0x3c, 0x7c, 0x97, 0x00, 0x06, 0xbb, 0x4d, 0x90,
+	0x11, 0x54, 0x06, 0x52, 0x08, 0xa4, 0x9a, 0xb4, 0x9c, 0x8c, 0x3d, 0x39, 0xa6, 0xab, 0x75, 0x9d,
+	0x5d, 0xfb, 0x5d, 0xa0, 0x7d, 0x42, 0x5e, 0x00, 0x86, 0x2c, 0xa2, 0xff, 0xe2, 0x35, 0x84, 0xe2,
+	0x14, 0xa3, 0xbc, 0x2e, 0x23, 0xa8, 0x8c, 0x99, 0xff, 0x1a, 0xa2, 0xc8, 0x69, 0xf4, 0x0c, 0xd5,
+	0x31, 0xf3, 0x07, 0x5c, 0x46, 0x98, 0xd3, 0xe9, 0x0d, 0x6a, 0xe6, 0x9c, 0xb4, 0xa7, 0x34, 0xb9,
+	0xc9, 0xc4, 0xd3, 0xe7, 0x76, 0xdc, 0x6c, 0x89, 0xa3, 0x0c, 0x73, 0xce, 0xf6, 0x0e, 0x17, 0x87,
+	0xa7, 0x45, 0x3a, 0x99, 0x3d, 0x19, 0xf7, 0x77, 0xd4, 0x97, 0xd7, 0x53, 0x8d, 0x2e, 0x46, 0x98,
+	0xf3, 0xf7, 0xce, 0xcb, 0xe6, 0x71, 0x3f, 0xfe, 0x0c, 0x00, 0x95, 0xd4, 0xf1, 0xc7, 0x27, 0x04,
+	0x00, 0x00,
+}
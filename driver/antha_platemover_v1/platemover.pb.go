@@ -0,0 +1,400 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/antha-lang/antha/driver/antha_platemover_v1/platemover.proto
+
+/*
+Package antha_platemover_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+	github.com/antha-lang/antha/driver/antha_platemover_v1/platemover.proto
+
+It has these top-level messages:
+	BoolReply
+	Nest
+	NestsReply
+	MoveRequest
+	Blank
+*/
+package antha_platemover_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BoolReply struct {
+	Result bool `protobuf:"varint,1,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *BoolReply) Reset()                    { *m = BoolReply{} }
+func (m *BoolReply) String() string            { return proto.CompactTextString(m) }
+func (*BoolReply) ProtoMessage()               {}
+func (*BoolReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BoolReply) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// A Nest is a position on a device that the mover can put plates in and
+// take plates from
+type Nest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Driver type of the device the nest belongs to, e.g.,
+	// antha.platereader.v1.PlateReader
+	Device string `protobuf:"bytes,2,opt,name=device" json:"device,omitempty"`
+	// Labware types the nest accepts; empty if it accepts any
+	Labware []string `protobuf:"bytes,3,rep,name=labware" json:"labware,omitempty"`
+}
+
+func (m *Nest) Reset()                    { *m = Nest{} }
+func (m *Nest) String() string            { return proto.CompactTextString(m) }
+func (*Nest) ProtoMessage()               {}
+func (*Nest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Nest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Nest) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *Nest) GetLabware() []string {
+	if m != nil {
+		return m.Labware
+	}
+	return nil
+}
+
+type NestsReply struct {
+	Nests []*Nest `protobuf:"bytes,1,rep,name=nests" json:"nests,omitempty"`
+}
+
+func (m *NestsReply) Reset()                    { *m = NestsReply{} }
+func (m *NestsReply) String() string            { return proto.CompactTextString(m) }
+func (*NestsReply) ProtoMessage()               {}
+func (*NestsReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *NestsReply) GetNests() []*Nest {
+	if m != nil {
+		return m.Nests
+	}
+	return nil
+}
+
+type MoveRequest struct {
+	PlateId string `protobuf:"bytes,1,opt,name=plate_id,json=plateId" json:"plate_id,omitempty"`
+	// Labware type of the plate
+	Labware string `protobuf:"bytes,2,opt,name=labware" json:"labware,omitempty"`
+	// Name of the nest to take the plate from
+	Source string `protobuf:"bytes,3,opt,name=source" json:"source,omitempty"`
+	// Name of the nest to put the plate in
+	Destination string `protobuf:"bytes,4,opt,name=destination" json:"destination,omitempty"`
+}
+
+func (m *MoveRequest) Reset()                    { *m = MoveRequest{} }
+func (m *MoveRequest) String() string            { return proto.CompactTextString(m) }
+func (*MoveRequest) ProtoMessage()               {}
+func (*MoveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *MoveRequest) GetPlateId() string {
+	if m != nil {
+		return m.PlateId
+	}
+	return ""
+}
+
+func (m *MoveRequest) GetLabware() string {
+	if m != nil {
+		return m.Labware
+	}
+	return ""
+}
+
+func (m *MoveRequest) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *MoveRequest) GetDestination() string {
+	if m != nil {
+		return m.Destination
+	}
+	return ""
+}
+
+type Blank struct {
+}
+
+func (m *Blank) Reset()                    { *m = Blank{} }
+func (m *Blank) String() string            { return proto.CompactTextString(m) }
+func (*Blank) ProtoMessage()               {}
+func (*Blank) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func init() {
+	proto.RegisterType((*BoolReply)(nil), "antha.platemover.v1.BoolReply")
+	proto.RegisterType((*Nest)(nil), "antha.platemover.v1.Nest")
+	proto.RegisterType((*NestsReply)(nil), "antha.platemover.v1.NestsReply")
+	proto.RegisterType((*MoveRequest)(nil), "antha.platemover.v1.MoveRequest")
+	proto.RegisterType((*Blank)(nil), "antha.platemover.v1.Blank")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for PlateMover service
+
+type PlateMoverClient interface {
+	Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	ListNests(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*NestsReply, error)
+	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*BoolReply, error)
+}
+
+type plateMoverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPlateMoverClient(cc *grpc.ClientConn) PlateMoverClient {
+	return &plateMoverClient{cc}
+}
+
+func (c *plateMoverClient) Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/Connect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plateMoverClient) Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/Disconnect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plateMoverClient) Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/Test", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plateMoverClient) ListNests(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*NestsReply, error) {
+	out := new(NestsReply)
+	err := grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/ListNests", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *plateMoverClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/Move", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for PlateMover service
+
+type PlateMoverServer interface {
+	Connect(context.Context, *Blank) (*BoolReply, error)
+	Disconnect(context.Context, *Blank) (*BoolReply, error)
+	Test(context.Context, *Blank) (*BoolReply, error)
+	ListNests(context.Context, *Blank) (*NestsReply, error)
+	Move(context.Context, *MoveRequest) (*BoolReply, error)
+}
+
+func RegisterPlateMoverServer(s *grpc.Server, srv PlateMoverServer) {
+	s.RegisterService(&_PlateMover_serviceDesc, srv)
+}
+
+func _PlateMover_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlateMoverServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.platemover.v1.PlateMover/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlateMoverServer).Connect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlateMover_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlateMoverServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.platemover.v1.PlateMover/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlateMoverServer).Disconnect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlateMover_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlateMoverServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.platemover.v1.PlateMover/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlateMoverServer).Test(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlateMover_ListNests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlateMoverServer).ListNests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.platemover.v1.PlateMover/ListNests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlateMoverServer).ListNests(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlateMover_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlateMoverServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.platemover.v1.PlateMover/Move",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlateMoverServer).Move(ctx, req.(*MoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PlateMover_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "antha.platemover.v1.PlateMover",
+	HandlerType: (*PlateMoverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _PlateMover_Connect_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _PlateMover_Disconnect_Handler,
+		},
+		{
+			MethodName: "Test",
+			Handler:    _PlateMover_Test_Handler,
+		},
+		{
+			MethodName: "ListNests",
+			Handler:    _PlateMover_ListNests_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _PlateMover_Move_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "github.com/antha-lang/antha/driver/antha_platemover_v1/platemover.proto",
+}
+
+func init() {
+	proto.RegisterFile("github.com/antha-lang/antha/driver/antha_platemover_v1/platemover.proto", fileDescriptor0)
+}
+
+var fileDescriptor0 = []byte{
+	// 353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x41, 0x6f, 0xe2, 0x30,
+	0x10, 0x85, 0x17, 0x08, 0x84, 0x0c, 0x37, 0xaf, 0xb4, 0x0a, 0x1c, 0x76, 0xa3, 0xec, 0x85, 0x4b,
+	0x13, 0x41, 0xcf, 0xbd, 0x50, 0x24, 0x54, 0x44, 0xab, 0x2a, 0xea, 0x1d, 0x99, 0x64, 0x04, 0x56,
+	0x83, 0x4d, 0x6d, 0x27, 0x55, 0xa5, 0xfe, 0xf7, 0x56, 0x76, 0x40, 0xe4, 0x90, 0xb6, 0x87, 0xf6,
+	0xe6, 0x37, 0xf3, 0xfc, 0xe6, 0x4b, 0xc6, 0xb0, 0xd8, 0x32, 0xbd, 0x2b, 0x36, 0x51, 0x2a, 0xf6,
+	0x31, 0xe5, 0x7a, 0x47, 0x2f, 0x72, 0xca, 0xb7, 0xd5, 0x31, 0xce, 0x24, 0x2b, 0x51, 0x56, 0x62,
+	0x7d, 0xc8, 0xa9, 0xc6, 0xbd, 0x28, 0x51, 0xae, 0xcb, 0x49, 0x7c, 0x56, 0xd1, 0x41, 0x0a, 0x2d,
+	0xc8, 0x6f, 0xeb, 0x8a, 0x6a, 0xf5, 0x72, 0x12, 0xfe, 0x07, 0x6f, 0x26, 0x44, 0x9e, 0xe0, 0x21,
+	0x7f, 0x21, 0x7f, 0xa0, 0x27, 0x51, 0x15, 0xb9, 0xf6, 0x5b, 0x41, 0x6b, 0xdc, 0x4f, 0x8e, 0x2a,
+	0x5c, 0x81, 0x73, 0x87, 0x4a, 0x13, 0x02, 0x0e, 0xa7, 0x7b, 0xb4, 0x5d, 0x2f, 0xb1, 0x67, 0x73,
+	0x27, 0xc3, 0x92, 0xa5, 0xe8, 0xb7, 0x6d, 0xf5, 0xa8, 0x88, 0x0f, 0x6e, 0x4e, 0x37, 0xcf, 0x54,
+	0xa2, 0xdf, 0x09, 0x3a, 0x63, 0x2f, 0x39, 0xc9, 0xf0, 0x0a, 0xc0, 0xa4, 0xa9, 0x6a, 0x66, 0x0c,
+	0x5d, 0x6e, 0x94, 0xdf, 0x0a, 0x3a, 0xe3, 0xc1, 0x74, 0x18, 0x35, 0x50, 0x46, 0xc6, 0x9f, 0x54,
+	0xbe, 0xf0, 0x15, 0x06, 0xb7, 0xa2, 0xc4, 0x04, 0x9f, 0x0a, 0xc3, 0x34, 0x84, 0xbe, 0xf5, 0xae,
+	0x59, 0x76, 0xe4, 0x72, 0xad, 0xbe, 0xc9, 0xea, 0x08, 0x15, 0xdb, 0x49, 0x1a, 0x68, 0x25, 0x0a,
+	0x99, 0x1a, 0x36, 0x0b, 0x5d, 0x29, 0x12, 0xc0, 0x20, 0x43, 0xa5, 0x19, 0xa7, 0x9a, 0x09, 0xee,
+	0x3b, 0xb6, 0x59, 0x2f, 0x85, 0x2e, 0x74, 0x67, 0x39, 0xe5, 0x8f, 0xd3, 0xb7, 0x36, 0xc0, 0xbd,
+	0x19, 0x64, 0x60, 0x24, 0x59, 0x80, 0x7b, 0x2d, 0x38, 0xc7, 0x54, 0x93, 0x51, 0xe3, 0x27, 0xd8,
+	0x5b, 0xa3, 0xbf, 0xcd, 0xbd, 0xd3, 0x06, 0xc2, 0x5f, 0x64, 0x09, 0x30, 0x67, 0x2a, 0xfd, 0x91,
+	0xac, 0x39, 0x38, 0x0f, 0xa8, 0xbe, 0x9b, 0xb2, 0x04, 0x6f, 0xc5, 0x94, 0xb6, 0x3b, 0xfb, 0x34,
+	0xea, 0xdf, 0x87, 0xbb, 0x53, 0xe7, 0x2c, 0xc7, 0xfc, 0x2f, 0x12, 0x34, 0x5a, 0x6b, 0x7b, 0xfd,
+	0x9a, 0x6b, 0xd3, 0xb3, 0xcf, 0xfa, 0xf2, 0x7d, 0x00, 0xef, 0xd5, 0xb0, 0xbf, 0x21, 0x03, 0x00,
+	0x00,
+}
@@ -0,0 +1,309 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/antha-lang/antha/driver/antha_sealer_v1/sealer.proto
+
+/*
+Package antha_sealer_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+	github.com/antha-lang/antha/driver/antha_sealer_v1/sealer.proto
+
+It has these top-level messages:
+	BoolReply
+	SealRequest
+	Blank
+*/
+package antha_sealer_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BoolReply struct {
+	Result bool `protobuf:"varint,1,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *BoolReply) Reset()                    { *m = BoolReply{} }
+func (m *BoolReply) String() string            { return proto.CompactTextString(m) }
+func (*BoolReply) ProtoMessage()               {}
+func (*BoolReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BoolReply) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type SealRequest struct {
+	// Plate to seal or peel
+	PlateId string `protobuf:"bytes,1,opt,name=plate_id,json=plateId" json:"plate_id,omitempty"`
+}
+
+func (m *SealRequest) Reset()                    { *m = SealRequest{} }
+func (m *SealRequest) String() string            { return proto.CompactTextString(m) }
+func (*SealRequest) ProtoMessage()               {}
+func (*SealRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SealRequest) GetPlateId() string {
+	if m != nil {
+		return m.PlateId
+	}
+	return ""
+}
+
+type Blank struct {
+}
+
+func (m *Blank) Reset()                    { *m = Blank{} }
+func (m *Blank) String() string            { return proto.CompactTextString(m) }
+func (*Blank) ProtoMessage()               {}
+func (*Blank) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func init() {
+	proto.RegisterType((*BoolReply)(nil), "antha.sealer.v1.BoolReply")
+	proto.RegisterType((*SealRequest)(nil), "antha.sealer.v1.SealRequest")
+	proto.RegisterType((*Blank)(nil), "antha.sealer.v1.Blank")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Sealer service
+
+type SealerClient interface {
+	Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Seal(ctx context.Context, in *SealRequest, opts ...grpc.CallOption) (*BoolReply, error)
+	Peel(ctx context.Context, in *SealRequest, opts ...grpc.CallOption) (*BoolReply, error)
+}
+
+type sealerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSealerClient(cc *grpc.ClientConn) SealerClient {
+	return &sealerClient{cc}
+}
+
+func (c *sealerClient) Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.sealer.v1.Sealer/Connect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sealerClient) Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.sealer.v1.Sealer/Disconnect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sealerClient) Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.sealer.v1.Sealer/Test", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sealerClient) Seal(ctx context.Context, in *SealRequest, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.sealer.v1.Sealer/Seal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sealerClient) Peel(ctx context.Context, in *SealRequest, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.sealer.v1.Sealer/Peel", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Sealer service
+
+type SealerServer interface {
+	Connect(context.Context, *Blank) (*BoolReply, error)
+	Disconnect(context.Context, *Blank) (*BoolReply, error)
+	Test(context.Context, *Blank) (*BoolReply, error)
+	Seal(context.Context, *SealRequest) (*BoolReply, error)
+	Peel(context.Context, *SealRequest) (*BoolReply, error)
+}
+
+func RegisterSealerServer(s *grpc.Server, srv SealerServer) {
+	s.RegisterService(&_Sealer_serviceDesc, srv)
+}
+
+func _Sealer_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealerServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.sealer.v1.Sealer/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealerServer).Connect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sealer_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealerServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.sealer.v1.Sealer/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealerServer).Disconnect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sealer_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealerServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.sealer.v1.Sealer/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealerServer).Test(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sealer_Seal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealerServer).Seal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.sealer.v1.Sealer/Seal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealerServer).Seal(ctx, req.(*SealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sealer_Peel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealerServer).Peel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.sealer.v1.Sealer/Peel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealerServer).Peel(ctx, req.(*SealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Sealer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "antha.sealer.v1.Sealer",
+	HandlerType: (*SealerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Sealer_Connect_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _Sealer_Disconnect_Handler,
+		},
+		{
+			MethodName: "Test",
+			Handler:    _Sealer_Test_Handler,
+		},
+		{
+			MethodName: "Seal",
+			Handler:    _Sealer_Seal_Handler,
+		},
+		{
+			MethodName: "Peel",
+			Handler:    _Sealer_Peel_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "github.com/antha-lang/antha/driver/antha_sealer_v1/sealer.proto",
+}
+
+func init() {
+	proto.RegisterFile("github.com/antha-lang/antha/driver/antha_sealer_v1/sealer.proto", fileDescriptor0)
+}
+
+var fileDescriptor0 = []byte{
+	// 238 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xb2, 0x4f, 0xcf, 0x2c, 0xc9,
+	0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0xd5, 0x4f, 0xcc, 0x2b, 0xc9, 0x48, 0xd4, 0xcd, 0x49, 0xcc,
+	0x4b, 0x87, 0x30, 0xf5, 0x53, 0x8a, 0x32, 0xcb, 0x52, 0x8b, 0x20, 0x9c, 0xf8, 0xe2, 0xd4, 0xc4,
+	0x9c, 0xd4, 0xa2, 0xf8, 0x32, 0x43, 0x7d, 0x08, 0x4b, 0xaf, 0xa0, 0x28, 0xbf, 0x24, 0x5f, 0x88,
+	0x1f, 0x2c, 0xab, 0x07, 0x15, 0x2b, 0x33, 0x54, 0x52, 0xe6, 0xe2, 0x74, 0xca, 0xcf, 0xcf, 0x09,
+	0x4a, 0x2d, 0xc8, 0xa9, 0x14, 0x12, 0xe3, 0x62, 0x2b, 0x4a, 0x2d, 0x2e, 0xcd, 0x29, 0x91, 0x60,
+	0x54, 0x60, 0xd4, 0xe0, 0x08, 0x82, 0xf2, 0x94, 0x34, 0xb8, 0xb8, 0x83, 0x53, 0x13, 0x73, 0x82,
+	0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x84, 0x24, 0xb9, 0x38, 0x0a, 0x72, 0x12, 0x4b, 0x52, 0xe3,
+	0x33, 0x53, 0xc0, 0x0a, 0x39, 0x83, 0xd8, 0xc1, 0x7c, 0xcf, 0x14, 0x25, 0x76, 0x2e, 0x56, 0xa7,
+	0x9c, 0xc4, 0xbc, 0x6c, 0xa3, 0xcb, 0x4c, 0x5c, 0x6c, 0xc1, 0x60, 0x5b, 0x84, 0xec, 0xb9, 0xd8,
+	0x9d, 0xf3, 0xf3, 0xf2, 0x52, 0x93, 0x4b, 0x84, 0xc4, 0xf4, 0xd0, 0xec, 0xd7, 0x03, 0xab, 0x96,
+	0x92, 0xc2, 0x14, 0x87, 0x39, 0x4a, 0x89, 0x41, 0xc8, 0x89, 0x8b, 0xcb, 0x25, 0xb3, 0x38, 0x99,
+	0x22, 0x33, 0x6c, 0xb8, 0x58, 0x42, 0x52, 0x8b, 0xc9, 0xd5, 0xed, 0xc4, 0xc5, 0x02, 0xf2, 0x8c,
+	0x90, 0x0c, 0x86, 0x2a, 0xa4, 0x70, 0x21, 0x6c, 0x46, 0x40, 0x6a, 0x2a, 0x45, 0x66, 0x24, 0xb1,
+	0x81, 0x63, 0xd1, 0x18, 0x30, 0x00, 0xcc, 0x0e, 0x27, 0x23, 0x08, 0x02, 0x00, 0x00,
+}
@@ -0,0 +1,522 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: github.com/antha-lang/antha/driver/antha_thermocycler_v1/thermocycler.proto
+
+/*
+Package antha_thermocycler_v1 is a generated protocol buffer package.
+
+It is generated from these files:
+	github.com/antha-lang/antha/driver/antha_thermocycler_v1/thermocycler.proto
+
+It has these top-level messages:
+	BoolReply
+	Step
+	Stage
+	Programme
+	ProgrammeRequest
+	Blank
+*/
+package antha_thermocycler_v1
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BoolReply struct {
+	Result bool `protobuf:"varint,1,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *BoolReply) Reset()                    { *m = BoolReply{} }
+func (m *BoolReply) String() string            { return proto.CompactTextString(m) }
+func (*BoolReply) ProtoMessage()               {}
+func (*BoolReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BoolReply) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+type Step struct {
+	// Temperature in C
+	Temperature float64 `protobuf:"fixed64,1,opt,name=temperature" json:"temperature,omitempty"`
+	// Time in seconds
+	Time float64 `protobuf:"fixed64,2,opt,name=time" json:"time,omitempty"`
+	// Ramp rate in C per second; zero for the device maximum
+	RampRate float64 `protobuf:"fixed64,3,opt,name=ramp_rate,json=rampRate" json:"ramp_rate,omitempty"`
+}
+
+func (m *Step) Reset()                    { *m = Step{} }
+func (m *Step) String() string            { return proto.CompactTextString(m) }
+func (*Step) ProtoMessage()               {}
+func (*Step) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Step) GetTemperature() float64 {
+	if m != nil {
+		return m.Temperature
+	}
+	return 0
+}
+
+func (m *Step) GetTime() float64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Step) GetRampRate() float64 {
+	if m != nil {
+		return m.RampRate
+	}
+	return 0
+}
+
+type Stage struct {
+	Cycles int32   `protobuf:"varint,1,opt,name=cycles" json:"cycles,omitempty"`
+	Steps  []*Step `protobuf:"bytes,2,rep,name=steps" json:"steps,omitempty"`
+}
+
+func (m *Stage) Reset()                    { *m = Stage{} }
+func (m *Stage) String() string            { return proto.CompactTextString(m) }
+func (*Stage) ProtoMessage()               {}
+func (*Stage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Stage) GetCycles() int32 {
+	if m != nil {
+		return m.Cycles
+	}
+	return 0
+}
+
+func (m *Stage) GetSteps() []*Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type Programme struct {
+	// Lid temperature in C; ignored unless has_lid_temperature is set
+	LidTemperature    float64  `protobuf:"fixed64,1,opt,name=lid_temperature,json=lidTemperature" json:"lid_temperature,omitempty"`
+	HasLidTemperature bool     `protobuf:"varint,2,opt,name=has_lid_temperature,json=hasLidTemperature" json:"has_lid_temperature,omitempty"`
+	Stages            []*Stage `protobuf:"bytes,3,rep,name=stages" json:"stages,omitempty"`
+	// Final hold temperature in C; ignored unless has_hold_temperature is set
+	HoldTemperature    float64 `protobuf:"fixed64,4,opt,name=hold_temperature,json=holdTemperature" json:"hold_temperature,omitempty"`
+	HasHoldTemperature bool    `protobuf:"varint,5,opt,name=has_hold_temperature,json=hasHoldTemperature" json:"has_hold_temperature,omitempty"`
+}
+
+func (m *Programme) Reset()                    { *m = Programme{} }
+func (m *Programme) String() string            { return proto.CompactTextString(m) }
+func (*Programme) ProtoMessage()               {}
+func (*Programme) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *Programme) GetLidTemperature() float64 {
+	if m != nil {
+		return m.LidTemperature
+	}
+	return 0
+}
+
+func (m *Programme) GetHasLidTemperature() bool {
+	if m != nil {
+		return m.HasLidTemperature
+	}
+	return false
+}
+
+func (m *Programme) GetStages() []*Stage {
+	if m != nil {
+		return m.Stages
+	}
+	return nil
+}
+
+func (m *Programme) GetHoldTemperature() float64 {
+	if m != nil {
+		return m.HoldTemperature
+	}
+	return 0
+}
+
+func (m *Programme) GetHasHoldTemperature() bool {
+	if m != nil {
+		return m.HasHoldTemperature
+	}
+	return false
+}
+
+type ProgrammeRequest struct {
+	PlateId string `protobuf:"bytes,1,opt,name=plate_id,json=plateId" json:"plate_id,omitempty"`
+	// Wells to run in A1 format
+	Wells     []string   `protobuf:"bytes,2,rep,name=wells" json:"wells,omitempty"`
+	Programme *Programme `protobuf:"bytes,3,opt,name=programme" json:"programme,omitempty"`
+}
+
+func (m *ProgrammeRequest) Reset()                    { *m = ProgrammeRequest{} }
+func (m *ProgrammeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProgrammeRequest) ProtoMessage()               {}
+func (*ProgrammeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ProgrammeRequest) GetPlateId() string {
+	if m != nil {
+		return m.PlateId
+	}
+	return ""
+}
+
+func (m *ProgrammeRequest) GetWells() []string {
+	if m != nil {
+		return m.Wells
+	}
+	return nil
+}
+
+func (m *ProgrammeRequest) GetProgramme() *Programme {
+	if m != nil {
+		return m.Programme
+	}
+	return nil
+}
+
+type Blank struct {
+}
+
+func (m *Blank) Reset()                    { *m = Blank{} }
+func (m *Blank) String() string            { return proto.CompactTextString(m) }
+func (*Blank) ProtoMessage()               {}
+func (*Blank) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func init() {
+	proto.RegisterType((*BoolReply)(nil), "antha.thermocycler.v1.BoolReply")
+	proto.RegisterType((*Step)(nil), "antha.thermocycler.v1.Step")
+	proto.RegisterType((*Stage)(nil), "antha.thermocycler.v1.Stage")
+	proto.RegisterType((*Programme)(nil), "antha.thermocycler.v1.Programme")
+	proto.RegisterType((*ProgrammeRequest)(nil), "antha.thermocycler.v1.ProgrammeRequest")
+	proto.RegisterType((*Blank)(nil), "antha.thermocycler.v1.Blank")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Thermocycler service
+
+type ThermocyclerClient interface {
+	Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	LidOpen(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	LidClose(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+	ProgrammeStart(ctx context.Context, in *ProgrammeRequest, opts ...grpc.CallOption) (*BoolReply, error)
+	ProgrammeStop(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error)
+}
+
+type thermocyclerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewThermocyclerClient(cc *grpc.ClientConn) ThermocyclerClient {
+	return &thermocyclerClient{cc}
+}
+
+func (c *thermocyclerClient) Connect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/Connect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) Disconnect(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/Disconnect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) Test(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/Test", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) LidOpen(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/LidOpen", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) LidClose(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/LidClose", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) ProgrammeStart(ctx context.Context, in *ProgrammeRequest, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/ProgrammeStart", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *thermocyclerClient) ProgrammeStop(ctx context.Context, in *Blank, opts ...grpc.CallOption) (*BoolReply, error) {
+	out := new(BoolReply)
+	err := grpc.Invoke(ctx, "/antha.thermocycler.v1.Thermocycler/ProgrammeStop", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Thermocycler service
+
+type ThermocyclerServer interface {
+	Connect(context.Context, *Blank) (*BoolReply, error)
+	Disconnect(context.Context, *Blank) (*BoolReply, error)
+	Test(context.Context, *Blank) (*BoolReply, error)
+	LidOpen(context.Context, *Blank) (*BoolReply, error)
+	LidClose(context.Context, *Blank) (*BoolReply, error)
+	ProgrammeStart(context.Context, *ProgrammeRequest) (*BoolReply, error)
+	ProgrammeStop(context.Context, *Blank) (*BoolReply, error)
+}
+
+func RegisterThermocyclerServer(s *grpc.Server, srv ThermocyclerServer) {
+	s.RegisterService(&_Thermocycler_serviceDesc, srv)
+}
+
+func _Thermocycler_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/Connect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).Connect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/Disconnect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).Disconnect(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_Test_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).Test(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/Test",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).Test(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_LidOpen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).LidOpen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/LidOpen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).LidOpen(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_LidClose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).LidClose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/LidClose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).LidClose(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_ProgrammeStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProgrammeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).ProgrammeStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/ProgrammeStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).ProgrammeStart(ctx, req.(*ProgrammeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Thermocycler_ProgrammeStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Blank)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThermocyclerServer).ProgrammeStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/antha.thermocycler.v1.Thermocycler/ProgrammeStop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThermocyclerServer).ProgrammeStop(ctx, req.(*Blank))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Thermocycler_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "antha.thermocycler.v1.Thermocycler",
+	HandlerType: (*ThermocyclerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Connect",
+			Handler:    _Thermocycler_Connect_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _Thermocycler_Disconnect_Handler,
+		},
+		{
+			MethodName: "Test",
+			Handler:    _Thermocycler_Test_Handler,
+		},
+		{
+			MethodName: "LidOpen",
+			Handler:    _Thermocycler_LidOpen_Handler,
+		},
+		{
+			MethodName: "LidClose",
+			Handler:    _Thermocycler_LidClose_Handler,
+		},
+		{
+			MethodName: "ProgrammeStart",
+			Handler:    _Thermocycler_ProgrammeStart_Handler,
+		},
+		{
+			MethodName: "ProgrammeStop",
+			Handler:    _Thermocycler_ProgrammeStop_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "github.com/antha-lang/antha/driver/antha_thermocycler_v1/thermocycler.proto",
+}
+
+func init() {
+	proto.RegisterFile("github.com/antha-lang/antha/driver/antha_thermocycler_v1/thermocycler.proto", fileDescriptor0)
+}
+
+var fileDescriptor0 = []byte{
+	// 489 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x25, 0x8d, 0x9d, 0xc4, 0x13, 0x68, 0xcb, 0x52, 0x90, 0xa1, 0x1c, 0x22, 0x73, 0x68, 0x39,
+	0xe0, 0x90, 0xc0, 0x99, 0x43, 0xcb, 0xa1, 0x28, 0x11, 0x1f, 0x9b, 0x5c, 0x38, 0x20, 0x6b, 0x6b,
+	0x8f, 0x6c, 0x8b, 0xb5, 0x77, 0xd9, 0xdd, 0x04, 0xf5, 0xcc, 0x3f, 0xe3, 0x4f, 0x71, 0x45, 0x5e,
+	0x87, 0xd4, 0xa1, 0x44, 0x20, 0x91, 0x9b, 0xdf, 0xcc, 0x9b, 0x37, 0x6f, 0x66, 0x56, 0x86, 0x49,
+	0x9a, 0x9b, 0x6c, 0x71, 0x19, 0xc6, 0xa2, 0x18, 0xb2, 0xd2, 0x64, 0xec, 0x19, 0x67, 0x65, 0x5a,
+	0x7f, 0x0e, 0x13, 0x95, 0x2f, 0x51, 0xd5, 0x20, 0x32, 0x19, 0xaa, 0x42, 0xc4, 0x57, 0x31, 0x47,
+	0x15, 0x2d, 0x47, 0xc3, 0x26, 0x0e, 0xa5, 0x12, 0x46, 0x90, 0xfb, 0x96, 0x19, 0x6e, 0x64, 0x96,
+	0xa3, 0xe0, 0x09, 0x78, 0x67, 0x42, 0x70, 0x8a, 0x92, 0x5f, 0x91, 0x07, 0xd0, 0x51, 0xa8, 0x17,
+	0xdc, 0xf8, 0xad, 0x41, 0xeb, 0xb4, 0x47, 0x57, 0x28, 0xf8, 0x08, 0xce, 0xcc, 0xa0, 0x24, 0x03,
+	0xe8, 0x1b, 0x2c, 0x24, 0x2a, 0x66, 0x16, 0x0a, 0x2d, 0xa9, 0x45, 0x9b, 0x21, 0x42, 0xc0, 0x31,
+	0x79, 0x81, 0xfe, 0x9e, 0x4d, 0xd9, 0x6f, 0x72, 0x0c, 0x9e, 0x62, 0x85, 0x8c, 0x14, 0x33, 0xe8,
+	0xb7, 0x6d, 0xa2, 0x57, 0x05, 0x28, 0x33, 0x18, 0x50, 0x70, 0x67, 0x86, 0xa5, 0x58, 0xf5, 0xb6,
+	0xae, 0xb4, 0x95, 0x75, 0xe9, 0x0a, 0x91, 0x11, 0xb8, 0xda, 0xa0, 0xd4, 0xfe, 0xde, 0xa0, 0x7d,
+	0xda, 0x1f, 0x1f, 0x87, 0x7f, 0x9c, 0x23, 0xac, 0xfc, 0xd1, 0x9a, 0x19, 0xfc, 0x68, 0x81, 0xf7,
+	0x5e, 0x89, 0x54, 0xb1, 0xa2, 0x40, 0x72, 0x02, 0x07, 0x3c, 0x4f, 0xa2, 0x9b, 0xc6, 0xf7, 0x79,
+	0x9e, 0xcc, 0x1b, 0xde, 0x43, 0xb8, 0x97, 0x31, 0x1d, 0xfd, 0x4e, 0xde, 0xb3, 0xab, 0xb8, 0x9b,
+	0x31, 0x3d, 0xdd, 0xe4, 0xbf, 0x84, 0x8e, 0xae, 0xac, 0x6b, 0xbf, 0x6d, 0xad, 0x3d, 0xde, 0x6a,
+	0x8d, 0xa5, 0x48, 0x57, 0x5c, 0xf2, 0x14, 0x0e, 0x33, 0xc1, 0x37, 0x5b, 0x38, 0xd6, 0xcf, 0x41,
+	0x15, 0x6f, 0x36, 0x78, 0x0e, 0x47, 0x95, 0xa1, 0x1b, 0x74, 0xd7, 0x3a, 0x22, 0x19, 0xd3, 0x17,
+	0x9b, 0x15, 0xc1, 0xb7, 0x16, 0x1c, 0xae, 0x27, 0xa7, 0xf8, 0x65, 0x81, 0xda, 0x90, 0x87, 0xd0,
+	0x93, 0x9c, 0x19, 0x8c, 0xf2, 0xc4, 0x4e, 0xee, 0xd1, 0xae, 0xc5, 0x6f, 0x12, 0x72, 0x04, 0xee,
+	0x57, 0xe4, 0xbc, 0x5e, 0xae, 0x47, 0x6b, 0x40, 0x5e, 0x81, 0x27, 0x7f, 0x89, 0xd8, 0x83, 0xf5,
+	0xc7, 0x83, 0x2d, 0xb3, 0x5d, 0x37, 0xbb, 0x2e, 0x09, 0xba, 0xe0, 0x9e, 0x71, 0x56, 0x7e, 0x1e,
+	0x7f, 0x77, 0xe0, 0xf6, 0xbc, 0x51, 0x41, 0x26, 0xd0, 0x3d, 0x17, 0x65, 0x89, 0xb1, 0x21, 0xdb,
+	0xb6, 0x65, 0x2b, 0x1f, 0x6d, 0xeb, 0xb7, 0x7e, 0xab, 0xc1, 0x2d, 0xf2, 0x16, 0xe0, 0x75, 0xae,
+	0xe3, 0x9d, 0xe9, 0x5d, 0x80, 0x33, 0x47, 0xbd, 0x0b, 0xa5, 0x09, 0x74, 0xa7, 0x79, 0xf2, 0x4e,
+	0x62, 0xb9, 0x03, 0xb1, 0x29, 0xf4, 0xa6, 0x79, 0x72, 0xce, 0x85, 0xc6, 0x1d, 0xa8, 0x7d, 0x82,
+	0xfd, 0xf5, 0xcd, 0x66, 0x86, 0x29, 0x43, 0x4e, 0xfe, 0x7a, 0xda, 0xfa, 0x1d, 0xfd, 0x93, 0xfc,
+	0x07, 0xb8, 0xd3, 0x90, 0x17, 0xf2, 0xff, 0x1d, 0x5f, 0x76, 0xec, 0xff, 0xeb, 0xc5, 0xcf, 0x01,
+	0x00, 0x51, 0x23, 0xc3, 0x78, 0x0e, 0x05, 0x00, 0x00,
+}
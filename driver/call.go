@@ -1,6 +1,9 @@
 package driver
 
-import "github.com/golang/protobuf/proto"
+import (
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/golang/protobuf/proto"
+)
 
 // A Call is a generic call to a device
 type Call struct {
@@ -8,3 +11,12 @@ type Call struct {
 	Args   proto.Message
 	Reply  proto.Message
 }
+
+// Seconds returns a time in seconds, as devices take it, or zero if it is not
+// set
+func Seconds(t wunit.Time) float64 {
+	if t.IsNil() {
+		return 0
+	}
+	return t.Seconds()
+}
@@ -5,6 +5,10 @@
 //go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_platereader_v1/platereader.proto --go_out=plugins=grpc:${GOPATH}/src
 //go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_framework_v1/framework.proto --go_out=plugins=grpc:${GOPATH}/src
 //go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_quantstudio_v1/quantstudio.proto --go_out=plugins=grpc:${GOPATH}/src
+//go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_centrifuge_v1/centrifuge.proto --go_out=plugins=grpc:${GOPATH}/src
+//go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_thermocycler_v1/thermocycler.proto --go_out=plugins=grpc:${GOPATH}/src
+//go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_sealer_v1/sealer.proto --go_out=plugins=grpc:${GOPATH}/src
+//go:generate protoc -I${GOPATH}/src ${GOPATH}/src/github.com/antha-lang/antha/driver/antha_platemover_v1/platemover.proto --go_out=plugins=grpc:${GOPATH}/src
 //go:generate protoc -I. lh/lh.proto --go_out=plugins=grpc:pb
 
 package driver
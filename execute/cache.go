@@ -0,0 +1,262 @@
+package execute
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/workflow"
+)
+
+// A Cache memoises the results of elements in a local directory. Results are
+// keyed by the hash of the source of the element, its name and version and a
+// hash of its inputs, so identical elements in different processes and
+// workflows share results. A result is used at most once in a run so that
+// processes with the same inputs do not share the identifiers of the liquids
+// they make.
+//
+// Only elements with metadata and type information are cached. Inputs are
+// hashed by their JSON encoding without the identifiers of liquids, plates
+// and wells, which are generated afresh in each run, so liquids with the same
+// name, type, volume, concentration and parentage hit the cache.
+type Cache struct {
+	dir string
+}
+
+// NewCache returns a cache stored in the given directory, creating the
+// directory if necessary.
+func NewCache(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// A cacheEntry is the stored result of running an element
+type cacheEntry struct {
+	Outputs map[string]json.RawMessage `json:"outputs"`
+	Trace   []*savedInst               `json:"trace"`
+	Effects []*effect                  `json:"effects"`
+}
+
+func (a *Cache) key(name inject.Name, source []byte, value inject.Value) (string, error) {
+	in, err := canonicalJSON(value)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, s := range []string{hex.EncodeToString(source), name.Repo, name.Tag} {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	h.Write(in) // nolint: errcheck
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// identifierFields are the fields of liquids, plates and wells which refer to
+// objects by identifier
+var identifierFields = map[string]bool{
+	"ID":       true,
+	"ParentID": true,
+	"BlockID":  true,
+	"Inst":     true,
+	"Loc":      true,
+}
+
+// daughterFields are the fields which refer to objects made later, and so are
+// not part of an input
+var daughterFields = map[string]bool{
+	"DaughtersID": true,
+}
+
+var uuidRegexp = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// canonicalJSON returns the JSON encoding of the value with the identifiers
+// in identifierFields replaced by the order in which they first appear, so
+// that inputs which share a parent or plate still differ from those which
+// don't.
+func canonicalJSON(value inject.Value) ([]byte, error) {
+	bs, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+	rename := func(s string) string {
+		return uuidRegexp.ReplaceAllStringFunc(s, func(id string) string {
+			if _, seen := ids[id]; !seen {
+				ids[id] = fmt.Sprintf("#%d", len(ids))
+			}
+			return ids[id]
+		})
+	}
+
+	var walk func(v interface{}, isID bool) interface{}
+	walk = func(v interface{}, isID bool) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			// visit in the order encoding/json writes keys, so that
+			// identifiers are numbered the same way each time
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if daughterFields[k] {
+					delete(v, k)
+				} else {
+					v[k] = walk(v[k], isID || identifierFields[k])
+				}
+			}
+		case []interface{}:
+			for i := range v {
+				v[i] = walk(v[i], isID)
+			}
+		case string:
+			if isID {
+				return rename(v)
+			}
+		}
+		return v
+	}
+
+	// encoding/json sorts map keys, so this is canonical for Values
+	return json.Marshal(walk(v, false))
+}
+
+func (a *Cache) path(key string) string {
+	return filepath.Join(a.dir, key+".json")
+}
+
+// call runs the element that satisfies query or, if its result is in the
+// cache, replays it. Satisfies the signature of workflow.Opt.Call.
+func (a *Cache) call(ctx context.Context, query inject.NameQuery, value inject.Value) (inject.Value, error) {
+	name, runner, err := inject.Lookup(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var source []byte
+	if mr, ok := runner.(inject.MetadataRunner); ok && mr.ElementMetadata() != nil {
+		source = mr.ElementMetadata().SourceSha256
+	}
+	tr, ok := runner.(inject.TypedRunner)
+	if !ok || len(source) == 0 {
+		return runner.Run(ctx, value)
+	}
+
+	key, err := a.key(name, source, value)
+	if err != nil {
+		// Inputs cannot be hashed, so results cannot be cached
+		return runner.Run(ctx, value)
+	}
+	if !getTrace(ctx).useCacheKey(key) {
+		// Another process in this run has already used this result
+		return runner.Run(ctx, value)
+	}
+
+	if out, err := a.replay(ctx, key, tr); err == nil {
+		return out, nil
+	}
+
+	out, err := runner.Run(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	process := workflow.ProcessFromContext(ctx)
+	if err := a.store(ctx, key, process, out); err != nil {
+		// the cache is only an optimisation, so the run still succeeds
+		log.Printf("cannot cache result of process %q: %s", process, err)
+	}
+	return out, nil
+}
+
+// replay returns the outputs of a cached result, issuing the instructions and
+// making the changes to execution state of the original run. Entries that
+// are missing or cannot be read are errors, and leave the execution state
+// unchanged.
+func (a *Cache) replay(ctx context.Context, key string, tr inject.TypedRunner) (inject.Value, error) {
+	bs, err := ioutil.ReadFile(a.path(key))
+	if err != nil {
+		return nil, err
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(bs, &entry); err != nil {
+		return nil, err
+	}
+
+	typ := inject.MakeValue(tr.Output())
+	out := make(inject.Value)
+	for name, data := range entry.Outputs {
+		v, err := unmarshalParam(ctx, &unmarshaler{}, name, data, typ)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = v
+	}
+
+	var insts []*commandInst
+	for _, s := range entry.Trace {
+		in, err := s.load()
+		if err != nil {
+			return nil, err
+		}
+		insts = append(insts, in)
+	}
+
+	process := workflow.ProcessFromContext(ctx)
+	for _, in := range insts {
+		Issue(ctx, in)
+	}
+	for _, e := range entry.Effects {
+		e.Process = process
+		apply(ctx, e)
+	}
+
+	return out, nil
+}
+
+// store saves the result of the given process. It is an error if the outputs
+// or instructions of the process cannot be serialized.
+func (a *Cache) store(ctx context.Context, key, process string, out inject.Value) error {
+	outputs, err := marshalValues(map[string]inject.Value{process: out})
+	if err != nil {
+		return err
+	}
+	entry := &cacheEntry{
+		Outputs: outputs[process],
+		Effects: getJournal(ctx).Effects(map[string]bool{process: true}),
+	}
+	for _, in := range getTrace(ctx).Instructions() {
+		if in.process != process {
+			continue
+		}
+		s, err := saveInst(in)
+		if err != nil {
+			return err
+		}
+		entry.Trace = append(entry.Trace, s)
+	}
+
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFile(a.path(key), bs)
+}
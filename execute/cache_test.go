@@ -0,0 +1,229 @@
+package execute
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/workflow"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	cache, err := NewCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type input struct {
+		Message string
+	}
+	type output struct {
+		Out *wtype.Liquid
+	}
+	runs := 0
+	runner := &inject.CheckedRunner{
+		RunFunc: func(ctx context.Context, value inject.Value) (inject.Value, error) {
+			runs++
+			water, err := inventory.NewComponent(ctx, inventory.WaterType)
+			if err != nil {
+				return nil, err
+			}
+			return inject.Value{"Out": Prompt(ctx, water, value["Message"].(string))}, nil
+		},
+		In:   &input{},
+		Out:  &output{},
+		Meta: &api.ElementMetadata{SourceSha256: []byte{1, 2, 3}},
+	}
+
+	run := func(message string, processes ...string) ([]*wtype.Liquid, context.Context) {
+		ctx := inject.NewContext(testinventory.NewContext(context.Background()))
+		if err := inject.Add(ctx, inject.Name{Repo: "Prompter", Stage: api.ElementStage_STEPS}, runner); err != nil {
+			t.Fatal(err)
+		}
+		ctx, _ = WithTrace(sampletracker.NewContext(withID(ctx, "")))
+
+		w, err := workflow.New(workflow.Opt{Call: cache.call})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, process := range processes {
+			if err := w.AddNode(process, "Prompter"); err != nil {
+				t.Fatal(err)
+			}
+			if err := w.SetParam(workflow.Port{Process: process, Port: "Message"}, message); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Run(ctx); err != nil {
+			t.Fatal(err)
+		}
+		var outs []*wtype.Liquid
+		for _, process := range processes {
+			out, ok := w.Outputs[workflow.Port{Process: process, Port: "Out"}].(*wtype.Liquid)
+			if !ok {
+				t.Fatalf("expecting liquid but got %T", w.Outputs[workflow.Port{Process: process, Port: "Out"}])
+			}
+			outs = append(outs, out)
+		}
+		return outs, ctx
+	}
+
+	first, _ := run("hello", "Prompt")
+	second, ctx := run("hello", "Prompt")
+	if runs != 1 {
+		t.Errorf("expecting 1 run but got %d", runs)
+	}
+	if first[0].ID != second[0].ID {
+		t.Errorf("expecting cached liquid %q but got %q", first[0].ID, second[0].ID)
+	}
+	if insts := getTrace(ctx).Instructions(); len(insts) != 1 {
+		t.Errorf("expecting 1 replayed instruction but got %d", len(insts))
+	} else if insts[0].process != "Prompt" {
+		t.Errorf("expecting instruction from %q but got %q", "Prompt", insts[0].process)
+	} else if insts[0].result[0].ID != second[0].ID {
+		t.Errorf("expecting instruction to make %q but got %q", second[0].ID, insts[0].result[0].ID)
+	}
+	if effects := getJournal(ctx).Effects(map[string]bool{"Prompt": true}); len(effects) == 0 {
+		t.Error("expecting replayed effects")
+	}
+
+	// the result is shared by a process with another name
+	renamed, ctx := run("hello", "Renamed")
+	if runs != 1 {
+		t.Errorf("expecting 1 run but got %d", runs)
+	}
+	if renamed[0].ID != first[0].ID {
+		t.Errorf("expecting cached liquid %q but got %q", first[0].ID, renamed[0].ID)
+	}
+	if insts := getTrace(ctx).Instructions(); len(insts) != 1 || insts[0].process != "Renamed" {
+		t.Error("expecting instruction to be replayed for renamed process")
+	}
+
+	// but not by two processes in the same run
+	both, _ := run("hello", "Prompt", "Again")
+	if runs != 2 {
+		t.Errorf("expecting 2 runs but got %d", runs)
+	}
+	if both[0].ID == both[1].ID {
+		t.Errorf("expecting processes in the same run to make different liquids but both made %q", both[0].ID)
+	}
+
+	if run("goodbye", "Prompt"); runs != 3 {
+		t.Errorf("expecting 3 runs but got %d", runs)
+	}
+}
+
+func TestCacheLiquidInput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir) // nolint: errcheck
+
+	cache, err := NewCache(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type input struct {
+		In *wtype.Liquid
+	}
+	type output struct {
+		Out *wtype.Liquid
+	}
+	runs := 0
+	runner := &inject.CheckedRunner{
+		RunFunc: func(ctx context.Context, value inject.Value) (inject.Value, error) {
+			runs++
+			return inject.Value{"Out": Prompt(ctx, value["In"].(*wtype.Liquid), "shake")}, nil
+		},
+		In:   &input{},
+		Out:  &output{},
+		Meta: &api.ElementMetadata{SourceSha256: []byte{4, 5, 6}},
+	}
+
+	run := func(volume float64) {
+		ctx := inject.NewContext(testinventory.NewContext(context.Background()))
+		if err := inject.Add(ctx, inject.Name{Repo: "Shaker", Stage: api.ElementStage_STEPS}, runner); err != nil {
+			t.Fatal(err)
+		}
+		ctx, _ = WithTrace(sampletracker.NewContext(withID(ctx, "")))
+
+		// a liquid read from parameters has a new identifier in each run
+		water, err := inventory.NewComponent(ctx, inventory.WaterType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		water.SetVolume(wunit.NewVolume(volume, "ul"))
+
+		w, err := workflow.New(workflow.Opt{Call: cache.call})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.AddNode("Shake", "Shaker"); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.SetParam(workflow.Port{Process: "Shake", Port: "In"}, water); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Run(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run(10.0)
+	if run(10.0); runs != 1 {
+		t.Errorf("expecting 1 run for the same liquid but got %d", runs)
+	}
+	if run(20.0); runs != 2 {
+		t.Errorf("expecting 2 runs for a different volume but got %d", runs)
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	// a stock and a sample of it, with fresh identifiers each time
+	makeLiquids := func() (*wtype.Liquid, *wtype.Liquid) {
+		stock := wtype.NewLHComponent()
+		stock.CName = "water"
+		sample := wtype.NewLHComponent()
+		sample.CName = "water"
+		sample.AddParentComponent(stock)
+		return stock, sample
+	}
+
+	key := func(v inject.Value) string {
+		bs, err := canonicalJSON(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(bs)
+	}
+
+	stock, sample := makeLiquids()
+	same := key(inject.Value{"A": stock, "B": sample})
+
+	// identifiers are not part of the key
+	stock2, sample2 := makeLiquids()
+	if again := key(inject.Value{"A": stock2, "B": sample2}); same != again {
+		t.Errorf("expecting %s but got %s", same, again)
+	}
+
+	// but parentage is
+	if different := key(inject.Value{"A": stock, "B": sample2}); same == different {
+		t.Errorf("expecting parentage to be part of the key, got %s for both", same)
+	}
+}
@@ -0,0 +1,277 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/workflow"
+)
+
+// instTypes are the types of command instructions that can be saved in a
+// checkpoint
+var instTypes = map[string]func() interface{}{
+	fmt.Sprintf("%T", &wtype.LHInstruction{}): func() interface{} { return &wtype.LHInstruction{} },
+	fmt.Sprintf("%T", &wtype.PRInstruction{}): func() interface{} { return &wtype.PRInstruction{} },
+	fmt.Sprintf("%T", &ast.IncubateInst{}):    func() interface{} { return &ast.IncubateInst{} },
+	fmt.Sprintf("%T", &ast.CentrifugeInst{}):  func() interface{} { return &ast.CentrifugeInst{} },
+	fmt.Sprintf("%T", &ast.ThermocycleInst{}): func() interface{} { return &ast.ThermocycleInst{} },
+	fmt.Sprintf("%T", &ast.CoverInst{}):       func() interface{} { return &ast.CoverInst{} },
+	fmt.Sprintf("%T", &ast.PromptInst{}):      func() interface{} { return &ast.PromptInst{} },
+	fmt.Sprintf("%T", &ast.QPCRInstruction{}): func() interface{} { return &ast.QPCRInstruction{} },
+}
+
+// A savedInst is the serialized form of a commandInst
+type savedInst struct {
+	Process  string
+	Args     []*wtype.Liquid
+	Result   []*wtype.Liquid
+	Request  ast.Request
+	InstType string
+	Inst     json.RawMessage
+	// Generation of a *wtype.LHInstruction, which is not serialized with it
+	Generation int `json:",omitempty"`
+}
+
+func saveInst(in *commandInst) (*savedInst, error) {
+	typ := fmt.Sprintf("%T", in.Command.Inst)
+	if _, ok := instTypes[typ]; !ok {
+		return nil, fmt.Errorf("cannot save instruction of type %s", typ)
+	}
+	bs, err := json.Marshal(in.Command.Inst)
+	if err != nil {
+		return nil, err
+	}
+	s := &savedInst{
+		Process:  in.process,
+		Args:     in.Args,
+		Result:   in.result,
+		Request:  in.Command.Request,
+		InstType: typ,
+		Inst:     bs,
+	}
+	if lh, ok := in.Command.Inst.(*wtype.LHInstruction); ok {
+		s.Generation = lh.Generation()
+	}
+	return s, nil
+}
+
+func (a *savedInst) load() (*commandInst, error) {
+	newInst, ok := instTypes[a.InstType]
+	if !ok {
+		return nil, fmt.Errorf("cannot load instruction of type %s", a.InstType)
+	}
+	inst := newInst()
+	if err := json.Unmarshal(a.Inst, inst); err != nil {
+		return nil, err
+	}
+	if lh, ok := inst.(*wtype.LHInstruction); ok {
+		lh.SetGeneration(a.Generation)
+	}
+	return &commandInst{
+		Args:   a.Args,
+		result: a.Result,
+		Command: &ast.Command{
+			Inst:    inst,
+			Request: a.Request,
+		},
+		process: a.Process,
+	}, nil
+}
+
+// A Checkpoint is a serialized snapshot of the progress of Run. Only the
+// effects of processes that have completed are saved, so resuming from a
+// checkpoint reruns any process that had not completed when the checkpoint
+// was taken.
+type Checkpoint struct {
+	// Processes that have completed
+	Completed []string `json:"completed"`
+	// Parameters assigned to processes that have not completed
+	Params map[string]map[string]json.RawMessage `json:"params"`
+	// Values generated by completed processes that were not connected to
+	// another process
+	Outputs map[string]map[string]json.RawMessage `json:"outputs"`
+	// Instructions issued by completed processes
+	Trace []*savedInst `json:"trace"`
+	// Changes to the execution state made by completed processes
+	Effects []*effect `json:"effects"`
+}
+
+func marshalValues(values map[string]inject.Value) (map[string]map[string]json.RawMessage, error) {
+	r := make(map[string]map[string]json.RawMessage)
+	for process, value := range values {
+		r[process] = make(map[string]json.RawMessage)
+		for name, v := range value {
+			bs, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("cannot save parameter %q of process %q: %s", name, process, err)
+			}
+			r[process][name] = bs
+		}
+	}
+	return r, nil
+}
+
+func makeCheckpoint(ctx context.Context, state *workflow.State, tr *Trace) (*Checkpoint, error) {
+	completed := make(map[string]bool)
+	for _, process := range state.Completed {
+		completed[process] = true
+	}
+
+	outputs := make(map[string]inject.Value)
+	for port, v := range state.Outputs {
+		if outputs[port.Process] == nil {
+			outputs[port.Process] = make(inject.Value)
+		}
+		outputs[port.Process][port.Port] = v
+	}
+
+	cp := &Checkpoint{
+		Completed: state.Completed,
+		Effects:   getJournal(ctx).Effects(completed),
+	}
+
+	var err error
+	if cp.Params, err = marshalValues(state.Params); err != nil {
+		return nil, err
+	}
+	if cp.Outputs, err = marshalValues(outputs); err != nil {
+		return nil, err
+	}
+
+	for _, in := range tr.Instructions() {
+		if !madeBy(completed, in.process) {
+			continue
+		}
+		s, err := saveInst(in)
+		if err != nil {
+			return nil, err
+		}
+		cp.Trace = append(cp.Trace, s)
+	}
+
+	return cp, nil
+}
+
+// unmarshalValues unmarshals the input or output values of each process
+func unmarshalValues(ctx context.Context, w *workflow.Workflow, raw map[string]map[string]json.RawMessage, outputs bool) (map[string]inject.Value, error) {
+	um := &unmarshaler{}
+	r := make(map[string]inject.Value)
+	for process, values := range raw {
+		cr, err := typedRunner(ctx, w, process)
+		if err != nil {
+			return nil, err
+		}
+		var t inject.Value
+		if !outputs {
+			t = mapValue(cr.Input(), w.MapOver(process))
+		} else if len(w.MapOver(process)) == 0 {
+			t = inject.MakeValue(cr.Output())
+		} else {
+			// All outputs of map processes are slices
+			var ports []string
+			for name := range inject.MakeValue(cr.Output()) {
+				ports = append(ports, name)
+			}
+			t = mapValue(cr.Output(), ports)
+		}
+		r[process] = make(inject.Value)
+		for name, data := range values {
+			v, err := unmarshalParam(ctx, um, name, data, t)
+			if err != nil {
+				return nil, fmt.Errorf("cannot restore parameter %q of process %q: %s", name, process, err)
+			}
+			r[process][name] = v
+		}
+	}
+	return r, nil
+}
+
+// restoreCheckpoint restores the state of a workflow, the trace and the
+// execution state from a checkpoint
+func restoreCheckpoint(ctx context.Context, w *workflow.Workflow, tr *Trace, cp *Checkpoint) error {
+	params, err := unmarshalValues(ctx, w, cp.Params, false)
+	if err != nil {
+		return err
+	}
+	outputs, err := unmarshalValues(ctx, w, cp.Outputs, true)
+	if err != nil {
+		return err
+	}
+
+	state := &workflow.State{
+		Completed: cp.Completed,
+		Params:    params,
+		Outputs:   make(map[workflow.Port]interface{}),
+	}
+	for process, values := range outputs {
+		for name, v := range values {
+			state.Outputs[workflow.Port{Process: process, Port: name}] = v
+		}
+	}
+
+	if err := w.Restore(state); err != nil {
+		return err
+	}
+
+	for _, s := range cp.Trace {
+		in, err := s.load()
+		if err != nil {
+			return err
+		}
+		tr.Issue(in)
+	}
+
+	for _, e := range cp.Effects {
+		apply(ctx, e)
+	}
+
+	return nil
+}
+
+// ReadCheckpoint reads a checkpoint from a file
+func ReadCheckpoint(filename string) (*Checkpoint, error) {
+	bs, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(bs, &cp); err != nil {
+		return nil, fmt.Errorf("cannot read checkpoint %q: %s", filename, err)
+	}
+	return &cp, nil
+}
+
+// WriteFile writes a checkpoint to a file. The file is replaced atomically, so
+// an interrupted write leaves any previous checkpoint intact.
+func (a *Checkpoint) WriteFile(filename string) error {
+	bs, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, bs)
+}
+
+// writeFile replaces the contents of a file atomically
+func writeFile(filename string, bs []byte) error {
+	f, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename))
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bs); err != nil {
+		f.Close()           // nolint: errcheck
+		os.Remove(f.Name()) // nolint: errcheck
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) // nolint: errcheck
+		return err
+	}
+	return os.Rename(f.Name(), filename)
+}
@@ -0,0 +1,161 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+	"github.com/antha-lang/antha/target"
+)
+
+func TestSaveInst(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	water, err := inventory.NewComponent(ctx, inventory.WaterType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mix := wtype.NewLHMixInstruction()
+	mix.AddInput(water)
+	mix.AddOutput(water.Dup())
+	mix.SetGeneration(3)
+
+	for _, in := range []*commandInst{
+		{
+			Args:    []*wtype.Liquid{water},
+			result:  []*wtype.Liquid{water.Dup()},
+			process: "Prompt",
+			Command: &ast.Command{
+				Inst: &ast.PromptInst{Message: "hello"},
+				Request: ast.Request{
+					Selector: []ast.NameValue{target.DriverSelectorV1Human},
+				},
+			},
+		},
+		{
+			Args:    mix.Inputs,
+			result:  mix.Outputs,
+			process: "Mix",
+			Command: &ast.Command{
+				Inst: mix,
+				Request: ast.Request{
+					Selector: []ast.NameValue{target.DriverSelectorV1Mixer},
+				},
+			},
+		},
+	} {
+		s, err := saveInst(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var saved savedInst
+		if err := json.Unmarshal(bs, &saved); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := saved.load()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if loaded.process != in.process {
+			t.Errorf("expecting process %q but got %q", in.process, loaded.process)
+		}
+		if e, f := in.Args[0].ID, loaded.Args[0].ID; e != f {
+			t.Errorf("expecting argument %q but got %q", e, f)
+		}
+		if !loaded.Command.Request.Contains(in.Command.Request) {
+			t.Errorf("expecting request %v but got %v", in.Command.Request, loaded.Command.Request)
+		}
+
+		switch inst := loaded.Command.Inst.(type) {
+		case *ast.PromptInst:
+			if inst.Message != "hello" {
+				t.Errorf("expecting message %q but got %q", "hello", inst.Message)
+			}
+		case *wtype.LHInstruction:
+			if g := inst.Generation(); g != mix.Generation() {
+				t.Errorf("expecting generation %d but got %d", mix.Generation(), g)
+			}
+		default:
+			t.Errorf("unexpected instruction type %T", inst)
+		}
+	}
+}
+
+func TestSaveInstCapabilities(t *testing.T) {
+	rpm, err := wunit.NewRate(600, "/min")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caps := []ast.Capability{
+		ast.NewValueCapability(target.CapabilityTemperature, wunit.NewTemperature(37, "C")),
+		ast.RangeCapability{Name: target.CapabilityShakeRate, Max: rpm},
+		ast.SetCapability{Name: "footprint", Values: []string{"SBS"}},
+		ast.BoolCapability{Name: "lid", Value: true},
+	}
+	in := &commandInst{
+		process: "Incubate",
+		Command: &ast.Command{
+			Inst: &ast.IncubateInst{},
+			Request: ast.Request{
+				Selector:     []ast.NameValue{target.DriverSelectorV1ShakerIncubator},
+				Capabilities: caps,
+			},
+		},
+	}
+
+	s, err := saveInst(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs, err := json.Marshal(&Checkpoint{Trace: []*savedInst{s}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cp Checkpoint
+	if err := json.Unmarshal(bs, &cp); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := cp.Trace[0].load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := loaded.Command.Request
+	if e, f := fmt.Sprint(caps), fmt.Sprint(req.Capabilities); e != f {
+		t.Errorf("expecting capabilities %s but got %s", e, f)
+	}
+
+	incubator := []ast.Capability{
+		ast.RangeCapability{
+			Name: target.CapabilityTemperature,
+			Min:  wunit.NewTemperature(4, "C"),
+			Max:  wunit.NewTemperature(30, "C"),
+		},
+	}
+	if err := ast.Satisfies(incubator, req); err == nil {
+		t.Error("expecting loaded temperature to be out of range")
+	}
+}
+
+func TestSaveUnknownInst(t *testing.T) {
+	in := &commandInst{
+		Command: &ast.Command{
+			Inst: struct{}{},
+		},
+	}
+	if _, err := saveInst(in); err == nil {
+		t.Errorf("expecting error saving unknown instruction type")
+	}
+}
@@ -9,8 +9,9 @@ type idContextKey int
 const theIDContextKey idContextKey = 0
 
 type withExecute struct {
-	ID    string
-	Maker *maker
+	ID      string
+	Maker   *maker
+	Journal *journal
 }
 
 type elementNameKey int
@@ -25,6 +26,10 @@ func getMaker(ctx context.Context) *maker {
 	return ctx.Value(theIDContextKey).(*withExecute).Maker
 }
 
+func getJournal(ctx context.Context) *journal {
+	return ctx.Value(theIDContextKey).(*withExecute).Journal
+}
+
 func getID(ctx context.Context) string {
 	v, ok := ctx.Value(theIDContextKey).(*withExecute)
 	if !ok {
@@ -35,8 +40,9 @@ func getID(ctx context.Context) string {
 
 func withID(parent context.Context, id string) context.Context {
 	return context.WithValue(parent, theIDContextKey, &withExecute{
-		ID:    id,
-		Maker: newMaker(),
+		ID:      id,
+		Maker:   newMaker(),
+		Journal: &journal{},
 	})
 }
 
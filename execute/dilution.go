@@ -0,0 +1,153 @@
+package execute
+
+import (
+	"context"
+	"math"
+
+	"github.com/antha-lang/antha/antha/anthalib/mixer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// A DilutionLayout says where to put the steps of a serial dilution. Steps
+// are laid out along a row, or down a column, of the plate so that several
+// dilutions started in adjacent wells can be made with a multichannel head.
+type DilutionLayout struct {
+	// Plate to make the dilution in (required)
+	Plate *wtype.Plate
+	// Well of the first step, A1 if unset
+	Start string
+	// Lay out the steps down a column rather than along a row
+	Down bool
+	// Well of the plate to discard the liquid taken from the last step into,
+	// so that it is left with the same volume as the others. If unset, the
+	// last step keeps the extra liquid.
+	Discard string
+}
+
+// wells returns the addresses of the wells for each step
+func (a DilutionLayout) wells(ctx context.Context, steps int) []string {
+	if a.Plate == nil {
+		Errorf(ctx, "serial dilution needs a plate")
+	}
+	start := a.Start
+	if start == "" {
+		start = "A1"
+	}
+	wc := wtype.MakeWellCoords(start)
+
+	var ret []string
+	for i := 0; i < steps; i++ {
+		addr := wc.FormatA1()
+		if _, ok := a.Plate.Wellcoords[addr]; !ok {
+			Errorf(ctx, "serial dilution of %d steps from %s does not fit on plate %s", steps, start, a.Plate.Name())
+		}
+		ret = append(ret, addr)
+		if a.Down {
+			wc.Y++
+		} else {
+			wc.X++
+		}
+	}
+	return ret
+}
+
+// dilutionVolumes returns the volume to transfer from each step to the next,
+// and of diluent to put in every well, for a serial dilution which leaves the
+// given volume in each well once the next transfer has been taken. The last
+// step is left with an extra transfer volume unless it is discarded.
+func dilutionVolumes(factor float64, volume wunit.Volume) (transfer, diluent wunit.Volume) {
+	return wunit.DivideVolume(volume, factor-1.0), volume.Dup()
+}
+
+// dilutionMixType returns the type of the liquid moved between steps of a
+// serial dilution of the stock. The liquid has to be mixed in after it is
+// dispensed so the type of the stock is kept only if its policy does so.
+func dilutionMixType(stock *wtype.Liquid) wtype.LiquidType {
+	if pol, err := wtype.GetPolicyByType(stock.Type); err == nil {
+		if cycles, ok := pol["POST_MIX"].(int); ok && cycles > 0 {
+			return stock.Type
+		}
+	}
+	return wtype.LTPostMix
+}
+
+// SerialDilution dilutes the stock with diluent by the factor repeatedly,
+// leaving the volume of each dilution in the wells given by the layout. The
+// diluent is added to all the wells first and then each step is made by
+// moving the same volume of liquid from the previous one and mixing it in.
+// The liquid taken from the last step is moved to the discard well of the
+// layout, if it has one. The diluted liquids are returned in order, starting
+// with the least dilute.
+func SerialDilution(ctx context.Context, stock, diluent *wtype.Liquid, factor float64, steps int, volume wunit.Volume, layout DilutionLayout) []*wtype.Liquid {
+	if factor <= 1.0 {
+		Errorf(ctx, "serial dilution factor must be greater than 1, not %g", factor)
+	}
+	if steps < 1 {
+		Errorf(ctx, "serial dilution needs at least one step, not %d", steps)
+	}
+
+	wells := layout.wells(ctx, steps)
+	if layout.Discard != "" {
+		if _, ok := layout.Plate.Wellcoords[layout.Discard]; !ok {
+			Errorf(ctx, "serial dilution cannot discard into well %s which is not on plate %s", layout.Discard, layout.Plate.Name())
+		}
+		for _, well := range wells {
+			if well == layout.Discard {
+				Errorf(ctx, "serial dilution cannot discard into well %s which holds one of its steps", well)
+			}
+		}
+	}
+	transfer, diluentVolume := dilutionVolumes(factor, volume)
+	mixType := dilutionMixType(stock)
+	series := wtype.GetUUID()
+
+	step := func(i int) *wtype.SerialDilutionStep {
+		return &wtype.SerialDilutionStep{
+			Series: series,
+			Step:   i + 1,
+			Steps:  steps,
+			Factor: factor,
+		}
+	}
+
+	// diluent doesn't depend on earlier steps so it can all go in at once
+	filled := make([]*wtype.Liquid, steps)
+	for i := range wells {
+		inst := mixer.GenericMix(mixer.MixOptions{
+			Inputs:      []*wtype.Liquid{mixer.Sample(diluent, diluentVolume)},
+			Destination: layout.Plate,
+			Address:     wells[i],
+		})
+		inst.SerialDilution = step(i)
+		filled[i] = genericMix(ctx, inst)
+	}
+
+	ret := make([]*wtype.Liquid, steps)
+	prev := stock
+	for i := range wells {
+		moving := mixer.Sample(prev, transfer)
+		moving.Type = mixType
+
+		inst := mixer.GenericMix(mixer.MixOptions{
+			Inputs: []*wtype.Liquid{filled[i], moving},
+		})
+		inst.SerialDilution = step(i)
+		if stock.HasConcentration() {
+			conc := wunit.DivideConcentration(stock.Concentration(), math.Pow(factor, float64(i+1)))
+			inst.Outputs[0].SetConcentration(conc)
+		}
+		ret[i] = genericMix(ctx, inst)
+		prev = ret[i]
+	}
+
+	if layout.Discard != "" {
+		genericMix(ctx, mixer.GenericMix(mixer.MixOptions{
+			Inputs:      []*wtype.Liquid{mixer.Sample(prev, transfer)},
+			Destination: layout.Plate,
+			Address:     layout.Discard,
+		}))
+	}
+
+	return ret
+}
@@ -0,0 +1,126 @@
+package execute
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestDilutionVolumes(t *testing.T) {
+	const factor = 4.0
+	volume := wunit.NewVolume(90.0, "ul")
+
+	transfer, diluent := dilutionVolumes(factor, volume)
+	if !transfer.EqualTo(wunit.NewVolume(30.0, "ul")) || !diluent.EqualTo(volume) {
+		t.Errorf("expected to transfer 30 ul into 90 ul of diluent, got %s into %s", transfer, diluent)
+	}
+
+	// each step is diluted by the factor
+	total := wunit.AddVolumes(transfer, diluent)
+	if got := total.RawValue() / transfer.RawValue(); math.Abs(got-factor) > 1e-9 {
+		t.Errorf("expected dilution by %g, got %g", factor, got)
+	}
+
+	// and keeps the volume once the next transfer has been taken
+	if left := wunit.SubtractVolumes(total, transfer); !left.EqualTo(volume) {
+		t.Errorf("expected %s to be left, got %s", volume, left)
+	}
+}
+
+func TestSerialDilution(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	ctx, _ = WithTrace(withID(ctx, ""))
+
+	stock, err := inventory.NewComponent(ctx, "dna")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stock.SetConcentration(wunit.NewConcentration(80.0, "ng/ul"))
+	diluent, err := inventory.NewComponent(ctx, inventory.WaterType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		Layout DilutionLayout
+		Wells  []string
+	}{
+		{Layout: DilutionLayout{Start: "B2"}, Wells: []string{"B2", "B3", "B4"}},
+		{Layout: DilutionLayout{Start: "B2", Down: true}, Wells: []string{"B2", "C2", "D2"}},
+	} {
+		plate, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+		if err != nil {
+			t.Fatal(err)
+		}
+		test.Layout.Plate = plate
+
+		diluted := SerialDilution(ctx, stock, diluent, 2.0, 3, wunit.NewVolume(50.0, "ul"), test.Layout)
+		if len(diluted) != len(test.Wells) {
+			t.Fatalf("expected %d dilutions, got %d", len(test.Wells), len(diluted))
+		}
+
+		for i, l := range diluted {
+			if got := l.WellLocation(); got != test.Wells[i] {
+				t.Errorf("step %d: expected well %s, got %s", i+1, test.Wells[i], got)
+			}
+			expected := 80.0 / math.Pow(2.0, float64(i+1))
+			if got := l.Concentration(); math.Abs(got.RawValue()-expected) > 1e-9 || got.Unit().PrefixedSymbol() != "ng/ul" {
+				t.Errorf("step %d: expected concentration %g ng/ul, got %s", i+1, expected, got)
+			}
+		}
+	}
+}
+
+func TestSerialDilutionDiscard(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	ctx, tr := WithTrace(withID(ctx, ""))
+
+	stock, err := inventory.NewComponent(ctx, "dna")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diluent, err := inventory.NewComponent(ctx, inventory.WaterType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plate, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	volume := wunit.NewVolume(100.0, "ul")
+	diluted := SerialDilution(ctx, stock, diluent, 2.0, 3, volume, DilutionLayout{Plate: plate, Discard: "H12"})
+
+	// diluent for each step, the steps and then the discard
+	insts := tr.Instructions()
+	if len(insts) != 7 {
+		t.Fatalf("expected 7 instructions, got %d", len(insts))
+	}
+
+	var transfers []wunit.Volume
+	for _, in := range insts[3:6] {
+		mix := in.Command.Inst.(*wtype.LHInstruction)
+		transfers = append(transfers, mix.Inputs[len(mix.Inputs)-1].Volume())
+	}
+	for i, v := range transfers {
+		if !v.EqualTo(volume) {
+			t.Errorf("step %d: expected to transfer %s, got %s", i+1, volume, v)
+		}
+	}
+
+	discard := insts[6].Command.Inst.(*wtype.LHInstruction)
+	if discard.Welladdress != "H12" || discard.PlateID != plate.ID {
+		t.Errorf("expected discard into H12 of plate %s, got %s of %s", plate.ID, discard.Welladdress, discard.PlateID)
+	}
+	if discard.SerialDilution != nil {
+		t.Error("expected discard not to be a step of the dilution")
+	}
+	if in := discard.Inputs[0]; !in.Volume().EqualTo(volume) || in.ParentID != diluted[2].ID {
+		t.Errorf("expected to discard %s of the last step %s, got %s of %s", volume, diluted[2].ID, in.Volume(), in.ParentID)
+	}
+}
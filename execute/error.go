@@ -2,28 +2,156 @@ package execute
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/workflow"
 )
 
-// An UserError reported by user code
-type UserError struct {
-	message string
+// An ElementError is an error in running an element
+type ElementError struct {
+	Process  string                 // Name of workflow process, if known
+	Element  string                 // Name of element, if known
+	Location *inject.SourceLocation // Line in element source, if known
+	Message  string                 // Description of the error
+	Stack    string                 // Stack trace if the element panicked
+	Err      error                  // Underlying error, if any
+}
+
+// Error satisfies the error interface. The message is concise: it does not
+// include the stack trace or underlying errors.
+func (a *ElementError) Error() string {
+	var prefix string
+	if a.Element != "" {
+		prefix = "element " + a.Element
+	}
+	if a.Location != nil {
+		if prefix != "" {
+			prefix += " "
+		}
+		prefix += "at " + a.Location.String()
+	}
+	if prefix == "" {
+		return a.Message
+	}
+	return prefix + ": " + a.Message
+}
+
+// Cause returns the underlying error, if any
+func (a *ElementError) Cause() error {
+	return a.Err
+}
+
+type causer interface {
+	Cause() error
 }
 
-// Error satisfies the error interface
-func (err UserError) Error() string {
-	return err.message
+type jsonElementError struct {
+	Process string   `json:"process,omitempty"`
+	Element string   `json:"element,omitempty"`
+	File    string   `json:"file,omitempty"`
+	Line    int      `json:"line,omitempty"`
+	Message string   `json:"message"`
+	Causes  []string `json:"causes,omitempty"`
+	Stack   string   `json:"stack,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Underlying errors are given as a
+// list of messages, outermost first.
+func (a *ElementError) MarshalJSON() ([]byte, error) {
+	v := jsonElementError{
+		Process: a.Process,
+		Element: a.Element,
+		Message: a.Message,
+		Stack:   a.Stack,
+	}
+	if a.Location != nil {
+		v.File = a.Location.File
+		v.Line = a.Location.Line
+	}
+	for err := a.Err; err != nil; {
+		v.Causes = append(v.Causes, err.Error())
+		c, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+	return json.Marshal(v)
 }
 
 // Errorf reports an execution error. Does not return
 func Errorf(ctx context.Context, format string, args ...interface{}) {
-	userMsg := fmt.Sprintf(format, args...)
-	elementName := getElementName(ctx)
+	err := &ElementError{
+		Process:  workflow.ProcessFromContext(ctx),
+		Element:  getElementName(ctx),
+		Location: inject.ElementCaller(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	// Keep the first error argument, if any, as the cause
+	for _, arg := range args {
+		if e, ok := arg.(error); ok {
+			err.Err = e
+			break
+		}
+	}
 
-	msg := userMsg
-	if len(elementName) != 0 {
-		msg = "element " + elementName + ": " + userMsg
+	panic(err)
+}
+
+// elementErrors replaces the errors of processes, and of the runs of map
+// processes, with ElementErrors. Errors raised by Errorf are used as they are,
+// so no stack trace is attached to them.
+func elementErrors(w *workflow.Workflow, err error) error {
+	errs, ok := err.(workflow.ProcessErrors)
+	if !ok {
+		return err
 	}
+	for _, pErr := range errs {
+		process, _ := workflow.SplitInstanceName(pErr.Process)
+		element, _ := w.FuncName(process)
 
-	panic(UserError{message: msg})
+		switch e := pErr.Err.(type) {
+		case workflow.ProcessErrors:
+			pErr.Err = elementErrors(w, e)
+		case *ElementError:
+		case *workflow.PanicError:
+			if eErr, ok := e.Value.(*ElementError); ok {
+				pErr.Err = eErr
+				continue
+			}
+			pErr.Err = &ElementError{
+				Process:  pErr.Process,
+				Element:  element,
+				Location: e.Location,
+				Message:  fmt.Sprintf("panic: %v", e.Value),
+				Stack:    e.Stack,
+			}
+		default:
+			pErr.Err = &ElementError{
+				Process: pErr.Process,
+				Element: element,
+				Message: e.Error(),
+				Err:     e,
+			}
+		}
+	}
+	return errs
+}
+
+// ElementErrors returns the ElementErrors of every failed process in an error
+// returned by Run
+func ElementErrors(err error) []*ElementError {
+	switch e := err.(type) {
+	case *ElementError:
+		return []*ElementError{e}
+	case workflow.ProcessErrors:
+		var r []*ElementError
+		for _, pErr := range e {
+			r = append(r, ElementErrors(pErr.Err)...)
+		}
+		return r
+	}
+	return nil
 }
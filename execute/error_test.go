@@ -0,0 +1,115 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/workflow"
+)
+
+func TestElementErrors(t *testing.T) {
+	// Pretend this file is the generated code of an element whose source
+	// lines are offset by 1000
+	_, file, _, _ := runtime.Caller(0)
+	lineMap := make(map[int]int)
+	for line := 1; line < 1000; line++ {
+		lineMap[line] = line + 1000
+	}
+	inject.RegisterLineMap(file, "Failer.an", "Failer", lineMap)
+
+	cause := errors.New("out of water")
+	var errorfLine, panicLine int
+	ctx := inject.NewContext(context.Background())
+	add := func(name string, run func(context.Context, inject.Value) (inject.Value, error)) {
+		if err := inject.Add(ctx, inject.Name{Repo: name, Stage: api.ElementStage_STEPS}, &inject.FuncRunner{RunFunc: run}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("Failer", func(ctx context.Context, value inject.Value) (inject.Value, error) {
+		ctx = WithElementName(ctx, "Failer")
+		_, _, errorfLine, _ = runtime.Caller(0)
+		Errorf(ctx, "cannot fill: %s", cause)
+		return nil, nil
+	})
+	add("Panicker", func(ctx context.Context, value inject.Value) (inject.Value, error) {
+		var m map[string]int
+		_, _, panicLine, _ = runtime.Caller(0)
+		m["a"] = 1
+		return nil, nil
+	})
+	add("Returner", func(ctx context.Context, value inject.Value) (inject.Value, error) {
+		return nil, cause
+	})
+
+	// Failed workflows do not start further processes, so run each
+	// element in a separate workflow
+	var errs []*ElementError
+	for process, component := range []string{"Failer", "Panicker", "Returner"} {
+		_, err := Run(ctx, Opt{
+			Workflow: &workflow.Desc{
+				Processes: map[string]workflow.Process{
+					string(rune('A' + process)): {Component: component},
+				},
+			},
+		})
+		if err == nil {
+			t.Fatalf("expecting error for element %q but got none", component)
+		}
+		pErrs := ElementErrors(err)
+		if len(pErrs) != 1 {
+			t.Fatalf("expecting 1 element error but got %d: %s", len(pErrs), err)
+		}
+		errs = append(errs, pErrs...)
+	}
+
+	failed := errs[0]
+	if failed.Process != "A" || failed.Element != "Failer" {
+		t.Errorf("expecting process %q of element %q but got %q of %q", "A", "Failer", failed.Process, failed.Element)
+	}
+	if failed.Location == nil || failed.Location.Line != errorfLine+1+1000 {
+		t.Errorf("expecting line %d but got %v", errorfLine+1+1000, failed.Location)
+	}
+	if failed.Err != cause {
+		t.Errorf("expecting cause %v but got %v", cause, failed.Err)
+	}
+	if e, f := "element Failer at Failer.an:", failed.Error(); !strings.HasPrefix(f, e) {
+		t.Errorf("expecting message starting with %q but got %q", e, f)
+	}
+
+	panicked := errs[1]
+	if panicked.Element != "Panicker" || panicked.Stack == "" || !strings.HasPrefix(panicked.Message, "panic: ") {
+		t.Errorf("unexpected error for panic: %#v", panicked)
+	}
+	if panicked.Location == nil || panicked.Location.Line != panicLine+1+1000 {
+		t.Errorf("expecting line %d but got %v", panicLine+1+1000, panicked.Location)
+	}
+	if strings.Contains(panicked.Error(), panicked.Stack) {
+		t.Errorf("expecting concise message but got %q", panicked.Error())
+	}
+
+	returned := errs[2]
+	if returned.Element != "Returner" || returned.Message != cause.Error() || returned.Location != nil {
+		t.Errorf("unexpected error for returned error: %#v", returned)
+	}
+
+	bs, err := json.Marshal(failed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(bs, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v["process"] != "A" || v["file"] != "Failer.an" || v["line"] != float64(errorfLine+1+1000) {
+		t.Errorf("unexpected JSON %s", bs)
+	}
+	if causes, ok := v["causes"].([]interface{}); !ok || len(causes) != 1 || causes[0] != cause.Error() {
+		t.Errorf("expecting causes [%q] but got %v", cause, v["causes"])
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/antha-lang/antha/codegen"
 	"github.com/antha-lang/antha/inject"
 	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/profile"
 	"github.com/antha-lang/antha/target"
 	"github.com/antha-lang/antha/workflow"
 )
@@ -19,6 +20,10 @@ type Result struct {
 	Workflow *workflow.Workflow
 	Input    []ast.Node
 	Insts    []ast.Inst
+	// Estimated start and end times of Insts
+	Schedule *target.Schedule
+	// Timings of the phases of execution
+	Profile *profile.Profile
 }
 
 // An Opt are options for Run.
@@ -35,37 +40,78 @@ type Opt struct {
 	// content for each wtype.File from file of the same name in the current
 	// directory.
 	TransitionalReadLocalFiles bool
+	// Maximum number of workflow processes to run concurrently. If zero,
+	// defaults to runtime.GOMAXPROCS.
+	MaxConcurrency int
+	// If not empty, save a checkpoint to this file after each workflow
+	// process completes.
+	CheckpointFile string
+	// If not nil, resume execution from this checkpoint instead of assigning
+	// Params to the workflow.
+	Resume *Checkpoint
+	// If not nil, reuse the results of elements from this cache and save
+	// new results to it.
+	Cache *Cache
 }
 
-// Run is a simple entrypoint for one-shot execution of workflows.
+// Run is a simple entrypoint for one-shot execution of workflows. If parent
+// does not have a profile, execution is profiled with a new one.
 func Run(parent context.Context, opt Opt) (res *Result, err error) {
+	if profile.FromContext(parent) == nil {
+		parent = profile.NewContext(parent)
+	}
 	ctx := sampletracker.NewContext(target.WithTarget(withID(parent, opt.ID), opt.Target))
 
-	w, err := workflow.New(workflow.Opt{FromDesc: opt.Workflow})
+	ctxTr, tr := WithTrace(ctx)
+
+	wopt := workflow.Opt{
+		FromDesc:       opt.Workflow,
+		MaxConcurrency: opt.MaxConcurrency,
+	}
+	if opt.Cache != nil {
+		wopt.Call = opt.Cache.call
+	}
+	if opt.CheckpointFile != "" {
+		wopt.Checkpoint = func(state *workflow.State) error {
+			cp, err := makeCheckpoint(ctx, state, tr)
+			if err != nil {
+				return err
+			}
+			return cp.WriteFile(opt.CheckpointFile)
+		}
+	}
+
+	w, err := workflow.New(wopt)
 	if err != nil {
 		return nil, err
 	}
 
-	if _, err := setParams(ctx, w, opt.Params, opt.TransitionalReadLocalFiles); err != nil {
+	if opt.Resume != nil {
+		if err := restoreCheckpoint(ctx, w, tr, opt.Resume); err != nil {
+			return nil, err
+		}
+	} else if _, err := setParams(ctx, w, opt.Params, opt.TransitionalReadLocalFiles); err != nil {
 		return nil, err
 	}
 
-	ctxTr, tr := WithTrace(ctx)
 	defer func() {
 		if res := recover(); res == nil {
 			return
-		} else if uErr, ok := res.(UserError); ok {
+		} else if eErr, ok := res.(*ElementError); ok {
 			// Errorf internally calls panic, which is *not* the Go
 			// way. But until we fix that, to avoid full stack traces
 			// popping out here, we catch this case, and we deliberately
 			// do not attach a stack trace to it.
-			err = uErr
+			err = eErr
 		} else {
 			err = fmt.Errorf("%s\n%s", res, inject.ElementStackTrace())
 		}
 	}()
-	if err := w.Run(ctxTr); err != nil {
-		return nil, err
+	span := profile.Start(ctx, "execute", "run workflow")
+	err = w.Run(ctxTr)
+	span.End()
+	if err != nil {
+		return nil, elementErrors(w, err)
 	}
 
 	t, err := target.GetTarget(ctx)
@@ -73,12 +119,23 @@ func Run(parent context.Context, opt Opt) (res *Result, err error) {
 		return nil, err
 	}
 
-	nodes, err := getMaker(ctx).MakeNodes(tr.Instructions())
+	span = profile.Start(ctx, "execute", "make nodes")
+	nodes, err := getMaker(ctx).MakeNodes(tr.SortedInstructions(w.Order()))
+	span.End()
 	if err != nil {
 		return nil, err
 	}
 
+	span = profile.Start(ctx, "execute", "compile")
 	instrs, err := codegen.Compile(ctx, t, nodes)
+	span.End()
+	if err != nil {
+		return nil, err
+	}
+
+	span = profile.Start(ctx, "execute", "schedule")
+	schedule, err := target.NewSchedule(instrs)
+	span.End()
 	if err != nil {
 		return nil, err
 	}
@@ -87,5 +144,7 @@ func Run(parent context.Context, opt Opt) (res *Result, err error) {
 		Workflow: w,
 		Input:    nodes,
 		Insts:    instrs,
+		Schedule: schedule,
+		Profile:  profile.FromContext(ctx),
 	}, nil
 }
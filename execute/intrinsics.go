@@ -9,7 +9,6 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/inventory"
-	"github.com/antha-lang/antha/microArch/sampletracker"
 	"github.com/antha-lang/antha/target"
 )
 
@@ -20,12 +19,14 @@ type commandInst struct {
 	// Components created by this command. Returned back to user code
 	result  []*wtype.Liquid
 	Command *ast.Command
+	// Workflow process that issued this command
+	process string
 }
 
 // SetInputPlate Indicate to the scheduler the the contents of the plate is user
 // supplied. This modifies the argument to mark each well as such.
 func SetInputPlate(ctx context.Context, plate *wtype.Plate) {
-	sampletracker.FromContext(ctx).SetInputPlate(plate)
+	setInputPlate(ctx, plate)
 }
 
 // An IncubateOpt are options to an incubate command
@@ -55,8 +56,8 @@ func newCompFromComp(ctx context.Context, in *wtype.Liquid) *wtype.Liquid {
 	comp.BlockID = wtype.NewBlockID(getID(ctx))
 	comp.SetGeneration(comp.Generation() + 1)
 
-	getMaker(ctx).UpdateAfterInst(in.ID, comp.ID)
-	sampletracker.FromContext(ctx).UpdateIDOf(in.ID, comp.ID)
+	updateAfterInst(ctx, in.ID, comp.ID)
+	updateIDOf(ctx, in.ID, comp.ID)
 
 	return comp
 }
@@ -84,6 +85,9 @@ func Incubate(ctx context.Context, in *wtype.Liquid, opt IncubateOpt) *wtype.Liq
 				Selector: []ast.NameValue{
 					target.DriverSelectorV1ShakerIncubator,
 				},
+				Capabilities: append(
+					ast.NewValueCapabilities(target.CapabilityTemperature, opt.Temp, opt.PreTemp),
+					ast.NewValueCapabilities(target.CapabilityShakeRate, opt.ShakeRate, opt.PreShakeRate)...),
 			},
 		},
 	}
@@ -92,6 +96,136 @@ func Incubate(ctx context.Context, in *wtype.Liquid, opt IncubateOpt) *wtype.Liq
 	return inst.result[0]
 }
 
+// A CentrifugeOpt are options to a centrifuge command
+type CentrifugeOpt struct {
+	// Relative centrifugal force at which to spin component, e.g., 500 xg
+	Force wunit.Acceleration
+	// Time for which to spin component at speed
+	Time wunit.Time
+	// Temperature at which to spin component, if any
+	Temp wunit.Temperature
+	// Time to reach speed, if not the device default
+	AccelTime wunit.Time
+	// Time to stop, if not the device default
+	DecelTime wunit.Time
+}
+
+// Centrifuge centrifuges a component
+func Centrifuge(ctx context.Context, in *wtype.Liquid, opt CentrifugeOpt) *wtype.Liquid {
+	if !opt.Force.IsPositive() {
+		Errorf(ctx, "cannot centrifuge %s: no force given", in.CName)
+	} else if !opt.Time.IsPositive() {
+		Errorf(ctx, "cannot centrifuge %s: no time given", in.CName)
+	}
+
+	inst := &commandInst{
+		Args:   []*wtype.Liquid{in},
+		result: []*wtype.Liquid{newCompFromComp(ctx, in)},
+		Command: &ast.Command{
+			Inst: &ast.CentrifugeInst{
+				Force:     opt.Force,
+				Time:      opt.Time,
+				Temp:      opt.Temp,
+				AccelTime: opt.AccelTime,
+				DecelTime: opt.DecelTime,
+			},
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1Centrifuge,
+				},
+				Capabilities: append(
+					ast.NewValueCapabilities(target.CapabilityForce, opt.Force),
+					ast.NewValueCapabilities(target.CapabilityTemperature, opt.Temp)...),
+			},
+		},
+	}
+
+	Issue(ctx, inst)
+	return inst.result[0]
+}
+
+// Thermocycle runs a thermocycling programme, e.g., for PCR, on a component
+func Thermocycle(ctx context.Context, in *wtype.Liquid, programme wtype.ThermocycleProgramme) *wtype.Liquid {
+	if err := programme.Validate(); err != nil {
+		Errorf(ctx, "cannot thermocycle %s: %s", in.CName, err)
+	}
+
+	inst := &commandInst{
+		Args:   []*wtype.Liquid{in},
+		result: []*wtype.Liquid{newCompFromComp(ctx, in)},
+		Command: &ast.Command{
+			Inst: &ast.ThermocycleInst{
+				ComponentIn: in,
+				Programme:   programme,
+			},
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1Thermocycler,
+				},
+				Capabilities: programmeNeeds(programme),
+			},
+		},
+	}
+
+	Issue(ctx, inst)
+	return inst.result[0]
+}
+
+// programmeNeeds returns the temperatures that a thermocycler needs to reach
+// to run a programme
+func programmeNeeds(programme wtype.ThermocycleProgramme) []ast.Capability {
+	temps := []wunit.Measurement{programme.HoldTemp}
+	for _, stage := range programme.Stages {
+		for _, step := range stage.Steps {
+			temps = append(temps, step.Temp)
+		}
+	}
+	return ast.NewValueCapabilities(target.CapabilityTemperature, temps...)
+}
+
+func cover(ctx context.Context, in *wtype.Liquid, action ast.CoverAction, selector ast.NameValue) *wtype.Liquid {
+	inst := &commandInst{
+		Args:   []*wtype.Liquid{in},
+		result: []*wtype.Liquid{newCompFromComp(ctx, in)},
+		Command: &ast.Command{
+			Inst: &ast.CoverInst{
+				ComponentIn: in,
+				Action:      action,
+			},
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					selector,
+				},
+			},
+		},
+	}
+
+	Issue(ctx, inst)
+	return inst.result[0]
+}
+
+// Seal seals the plate holding a component. The plate must be peeled before
+// its wells can be accessed again.
+func Seal(ctx context.Context, in *wtype.Liquid) *wtype.Liquid {
+	return cover(ctx, in, ast.SealAction, target.DriverSelectorV1Sealer)
+}
+
+// Peel peels the seal from the plate holding a component
+func Peel(ctx context.Context, in *wtype.Liquid) *wtype.Liquid {
+	return cover(ctx, in, ast.PeelAction, target.DriverSelectorV1Sealer)
+}
+
+// Lid puts a lid on the plate holding a component. The lid must be removed
+// before its wells can be accessed again.
+func Lid(ctx context.Context, in *wtype.Liquid) *wtype.Liquid {
+	return cover(ctx, in, ast.LidAction, target.DriverSelectorV1Human)
+}
+
+// Unlid removes the lid from the plate holding a component
+func Unlid(ctx context.Context, in *wtype.Liquid) *wtype.Liquid {
+	return cover(ctx, in, ast.UnlidAction, target.DriverSelectorV1Human)
+}
+
 // prompt... works pretty much like Handle does
 // but passes the instruction to the planner
 // in future this should generate handles as side-effects
@@ -302,7 +436,7 @@ func mix(ctx context.Context, inst *wtype.LHInstruction) *commandInst {
 		if c.Generation() > mx {
 			mx = c.Generation()
 		}
-		getMaker(ctx).UpdateAfterInst(c.ID, result.ID)
+		updateAfterInst(ctx, c.ID, result.ID)
 	}
 
 	inst.SetGeneration(mx)
@@ -351,6 +485,16 @@ func MixInto(ctx context.Context, outplate *wtype.Plate, address string, compone
 	}))
 }
 
+// MixIntoLayout mixes components into a well of the plate chosen
+// according to the layout
+func MixIntoLayout(ctx context.Context, outplate *wtype.Plate, layout wtype.OutputLayout, components ...*wtype.Liquid) *wtype.Liquid {
+	return genericMix(ctx, mixer.GenericMix(mixer.MixOptions{
+		Inputs:      components,
+		Destination: outplate,
+		Layout:      &layout,
+	}))
+}
+
 // MixNamed mixes components
 func MixNamed(ctx context.Context, outplatetype, address string, platename string, components ...*wtype.Liquid) *wtype.Liquid {
 	return genericMix(ctx, mixer.GenericMix(mixer.MixOptions{
@@ -403,7 +547,7 @@ func splitSample(ctx context.Context, component *wtype.Liquid, volume wunit.Volu
 	cmpMoving, cmpStaying := mixer.SplitSample(component, volume)
 
 	//the ID of the component that is staying has been updated
-	sampletracker.FromContext(ctx).UpdateIDOf(component.ID, cmpStaying.ID)
+	updateIDOf(ctx, component.ID, cmpStaying.ID)
 
 	split.AddOutput(cmpMoving)
 	split.AddOutput(cmpStaying)
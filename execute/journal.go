@@ -0,0 +1,94 @@
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/workflow"
+)
+
+type effectKind string
+
+const (
+	afterInstEffect  effectKind = "afterInst"
+	updateIDEffect   effectKind = "updateID"
+	inputPlateEffect effectKind = "inputPlate"
+)
+
+// An effect is a change to the execution state, other than issuing an
+// instruction, made by a workflow process.
+type effect struct {
+	Process string
+	Kind    effectKind
+	OldID   string       `json:",omitempty"`
+	NewID   string       `json:",omitempty"`
+	Plate   *wtype.Plate `json:",omitempty"`
+}
+
+// A journal records the effects of each process so that they can be replayed
+// when resuming from a checkpoint.
+type journal struct {
+	lock    sync.Mutex
+	effects []*effect
+}
+
+func (a *journal) add(e *effect) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.effects = append(a.effects, e)
+}
+
+// Effects returns the effects of the given processes in the order they were
+// made
+func (a *journal) Effects(processes map[string]bool) []*effect {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var effects []*effect
+	for _, e := range a.effects {
+		if madeBy(processes, e.Process) {
+			effects = append(effects, e)
+		}
+	}
+	return effects
+}
+
+func apply(ctx context.Context, e *effect) {
+	switch e.Kind {
+	case afterInstEffect:
+		getMaker(ctx).UpdateAfterInst(e.OldID, e.NewID)
+	case updateIDEffect:
+		sampletracker.FromContext(ctx).UpdateIDOf(e.OldID, e.NewID)
+	case inputPlateEffect:
+		sampletracker.FromContext(ctx).SetInputPlate(e.Plate)
+	}
+	getJournal(ctx).add(e)
+}
+
+func updateAfterInst(ctx context.Context, oldID, newID string) {
+	apply(ctx, &effect{
+		Process: workflow.ProcessFromContext(ctx),
+		Kind:    afterInstEffect,
+		OldID:   oldID,
+		NewID:   newID,
+	})
+}
+
+func updateIDOf(ctx context.Context, oldID, newID string) {
+	apply(ctx, &effect{
+		Process: workflow.ProcessFromContext(ctx),
+		Kind:    updateIDEffect,
+		OldID:   oldID,
+		NewID:   newID,
+	})
+}
+
+func setInputPlate(ctx context.Context, plate *wtype.Plate) {
+	apply(ctx, &effect{
+		Process: workflow.ProcessFromContext(ctx),
+		Kind:    inputPlateEffect,
+		Plate:   plate,
+	})
+}
@@ -1,11 +1,15 @@
 package execute
 
 import (
+	"sync"
+
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/ast"
 )
 
 type maker struct {
+	// Lock on afterInst, which is updated by concurrently running elements
+	lock sync.Mutex
 	// Map from old LHComponent id to new id after instruction (typically 1)
 	afterInst map[string][]string
 	// Map from old LHComponent id to new id after sample
@@ -109,6 +113,8 @@ func (a *maker) removeMultiEdges() {
 }
 
 func (a *maker) UpdateAfterInst(oldID, newID string) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
 	a.afterInst[oldID] = append(a.afterInst[oldID], newID)
 }
 
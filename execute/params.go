@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"reflect"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	api "github.com/antha-lang/antha/api/v1"
@@ -137,10 +138,12 @@ func (a *unmarshaler) unmarshalStruct(ctx context.Context, data []byte, obj inte
 	return err
 }
 
-func setParam(ctx context.Context, um *unmarshaler, w *workflow.Workflow, process, name string, data []byte, in map[string]interface{}) error {
-	value, ok := in[name]
+// unmarshalParam unmarshals data into a value of the same type as the named
+// parameter in typ
+func unmarshalParam(ctx context.Context, um *unmarshaler, name string, data []byte, typ map[string]interface{}) (interface{}, error) {
+	value, ok := typ[name]
 	if !ok {
-		return errUnknownParam
+		return nil, errUnknownParam
 	}
 
 	m := &meta.Unmarshaler{
@@ -149,45 +152,114 @@ func setParam(ctx context.Context, um *unmarshaler, w *workflow.Workflow, proces
 		},
 	}
 	if err := m.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func setParam(ctx context.Context, um *unmarshaler, w *workflow.Workflow, process, name string, data []byte, in map[string]interface{}) error {
+	value, err := unmarshalParam(ctx, um, name, data, in)
+	if err != nil {
 		return err
 	}
 
 	return w.SetParam(workflow.Port{Process: process, Port: name}, value)
 }
 
+// mapValue returns an example of the values of a process given an example x
+// of the values of its component. If the process is a map process, the values
+// of the given ports are slices of the values of the component.
+func mapValue(x interface{}, ports []string) inject.Value {
+	value := inject.MakeValue(x)
+	if len(ports) == 0 {
+		return value
+	}
+	types, err := inject.FieldTypes(x)
+	if err != nil {
+		return value
+	}
+	for _, port := range ports {
+		if typ, ok := types[port]; ok {
+			value[port] = reflect.MakeSlice(reflect.SliceOf(typ), 0, 0).Interface()
+		}
+	}
+	return value
+}
+
+// typedRunner returns the type information of the element run by a process
+func typedRunner(ctx context.Context, w *workflow.Workflow, process string) (inject.TypedRunner, error) {
+	query, err := w.Query(process)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get component for process %q: %s", process, err)
+	}
+	c := query.Repo
+	runner, err := inject.Find(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unknown component %q: %s", c, err)
+	}
+	cr, ok := runner.(inject.TypedRunner)
+	if !ok {
+		return nil, fmt.Errorf("cannot get type information for component %q: type %T", c, runner)
+	}
+	return cr, nil
+}
+
 func setParams(ctx context.Context, w *workflow.Workflow, params *RawParams, readLocalFiles bool) (*mixer.Opt, error) {
 	if params == nil {
 		return nil, nil
 	}
 
+	fail := func(_ workflow.Port, err error) error {
+		return err
+	}
+	if err := assignParams(ctx, w, params, readLocalFiles, fail); err != nil {
+		return nil, err
+	}
+
+	return params.Config, nil
+}
+
+// assignParams assigns params to the processes of w. Each failure is passed
+// to fail with the port, or the process if the port is empty, that it is
+// about. Assignment stops if fail returns an error; otherwise, it carries on
+// with the next parameter.
+func assignParams(ctx context.Context, w *workflow.Workflow, params *RawParams, readLocalFiles bool, fail func(workflow.Port, error) error) error {
 	um := &unmarshaler{
 		ReadLocalFiles: readLocalFiles,
 	}
 
+	ins := make(map[string]inject.Value)
 	for process, params := range params.Parameters {
-		c, err := w.FuncName(process)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get component for process %q: %s", process, err)
-		}
-		runner, err := inject.Find(ctx, inject.NameQuery{
-			Repo:  c,
-			Stage: api.ElementStage_STEPS,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("unknown component %q: %s", c, err)
-		}
-		cr, ok := runner.(inject.TypedRunner)
-		if !ok {
-			return nil, fmt.Errorf("cannot get type information for component %q: type %T", c, runner)
-		}
-		in := inject.MakeValue(cr.Input())
 		for name, value := range params {
-			if err := setParam(ctx, um, w, process, name, value, in); err != nil {
-				return nil, fmt.Errorf("cannot assign parameter %q of process %q to %s: %s",
-					name, process, string(value), err)
+			// Parameters of composite processes are assigned to their inner
+			// processes
+			port := w.ResolveInput(workflow.Port{Process: process, Port: name})
+			in, ok := ins[port.Process]
+			if !ok {
+				cr, err := typedRunner(ctx, w, port.Process)
+				if err != nil {
+					// Report each process once and skip its parameters
+					ins[port.Process] = nil
+					if err := fail(workflow.Port{Process: port.Process}, err); err != nil {
+						return err
+					}
+					continue
+				}
+				in = mapValue(cr.Input(), w.MapOver(port.Process))
+				ins[port.Process] = in
+			}
+			if in == nil {
+				continue
+			}
+			if err := setParam(ctx, um, w, port.Process, port.Port, value, in); err != nil {
+				if err := fail(port, fmt.Errorf("cannot assign parameter %q of process %q to %s: %s",
+					name, process, string(value), err)); err != nil {
+					return err
+				}
 			}
 		}
 	}
 
-	return params.Config, nil
+	return nil
 }
@@ -236,3 +236,25 @@ func TestConstructFile(t *testing.T) {
 		t.Errorf("expecting %v but got %v instead", e, f)
 	}
 }
+
+func TestMapParam(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+
+	type Input struct {
+		A string
+		B int
+	}
+	typ := mapValue(&Input{}, []string{"A"})
+
+	if v, err := unmarshalParam(ctx, &unmarshaler{}, "A", []byte(`["a", "b"]`), typ); err != nil {
+		t.Fatal(err)
+	} else if golden := []string{"a", "b"}; !reflect.DeepEqual(v, golden) {
+		t.Errorf("expecting %v but got %v instead", golden, v)
+	}
+
+	if v, err := unmarshalParam(ctx, &unmarshaler{}, "B", []byte(`1`), typ); err != nil {
+		t.Fatal(err)
+	} else if v != 1 {
+		t.Errorf("expecting %v but got %v instead", 1, v)
+	}
+}
@@ -2,7 +2,10 @@ package execute
 
 import (
 	"context"
+	"sort"
 	"sync"
+
+	"github.com/antha-lang/antha/workflow"
 )
 
 // This is pretty gross. What we do here is add into a context a
@@ -14,6 +17,8 @@ import (
 type Trace struct {
 	lock         sync.Mutex
 	instructions []*commandInst
+	// Keys of cached results used in this run
+	cacheKeys map[string]bool
 }
 
 // Issue an instruction - this records the instruction into the trace.
@@ -24,6 +29,22 @@ func (tr *Trace) Issue(instruction *commandInst) {
 	tr.instructions = append(tr.instructions, instruction)
 }
 
+// useCacheKey records the use of the cached result with the given key,
+// returning false if it has already been used.
+func (tr *Trace) useCacheKey(key string) bool {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+
+	if tr.cacheKeys[key] {
+		return false
+	}
+	if tr.cacheKeys == nil {
+		tr.cacheKeys = make(map[string]bool)
+	}
+	tr.cacheKeys[key] = true
+	return true
+}
+
 // Returns a (shallow) copy (to avoid data races) of the issued
 // instructions.
 func (tr *Trace) Instructions() []*commandInst {
@@ -35,8 +56,49 @@ func (tr *Trace) Instructions() []*commandInst {
 	return clone
 }
 
+// SortedInstructions returns a (shallow) copy of the issued instructions,
+// grouped by the process that issued them. Processes appear in the given
+// order, runs of map processes appear in item order, and instructions issued
+// by the same process remain in issue order. Instructions issued outside of a
+// process come first.
+func (tr *Trace) SortedInstructions(order []string) []*commandInst {
+	rank := make(map[string]int)
+	for idx, process := range order {
+		rank[process] = idx + 1
+	}
+	key := func(name string) (int, int) {
+		if r, ok := rank[name]; ok {
+			return r, -1
+		}
+		process, idx := workflow.SplitInstanceName(name)
+		return rank[process], idx
+	}
+
+	insts := tr.Instructions()
+	sort.SliceStable(insts, func(i, j int) bool {
+		ri, ii := key(insts[i].process)
+		rj, ij := key(insts[j].process)
+		if ri != rj {
+			return ri < rj
+		}
+		return ii < ij
+	})
+	return insts
+}
+
+// madeBy returns if the process with the given name is one of processes or
+// is a run of one of them
+func madeBy(processes map[string]bool, name string) bool {
+	if processes[name] {
+		return true
+	}
+	process, idx := workflow.SplitInstanceName(name)
+	return idx >= 0 && processes[process]
+}
+
 // Issue an instruction - this records the instruction into the trace.
 func Issue(ctx context.Context, instruction *commandInst) {
+	instruction.process = workflow.ProcessFromContext(ctx)
 	getTrace(ctx).Issue(instruction)
 }
 
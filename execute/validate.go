@@ -0,0 +1,66 @@
+package execute
+
+import (
+	"context"
+
+	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/utils"
+	"github.com/antha-lang/antha/workflow"
+)
+
+// Validate checks that a workflow and its parameters are well-typed without
+// running it. Only the Workflow, Params and TransitionalReadLocalFiles
+// fields of opt are used. See workflow.Workflow.Validate for the checks made.
+//
+// Unknown processes and parameters that cannot be assigned are reported
+// along with the errors of workflow.Workflow.Validate, as a utils.ErrorSlice
+// of *workflow.PortError with at most one error per port.
+func Validate(parent context.Context, opt Opt) error {
+	ctx := sampletracker.NewContext(withID(parent, opt.ID))
+
+	w, err := workflow.New(workflow.Opt{
+		FromDesc: opt.Workflow,
+	})
+	if err != nil {
+		return err
+	}
+
+	var errs utils.ErrorSlice
+	seen := make(map[workflow.Port]bool)
+	add := func(port workflow.Port, err error) {
+		if !seen[port] {
+			seen[port] = true
+			errs = append(errs, &workflow.PortError{Port: port, Err: err})
+		}
+	}
+
+	if opt.Params != nil {
+		// Collect every failure rather than stopping at the first
+		if err := assignParams(ctx, w, opt.Params, opt.TransitionalReadLocalFiles, func(port workflow.Port, err error) error {
+			add(port, err)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Parameters that failed to assign are also reported as unassigned by
+	// Workflow.Validate, so keep only the first error for each port
+	if err := w.Validate(ctx); err != nil {
+		es, ok := err.(utils.ErrorSlice)
+		if !ok {
+			return err
+		}
+		for _, e := range es {
+			perr, ok := e.(*workflow.PortError)
+			if !ok {
+				return err
+			}
+			add(perr.Port, perr.Err)
+		}
+	}
+
+	workflow.SortPortErrors(errs)
+
+	return errs.Pack()
+}
@@ -0,0 +1,74 @@
+package execute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/utils"
+	"github.com/antha-lang/antha/workflow"
+)
+
+func TestValidateCollectsParamErrors(t *testing.T) {
+	type copyIn struct {
+		In    string
+		Times int
+	}
+	type copyOut struct {
+		Out string
+	}
+	ctx := inject.NewContext(context.Background())
+	if err := inject.Add(ctx, inject.Name{Repo: "Copy", Stage: api.ElementStage_STEPS}, &inject.CheckedRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			return inject.Value{"Out": value["In"]}, nil
+		},
+		In:  &copyIn{},
+		Out: &copyOut{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Validate(ctx, Opt{
+		Workflow: &workflow.Desc{
+			Processes: map[string]workflow.Process{
+				"A": {Component: "Copy"},
+				"B": {Component: "Copy"},
+				"C": {Component: "Missing"},
+			},
+		},
+		Params: &RawParams{
+			Parameters: map[string]map[string]json.RawMessage{
+				"A": {"In": json.RawMessage(`"a"`), "Times": json.RawMessage(`"twice"`)},
+				"B": {"In": json.RawMessage(`"b"`), "Times": json.RawMessage(`2`), "Extra": json.RawMessage(`1`)},
+				"C": {"In": json.RawMessage(`"c"`)},
+				"D": {"In": json.RawMessage(`"d"`)},
+			},
+		},
+	})
+
+	errs, ok := err.(utils.ErrorSlice)
+	if !ok {
+		t.Fatalf("expecting errors but got %v", err)
+	}
+
+	expected := []workflow.Port{
+		{Process: "A", Port: "Times"},
+		{Process: "B", Port: "Extra"},
+		{Process: "C"},
+		{Process: "D"},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expecting %d errors but got %d:\n%s", len(expected), len(errs), err)
+	}
+	for i, e := range errs {
+		perr, ok := e.(*workflow.PortError)
+		if !ok {
+			t.Fatalf("expecting *workflow.PortError but got %T", e)
+		}
+		if perr.Port != expected[i] {
+			t.Errorf("error %d: expecting error on %q but got %q", i, expected[i], perr)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package graph
+
+// LongestPathOpt is a set of options to LongestPath
+type LongestPathOpt struct {
+	Graph  Graph
+	Weight func(n Node) int64 // Weight of each node. Weights should not be negative.
+}
+
+// LongestPaths are the heaviest paths starting at each node of a graph
+type LongestPaths struct {
+	// Total weight of the heaviest path starting at each node, including the
+	// node itself
+	Dist map[Node]int64
+	// Node after each node on its heaviest path or nil if the path ends at
+	// the node
+	Next map[Node]Node
+	// First node, in graph order, of the heaviest path in the graph
+	Start Node
+}
+
+// Path returns the heaviest path starting at a node
+func (a *LongestPaths) Path(n Node) (ret []Node) {
+	for ; n != nil; n = a.Next[n] {
+		ret = append(ret, n)
+	}
+	return
+}
+
+// LongestPath computes the heaviest path starting at each node of a directed
+// acyclic graph, where the weight of a path is the sum of the weights of its
+// nodes. Returns an error if the graph contains a cycle.
+func LongestPath(opt LongestPathOpt) (*LongestPaths, error) {
+	order, err := TopoSort(TopoSortOpt{Graph: opt.Graph})
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &LongestPaths{
+		Dist: make(map[Node]int64),
+		Next: make(map[Node]Node),
+	}
+
+	// Successors come before their predecessors in topological order
+	for _, n := range order {
+		var next Node
+		var dnext int64
+		for i, inum := 0, opt.Graph.NumOuts(n); i < inum; i++ {
+			out := opt.Graph.Out(n, i)
+			if d := ret.Dist[out]; next == nil || d > dnext {
+				next = out
+				dnext = d
+			}
+		}
+		ret.Dist[n] = opt.Weight(n) + dnext
+		if next != nil {
+			ret.Next[n] = next
+		}
+	}
+
+	for i, inum := 0, opt.Graph.NumNodes(); i < inum; i++ {
+		n := opt.Graph.Node(i)
+		if ret.Start == nil || ret.Dist[n] > ret.Dist[ret.Start] {
+			ret.Start = n
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLongestPath(t *testing.T) {
+	g := MakeTestGraph(map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d"},
+		"d": {"e", "f"},
+		"e": {"g"},
+		"f": {"g"},
+	})
+	weights := map[string]int64{
+		"a": 1,
+		"b": 5,
+		"c": 2,
+		"d": 1,
+		"e": 1,
+		"f": 3,
+		"g": 2,
+	}
+
+	edist := map[string]int64{
+		"a": 12,
+		"b": 11,
+		"c": 8,
+		"d": 6,
+		"e": 3,
+		"f": 5,
+		"g": 2,
+	}
+
+	paths, err := LongestPath(LongestPathOpt{
+		Graph: g,
+		Weight: func(n Node) int64 {
+			return weights[n.(string)]
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k, v := range edist {
+		if d, ok := paths.Dist[k]; !ok {
+			t.Errorf("did not find dist for node %q", k)
+		} else if d != v {
+			t.Errorf("expected %d for node %q found %d instead", v, k, d)
+		}
+	}
+
+	if e, f := []string{"a", "b", "d", "f", "g"}, toString(paths.Path(paths.Start)); !reflect.DeepEqual(e, f) {
+		t.Errorf("expected path %q found %q", e, f)
+	}
+}
+
+func TestLongestPathCycle(t *testing.T) {
+	g := MakeTestGraph(map[string][]string{
+		"a": {"b"},
+		"b": {"a"},
+	})
+	_, err := LongestPath(LongestPathOpt{
+		Graph: g,
+		Weight: func(n Node) int64 {
+			return 1
+		},
+	})
+	if err == nil {
+		t.Error("expected error for cyclic graph")
+	}
+}
@@ -37,35 +37,27 @@ func Add(parent context.Context, name Name, runner Runner) error {
 	return reg.Add(name, runner)
 }
 
-// Find returns a Runner given a query
+// Find returns a Runner given a query. Runners added to inner contexts take
+// precedence over those added to outer contexts. If more than one version of
+// a runner in the same context satisfies the query, the highest version is
+// returned.
 func Find(parent context.Context, query NameQuery) (Runner, error) {
-	type result struct {
-		runner Runner
-		level  int
-	}
+	_, r, err := Lookup(parent, query)
+	return r, err
+}
 
-	ctx := parent
-	level := 0
-	reg := getRegistry(ctx)
-	var results []result
-	for reg != nil {
-		runners, err := reg.Find(query)
+// Lookup is like Find but also returns the name the Runner was added with
+func Lookup(parent context.Context, query NameQuery) (Name, Runner, error) {
+	for reg := getRegistry(parent); reg != nil; reg = getRegistry(reg.parent) {
+		entries, err := reg.Find(query)
 		if err != nil {
-			return nil, err
+			return Name{}, nil, err
 		}
-		for _, runner := range runners {
-			results = append(results, result{level: level, runner: runner})
+		if len(entries) != 0 {
+			return entries[0].Name, entries[0].Runner, nil
 		}
-		level++
-		ctx = reg.parent
-		reg = getRegistry(ctx)
-	}
-
-	// TODO: better matching heuristics?
-	for _, r := range results {
-		return r.runner, nil
 	}
-	return nil, errFuncNotFound
+	return Name{}, nil, errFuncNotFound
 }
 
 // Call a function that satisfies the query
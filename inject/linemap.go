@@ -35,6 +35,55 @@ func RegisterLineMap(goElementPath, anElementPath, elementName string, lineMap m
 	elementMaps[goElementPath] = em
 }
 
+// A SourceLocation is a line in the source of an element
+type SourceLocation struct {
+	Element string `json:"element"`        // Name of the element
+	File    string `json:"file"`           // Path of the element source file
+	Line    int    `json:"line,omitempty"` // Line number or zero if unknown
+}
+
+// String returns the location in file:line form
+func (a *SourceLocation) String() string {
+	if a.Line == 0 {
+		return a.File
+	}
+	return fmt.Sprintf("%s:%d", a.File, a.Line)
+}
+
+// findElementMap returns the line map of the element containing a frame or
+// nil if the frame is not within an element
+func findElementMap(frame runtime.Frame) *elementMap {
+	for suffix, em := range elementMaps {
+		if strings.HasSuffix(frame.File, suffix) {
+			return em
+		}
+	}
+	return nil
+}
+
+// ElementCaller returns the location in element source of the innermost
+// frame of the current stack that is within an element, or nil if no frame is
+// within an element. When called from a function deferred during a panic,
+// this is the element line that panicked.
+func ElementCaller() *SourceLocation {
+	cs := make([]uintptr, 1000)
+	num := runtime.Callers(2, cs)
+	frames := runtime.CallersFrames(cs[:num])
+	for {
+		frame, more := frames.Next()
+		if em := findElementMap(frame); em != nil {
+			return &SourceLocation{
+				Element: em.elementName,
+				File:    em.anthaElementPath,
+				Line:    em.lineMap[frame.Line],
+			}
+		}
+		if !more {
+			return nil
+		}
+	}
+}
+
 // ElementStackTrace creates a stack trace, detecting whether or not
 // the panic occured within an element. If the panic did not occur
 // within an element, then the normal debug.Stack() is
@@ -111,19 +160,16 @@ func ElementStackTrace() string {
 	// and we will revert to using the standard stack trace.
 	var strs []string
 	for {
-		foundElement := false
-		for suffix, em := range elementMaps {
-			if strings.HasSuffix(frame.File, suffix) {
-				foundElement = true
-				lineStr := "(unknown line)"
-				if line, foundLine := em.lineMap[frame.Line]; foundLine {
-					lineStr = fmt.Sprint(line)
-				}
-				strs = append(strs, fmt.Sprintf("- [Element %s] %s:%s", em.elementName, em.anthaElementPath, lineStr))
-				strs = append(strs, fmt.Sprintf("       [Go] %s", frame.Function))
-				strs = append(strs, fmt.Sprintf("            %s:%d", frame.File, frame.Line))
-				break
+		em := findElementMap(frame)
+		foundElement := em != nil
+		if foundElement {
+			lineStr := "(unknown line)"
+			if line, foundLine := em.lineMap[frame.Line]; foundLine {
+				lineStr = fmt.Sprint(line)
 			}
+			strs = append(strs, fmt.Sprintf("- [Element %s] %s:%s", em.elementName, em.anthaElementPath, lineStr))
+			strs = append(strs, fmt.Sprintf("       [Go] %s", frame.Function))
+			strs = append(strs, fmt.Sprintf("            %s:%d", frame.File, frame.Line))
 		}
 		if len(strs) == 0 { // we haven't been able to find any matching element, so use standard stack
 			return standard
@@ -3,6 +3,7 @@ package inject
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 
 	api "github.com/antha-lang/antha/api/v1"
@@ -10,24 +11,36 @@ import (
 
 var errAlreadyAdded = errors.New("already added")
 
+// repoKey identifies all the versions of a runner
+type repoKey struct {
+	Repo  string
+	Stage api.ElementStage
+}
+
+type entry struct {
+	Name   Name
+	Runner Runner
+}
+
 type registry struct {
 	lock   sync.Mutex
 	parent context.Context
-	reg    map[Name]Runner
+	reg    map[repoKey][]entry // Entries in order of decreasing version
 }
 
 // Name uniquely identifiers a inject.Runner
 type Name struct {
 	Host  string // Host
 	Repo  string // Name
-	Tag   string // Version
+	Tag   string // Version, preferably a semantic version (see ParseVersion)
 	Stage api.ElementStage
 }
 
 // NameQuery is a query for a Runner
 type NameQuery struct {
+	Host  string // Host; if empty, runners of any host match
 	Repo  string // Name
-	Tag   string // Version
+	Tag   string // Version constraint (see Constraint)
 	Stage api.ElementStage
 }
 
@@ -36,27 +49,42 @@ func (a *registry) Add(name Name, runner Runner) error {
 	defer a.lock.Unlock()
 
 	if a.reg == nil {
-		a.reg = make(map[Name]Runner)
+		a.reg = make(map[repoKey][]entry)
 	}
-	if r := a.reg[name]; r != nil {
-		return errAlreadyAdded
+	key := repoKey{Repo: name.Repo, Stage: name.Stage}
+	entries := a.reg[key]
+	for _, e := range entries {
+		if e.Name == name {
+			return errAlreadyAdded
+		}
 	}
-	a.reg[name] = runner
+	entries = append(entries, entry{Name: name, Runner: runner})
+	sort.SliceStable(entries, func(i, j int) bool {
+		return LessTag(entries[j].Name.Tag, entries[i].Name.Tag)
+	})
+	a.reg[key] = entries
 	return nil
 }
 
-func (a *registry) Find(query NameQuery) ([]Runner, error) {
+// Find returns the entries that satisfy a query in order of decreasing
+// version
+func (a *registry) Find(query NameQuery) ([]entry, error) {
+	c, err := ParseConstraint(query.Tag)
+	if err != nil {
+		return nil, err
+	}
+
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	name := Name{
-		Repo:  query.Repo,
-		Tag:   query.Tag,
-		Stage: query.Stage,
-	}
-	r := a.reg[name]
-	if r == nil {
-		return nil, nil
+	var r []entry
+	for _, e := range a.reg[repoKey{Repo: query.Repo, Stage: query.Stage}] {
+		if query.Host != "" && query.Host != e.Name.Host {
+			continue
+		}
+		if c.Match(e.Name.Tag) {
+			r = append(r, e)
+		}
 	}
-	return []Runner{r}, nil
+	return r, nil
 }
@@ -0,0 +1,103 @@
+package remote
+
+import (
+	"context"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inject/remote/pb"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// A Client is a connection to a remote element server
+type Client struct {
+	host   string
+	conn   *grpc.ClientConn
+	client pb.ElementClient
+}
+
+// Dial connects to the element server at host
+func Dial(host string) (*Client, error) {
+	conn, err := grpc.Dial(host, grpc.WithInsecure())
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot connect to element server")
+	}
+	return &Client{
+		host:   host,
+		conn:   conn,
+		client: pb.NewElementClient(conn),
+	}, nil
+}
+
+// Close closes the connection to the server
+func (a *Client) Close() error {
+	return a.conn.Close()
+}
+
+// Runner returns a Runner that runs the remote element that satisfies query.
+// If out is not nil, outputs are decoded into values of the types of the
+// fields of out.
+func (a *Client) Runner(query inject.NameQuery, out interface{}) *Runner {
+	return &Runner{
+		client: a.client,
+		Query:  query,
+		Out:    out,
+	}
+}
+
+// Add adds a remote element to an inject context. The element is added under
+// name with Host set to the host of the client, so that queries, e.g., those
+// of workflow processes, can select it by host. If in and out are not nil,
+// they are examples of the inputs and outputs of the element, and the added
+// runner is an inject.TypedRunner.
+func (a *Client) Add(ctx context.Context, name inject.Name, in, out interface{}) error {
+	runner := a.Runner(inject.NameQuery{
+		Repo:  name.Repo,
+		Tag:   name.Tag,
+		Stage: name.Stage,
+	}, out)
+
+	name.Host = a.host
+	if in == nil || out == nil {
+		return inject.Add(ctx, name, runner)
+	}
+	return inject.Add(ctx, name, &inject.CheckedRunner{
+		RunFunc: runner.Run,
+		In:      in,
+		Out:     out,
+	})
+}
+
+// A Runner runs an element on a remote element server
+type Runner struct {
+	client pb.ElementClient
+	Query  inject.NameQuery
+	Out    interface{} // Example of output; if nil, outputs are generic JSON values
+}
+
+// Run implements an inject.Runner. Errors reported by the remote element are
+// returned as *Error.
+func (a *Runner) Run(ctx context.Context, value inject.Value) (inject.Value, error) {
+	data, err := encodeValue(value)
+	if err != nil {
+		return nil, errors.WithMessage(err, "cannot encode input")
+	}
+
+	reply, err := a.client.Run(ctx, &pb.RunRequest{
+		Repo:  a.Query.Repo,
+		Tag:   a.Query.Tag,
+		Stage: int32(a.Query.Stage),
+		Value: data,
+	})
+	if err != nil {
+		return nil, err
+	} else if reply.Error != nil {
+		return nil, fromProto(reply.Error)
+	}
+
+	out, err := decodeValue(reply.Value, a.Out)
+	if err != nil {
+		return nil, &Error{Kind: pb.ErrorKind_INVALID_VALUE, Message: err.Error()}
+	}
+	return out, nil
+}
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: element.proto
+
+/*
+Package pb is a generated protocol buffer package.
+
+It is generated from these files:
+	element.proto
+
+It has these top-level messages:
+	RunRequest
+	Error
+	RunReply
+*/
+package pb
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ErrorKind int32
+
+const (
+	ErrorKind_UNKNOWN ErrorKind = 0
+	// No element satisfies the query
+	ErrorKind_NOT_FOUND ErrorKind = 1
+	// Input or output value cannot be encoded or decoded
+	ErrorKind_INVALID_VALUE ErrorKind = 2
+	// Element returned an error
+	ErrorKind_FAILED ErrorKind = 3
+	// Element panicked
+	ErrorKind_PANIC ErrorKind = 4
+)
+
+var ErrorKind_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "NOT_FOUND",
+	2: "INVALID_VALUE",
+	3: "FAILED",
+	4: "PANIC",
+}
+var ErrorKind_value = map[string]int32{
+	"UNKNOWN":       0,
+	"NOT_FOUND":     1,
+	"INVALID_VALUE": 2,
+	"FAILED":        3,
+	"PANIC":         4,
+}
+
+func (x ErrorKind) String() string {
+	return proto.EnumName(ErrorKind_name, int32(x))
+}
+func (ErrorKind) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+type RunRequest struct {
+	// Query for the element to run
+	Repo string `protobuf:"bytes,1,opt,name=repo" json:"repo,omitempty"`
+	Tag  string `protobuf:"bytes,2,opt,name=tag" json:"tag,omitempty"`
+	// Value of org.antha_lang.antha.v1.ElementStage
+	Stage int32 `protobuf:"varint,3,opt,name=stage" json:"stage,omitempty"`
+	// JSON object of the input parameters of the element
+	Value []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *RunRequest) Reset()                    { *m = RunRequest{} }
+func (m *RunRequest) String() string            { return proto.CompactTextString(m) }
+func (*RunRequest) ProtoMessage()               {}
+func (*RunRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *RunRequest) GetRepo() string {
+	if m != nil {
+		return m.Repo
+	}
+	return ""
+}
+
+func (m *RunRequest) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *RunRequest) GetStage() int32 {
+	if m != nil {
+		return m.Stage
+	}
+	return 0
+}
+
+func (m *RunRequest) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+type Error struct {
+	Kind    ErrorKind `protobuf:"varint,1,opt,name=kind,enum=pb.ErrorKind" json:"kind,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message" json:"message,omitempty"`
+	// Stack trace of element, if any
+	Stack string `protobuf:"bytes,3,opt,name=stack" json:"stack,omitempty"`
+}
+
+func (m *Error) Reset()                    { *m = Error{} }
+func (m *Error) String() string            { return proto.CompactTextString(m) }
+func (*Error) ProtoMessage()               {}
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Error) GetKind() ErrorKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ErrorKind_UNKNOWN
+}
+
+func (m *Error) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *Error) GetStack() string {
+	if m != nil {
+		return m.Stack
+	}
+	return ""
+}
+
+type RunReply struct {
+	// JSON object of the output parameters of the element
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// If not null, the element did not run successfully
+	Error *Error `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *RunReply) Reset()                    { *m = RunReply{} }
+func (m *RunReply) String() string            { return proto.CompactTextString(m) }
+func (*RunReply) ProtoMessage()               {}
+func (*RunReply) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RunReply) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *RunReply) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RunRequest)(nil), "pb.RunRequest")
+	proto.RegisterType((*Error)(nil), "pb.Error")
+	proto.RegisterType((*RunReply)(nil), "pb.RunReply")
+	proto.RegisterEnum("pb.ErrorKind", ErrorKind_name, ErrorKind_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Element service
+
+type ElementClient interface {
+	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*RunReply, error)
+}
+
+type elementClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewElementClient(cc *grpc.ClientConn) ElementClient {
+	return &elementClient{cc}
+}
+
+func (c *elementClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*RunReply, error) {
+	out := new(RunReply)
+	err := grpc.Invoke(ctx, "/pb.Element/Run", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Element service
+
+type ElementServer interface {
+	Run(context.Context, *RunRequest) (*RunReply, error)
+}
+
+func RegisterElementServer(s *grpc.Server, srv ElementServer) {
+	s.RegisterService(&_Element_serviceDesc, srv)
+}
+
+func _Element_Run_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElementServer).Run(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Element/Run",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElementServer).Run(ctx, req.(*RunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Element_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Element",
+	HandlerType: (*ElementServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Run",
+			Handler:    _Element_Run_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "element.proto",
+}
+
+func init() { proto.RegisterFile("element.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 301 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x5d, 0x6b, 0xc2, 0x30,
+	0x14, 0x86, 0x8d, 0x6d, 0x75, 0x39, 0x5a, 0xc9, 0x0e, 0xbb, 0x28, 0xbb, 0x99, 0x2b, 0x0c, 0x64,
+	0x17, 0x32, 0xdc, 0x2f, 0x28, 0xb3, 0x42, 0x51, 0xe2, 0x16, 0xa6, 0x83, 0x5d, 0x4c, 0x74, 0x06,
+	0x11, 0x6b, 0x9b, 0xf5, 0x63, 0xe0, 0xbf, 0x1f, 0x4d, 0xb6, 0xba, 0xbb, 0x3c, 0xef, 0x81, 0xf7,
+	0x39, 0x9c, 0x80, 0x2b, 0x63, 0x79, 0x94, 0x49, 0x31, 0x54, 0x59, 0x5a, 0xa4, 0xd8, 0x54, 0x1b,
+	0xff, 0x03, 0x40, 0x94, 0x89, 0x90, 0x5f, 0xa5, 0xcc, 0x0b, 0x44, 0xb0, 0x33, 0xa9, 0x52, 0x8f,
+	0xf4, 0xc9, 0x80, 0x0a, 0xfd, 0x46, 0x06, 0x56, 0xb1, 0xde, 0x79, 0x4d, 0x1d, 0x55, 0x4f, 0xbc,
+	0x02, 0x27, 0x2f, 0xd6, 0x3b, 0xe9, 0x59, 0x7d, 0x32, 0x70, 0x84, 0x81, 0x2a, 0xfd, 0x5e, 0xc7,
+	0xa5, 0xf4, 0xec, 0x3e, 0x19, 0x74, 0x85, 0x01, 0xff, 0x1d, 0x9c, 0x30, 0xcb, 0xd2, 0x0c, 0x6f,
+	0xc1, 0x3e, 0xec, 0x93, 0xad, 0xae, 0xee, 0x8d, 0xdc, 0xa1, 0xda, 0x0c, 0xf5, 0x60, 0xba, 0x4f,
+	0xb6, 0x42, 0x8f, 0xd0, 0x83, 0xf6, 0x51, 0xe6, 0x79, 0xd5, 0x6c, 0x6c, 0x7f, 0xf8, 0x6b, 0xfc,
+	0x3c, 0x68, 0x23, 0x15, 0x06, 0xfc, 0x00, 0x2e, 0xf4, 0xee, 0x2a, 0x3e, 0x9d, 0xed, 0xe4, 0x9f,
+	0x1d, 0x6f, 0xc0, 0x91, 0x95, 0x44, 0xf7, 0x75, 0x46, 0xb4, 0xb6, 0x0a, 0x93, 0xdf, 0xbf, 0x00,
+	0xad, 0xb7, 0xc0, 0x0e, 0xb4, 0x17, 0x7c, 0xca, 0xe7, 0x6f, 0x9c, 0x35, 0xd0, 0x05, 0xca, 0xe7,
+	0xaf, 0xab, 0xc9, 0x7c, 0xc1, 0xc7, 0x8c, 0xe0, 0x25, 0xb8, 0x11, 0x5f, 0x06, 0xb3, 0x68, 0xbc,
+	0x5a, 0x06, 0xb3, 0x45, 0xc8, 0x9a, 0x08, 0xd0, 0x9a, 0x04, 0xd1, 0x2c, 0x1c, 0x33, 0x0b, 0x29,
+	0x38, 0xcf, 0x01, 0x8f, 0x9e, 0x98, 0x3d, 0x7a, 0x80, 0x76, 0x68, 0xce, 0x8c, 0x77, 0x60, 0x89,
+	0x32, 0xc1, 0x5e, 0xa5, 0x3d, 0x5f, 0xf9, 0xba, 0x5b, 0xb3, 0x8a, 0x4f, 0x7e, 0x63, 0xd3, 0xd2,
+	0xdf, 0xf1, 0xf8, 0x33, 0x00, 0x0d, 0x58, 0xb2, 0x49, 0x9f, 0x01, 0x00, 0x00,
+}
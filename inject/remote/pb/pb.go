@@ -0,0 +1,3 @@
+//go:generate protoc -I. element.proto --go_out=plugins=grpc:.
+
+package pb
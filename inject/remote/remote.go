@@ -0,0 +1,92 @@
+// Package remote runs inject.Runners on remote machines. A Server exposes the
+// runners registered in an inject context as a gRPC element service, and a
+// Client adds runners to a local inject context that forward calls to a
+// Server. Values are exchanged as JSON objects.
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inject/remote/pb"
+)
+
+// An Error is an error reported by a remote element
+type Error struct {
+	Kind    pb.ErrorKind
+	Message string
+	Stack   string // Stack trace of the remote element, if any
+}
+
+// Error satisfies the error interface
+func (a *Error) Error() string {
+	if a.Stack == "" {
+		return fmt.Sprintf("remote element: %s", a.Message)
+	}
+	return fmt.Sprintf("remote element: %s\n%s", a.Message, a.Stack)
+}
+
+func newError(kind pb.ErrorKind, err error) *pb.Error {
+	return &pb.Error{
+		Kind:    kind,
+		Message: err.Error(),
+	}
+}
+
+func fromProto(e *pb.Error) *Error {
+	return &Error{
+		Kind:    e.Kind,
+		Message: e.Message,
+		Stack:   e.Stack,
+	}
+}
+
+func encodeValue(value inject.Value) ([]byte, error) {
+	if value == nil {
+		value = make(inject.Value)
+	}
+	return json.Marshal(value)
+}
+
+// decodeValue decodes a JSON object into a Value. If example is not nil, each
+// parameter is decoded into a value of the type of the same field of example;
+// otherwise, parameters are decoded into generic JSON values.
+func decodeValue(data []byte, example interface{}) (inject.Value, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	var types map[string]reflect.Type
+	if example != nil {
+		var err error
+		if types, err = inject.FieldTypes(example); err != nil {
+			return nil, err
+		}
+	}
+
+	value := make(inject.Value)
+	for name, data := range raw {
+		if types == nil {
+			var v interface{}
+			if err := json.Unmarshal(data, &v); err != nil {
+				return nil, fmt.Errorf("cannot decode parameter %q: %s", name, err)
+			}
+			value[name] = v
+			continue
+		}
+
+		typ, ok := types[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q", name)
+		}
+		v := reflect.New(typ)
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, fmt.Errorf("cannot decode parameter %q: %s", name, err)
+		}
+		value[name] = v.Elem().Interface()
+	}
+	return value, nil
+}
@@ -0,0 +1,166 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inject/remote/pb"
+	"google.golang.org/grpc"
+)
+
+type addInput struct {
+	X, Y int
+}
+
+type addOutput struct {
+	Sum int
+}
+
+// serve starts a server on localhost for the runners in ctx and returns a
+// client connected to it
+func serve(t *testing.T, ctx context.Context) (*Client, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	NewServer(ctx).Register(s)
+	go s.Serve(lis) // nolint: errcheck
+
+	c, err := Dial(lis.Addr().String())
+	if err != nil {
+		s.Stop()
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close() // nolint: errcheck
+		s.Stop()
+	}
+}
+
+func makeServerContext(t *testing.T) context.Context {
+	ctx := inject.NewContext(context.Background())
+	if err := inject.Add(ctx, inject.Name{Repo: "add", Tag: "1.0.0"}, &inject.CheckedRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			var in addInput
+			if err := inject.Assign(value, &in); err != nil {
+				return nil, err
+			}
+			return inject.MakeValue(addOutput{Sum: in.X + in.Y}), nil
+		},
+		In:  &addInput{},
+		Out: &addOutput{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "fail"}, &inject.FuncRunner{
+		RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+			return nil, errors.New("failed")
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "panic"}, &inject.FuncRunner{
+		RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+			panic("panicked")
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return ctx
+}
+
+func TestRun(t *testing.T) {
+	c, stop := serve(t, makeServerContext(t))
+	defer stop()
+
+	ctx := inject.NewContext(context.Background())
+	name := inject.Name{Repo: "add", Tag: "1.0.0"}
+	if err := c.Add(ctx, name, &addInput{}, &addOutput{}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := inject.Call(ctx, inject.NameQuery{Repo: "add", Tag: "^1.0.0"}, inject.MakeValue(addInput{X: 1, Y: 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output addOutput
+	if err := inject.Assign(out, &output); err != nil {
+		t.Fatal(err)
+	} else if output.Sum != 3 {
+		t.Errorf("expecting %d but got %d", 3, output.Sum)
+	}
+
+	// Untyped runners decode generic JSON values
+	out, err = c.Runner(inject.NameQuery{Repo: "add"}, nil).Run(ctx, inject.Value{"X": 2, "Y": 2})
+	if err != nil {
+		t.Fatal(err)
+	} else if sum, ok := out["Sum"].(float64); !ok || sum != 4 {
+		t.Errorf("expecting %d but got %v", 4, out["Sum"])
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	c, stop := serve(t, makeServerContext(t))
+	defer stop()
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		Query inject.NameQuery
+		Value inject.Value
+		Kind  pb.ErrorKind
+	}{
+		{Query: inject.NameQuery{Repo: "missing"}, Kind: pb.ErrorKind_NOT_FOUND},
+		{Query: inject.NameQuery{Repo: "add", Tag: "2.0.0"}, Kind: pb.ErrorKind_NOT_FOUND},
+		{Query: inject.NameQuery{Repo: "add"}, Value: inject.Value{"X": "one"}, Kind: pb.ErrorKind_INVALID_VALUE},
+		{Query: inject.NameQuery{Repo: "add"}, Value: inject.Value{"Z": 1}, Kind: pb.ErrorKind_INVALID_VALUE},
+		{Query: inject.NameQuery{Repo: "fail"}, Kind: pb.ErrorKind_FAILED},
+		{Query: inject.NameQuery{Repo: "panic"}, Kind: pb.ErrorKind_PANIC},
+	} {
+		_, err := c.Runner(test.Query, nil).Run(ctx, test.Value)
+		if rerr, ok := err.(*Error); !ok {
+			t.Errorf("%s: expecting remote error but got %v", test.Query.Repo, err)
+		} else if rerr.Kind != test.Kind {
+			t.Errorf("%s: expecting %s but got %s: %s", test.Query.Repo, test.Kind, rerr.Kind, rerr)
+		} else if test.Kind == pb.ErrorKind_PANIC && rerr.Stack == "" {
+			t.Errorf("%s: expecting stack trace", test.Query.Repo)
+		}
+	}
+}
+
+func TestFindByHost(t *testing.T) {
+	c, stop := serve(t, makeServerContext(t))
+	defer stop()
+
+	ctx := inject.NewContext(context.Background())
+	if err := inject.Add(ctx, inject.Name{Repo: "add", Tag: "1.0.0"}, &inject.FuncRunner{
+		RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+			return nil, errors.New("run locally")
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(ctx, inject.Name{Repo: "add", Tag: "1.0.0"}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	name, runner, err := inject.Lookup(ctx, inject.NameQuery{Host: c.host, Repo: "add"})
+	if err != nil {
+		t.Fatal(err)
+	} else if name.Host != c.host {
+		t.Errorf("expecting host %q but got %q", c.host, name.Host)
+	}
+	out, err := runner.Run(ctx, inject.Value{"X": 1, "Y": 1})
+	if err != nil {
+		t.Fatal(err)
+	} else if sum, ok := out["Sum"].(float64); !ok || sum != 2 {
+		t.Errorf("expecting %d but got %v", 2, out["Sum"])
+	}
+
+	if _, err := inject.Find(ctx, inject.NameQuery{Host: "elsewhere", Repo: "add"}); err == nil {
+		t.Error("expecting error finding runner of unknown host")
+	}
+}
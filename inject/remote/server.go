@@ -0,0 +1,98 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/inject/remote/pb"
+	"google.golang.org/grpc"
+)
+
+// A Server exposes the runners registered in an inject context as a gRPC
+// element service
+type Server struct {
+	ctx context.Context
+}
+
+// NewServer returns a server for the runners registered in ctx. Runners are
+// run with the values of ctx, e.g., inventory, but are cancelled with their
+// request.
+func NewServer(ctx context.Context) *Server {
+	return &Server{ctx: ctx}
+}
+
+// Register registers the element service with a gRPC server
+func (a *Server) Register(s *grpc.Server) {
+	pb.RegisterElementServer(s, a)
+}
+
+// requestContext has the deadline and cancellation of a request and the
+// values of the server context
+type requestContext struct {
+	context.Context
+	values context.Context
+}
+
+func (a *requestContext) Value(key interface{}) interface{} {
+	return a.values.Value(key)
+}
+
+func run(ctx context.Context, runner inject.Runner, value inject.Value) (out inject.Value, perr *pb.Error) {
+	defer func() {
+		if res := recover(); res != nil {
+			out = nil
+			perr = &pb.Error{
+				Kind:    pb.ErrorKind_PANIC,
+				Message: fmt.Sprint(res),
+				Stack:   inject.ElementStackTrace(),
+			}
+		}
+	}()
+
+	out, err := runner.Run(ctx, value)
+	if err != nil {
+		return nil, newError(pb.ErrorKind_FAILED, err)
+	}
+	return out, nil
+}
+
+// Run implements the element service. Failures of the element are reported
+// in the reply; the returned error is only for failures of the service.
+func (a *Server) Run(ctx context.Context, req *pb.RunRequest) (*pb.RunReply, error) {
+	runner, err := inject.Find(a.ctx, inject.NameQuery{
+		Repo:  req.Repo,
+		Tag:   req.Tag,
+		Stage: api.ElementStage(req.Stage),
+	})
+	if err != nil {
+		return &pb.RunReply{
+			Error: newError(pb.ErrorKind_NOT_FOUND, fmt.Errorf("cannot find element %q version %q: %s", req.Repo, req.Tag, err)),
+		}, nil
+	}
+
+	var in interface{}
+	if tr, ok := runner.(inject.TypedRunner); ok {
+		in = tr.Input()
+	}
+	value, err := decodeValue(req.Value, in)
+	if err != nil {
+		return &pb.RunReply{
+			Error: newError(pb.ErrorKind_INVALID_VALUE, fmt.Errorf("cannot decode input: %s", err)),
+		}, nil
+	}
+
+	out, perr := run(&requestContext{Context: ctx, values: a.ctx}, runner, value)
+	if perr != nil {
+		return &pb.RunReply{Error: perr}, nil
+	}
+
+	data, err := encodeValue(out)
+	if err != nil {
+		return &pb.RunReply{
+			Error: newError(pb.ErrorKind_INVALID_VALUE, fmt.Errorf("cannot encode output: %s", err)),
+		}, nil
+	}
+	return &pb.RunReply{Value: data}, nil
+}
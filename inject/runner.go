@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"context"
+
+	api "github.com/antha-lang/antha/api/v1"
 )
 
 // RunFunc is the signature of injectable functions
@@ -32,12 +34,25 @@ type TypedRunner interface {
 	Output() interface{}
 }
 
+// A MetadataRunner is an injectable function that describes its source
+type MetadataRunner interface {
+	Runner
+	ElementMetadata() *api.ElementMetadata
+}
+
 // A CheckedRunner is a typed injectable function. It checks if input parameter
 // is assignable to In and output parameter is assignable to Out.
 type CheckedRunner struct {
 	RunFunc
-	In  interface{}
-	Out interface{}
+	In   interface{}
+	Out  interface{}
+	Meta *api.ElementMetadata // Metadata of element, if any
+}
+
+// ElementMetadata returns the metadata of the element run by this Runner or
+// nil if there is none
+func (a *CheckedRunner) ElementMetadata() *api.ElementMetadata {
+	return a.Meta
 }
 
 // Input returns an example of an input to this Runner
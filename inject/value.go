@@ -180,6 +180,22 @@ func assign(from, to interface{}, set, ignoreMissing bool) error {
 	return nil
 }
 
+// FieldTypes returns the type of each field of a Value or struct. Fields of
+// interface type with a concrete value have the type of that value.
+func FieldTypes(x interface{}) (map[string]reflect.Type, error) {
+	fields, err := makeFields(reflect.ValueOf(x))
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]reflect.Type)
+	for name, v := range fields {
+		if v.IsValid() {
+			types[name] = v.Type()
+		}
+	}
+	return types, nil
+}
+
 // AssignableTo returns if src is assignable to dst. Typing rule is as follows:
 // (1) every field of src must have a field of the same name in dst, (2) the
 // type of the src field must be golang assignable to the dst field, and (3)
@@ -0,0 +1,202 @@
+package inject
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Version is a semantic version, e.g., 1.2.3 or 1.2.3-beta.1
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // Pre-release identifiers, if any
+}
+
+// ParseVersion parses a semantic version. A leading "v" is ignored, as are
+// missing minor and patch numbers, e.g., "v1" is 1.0.0. Build metadata
+// (anything after a "+") is ignored.
+func ParseVersion(s string) (*Version, error) {
+	v := strings.TrimPrefix(s, "v")
+	if idx := strings.Index(v, "+"); idx >= 0 {
+		v = v[:idx]
+	}
+	var r Version
+	if idx := strings.Index(v, "-"); idx >= 0 {
+		r.Pre = v[idx+1:]
+		v = v[:idx]
+		if r.Pre == "" {
+			return nil, fmt.Errorf("invalid version %q: empty pre-release", s)
+		}
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version %q: too many components", s)
+	}
+	nums := []*int{&r.Major, &r.Minor, &r.Patch}
+	for idx, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q: bad number %q", s, part)
+		}
+		*nums[idx] = n
+	}
+	return &r, nil
+}
+
+// String returns the canonical form of a version
+func (a Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", a.Major, a.Minor, a.Patch)
+	if a.Pre != "" {
+		s += "-" + a.Pre
+	}
+	return s
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePre compares pre-release identifiers by semantic versioning
+// precedence. A version without pre-release identifiers has higher precedence
+// than one with.
+func comparePre(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for idx := 0; idx < len(as) && idx < len(bs); idx++ {
+		an, aErr := strconv.Atoi(as[idx])
+		bn, bErr := strconv.Atoi(bs[idx])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(an, bn)
+		case aErr == nil:
+			c = -1 // Numeric identifiers have lower precedence
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[idx], bs[idx])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(as), len(bs))
+}
+
+// Compare returns -1, 0 or 1 if a has lower, the same or higher precedence
+// than b
+func (a Version) Compare(b Version) int {
+	if c := compareInts(a.Major, b.Major); c != 0 {
+		return c
+	} else if c := compareInts(a.Minor, b.Minor); c != 0 {
+		return c
+	} else if c := compareInts(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	return comparePre(a.Pre, b.Pre)
+}
+
+// A Constraint restricts the versions of a runner that satisfy a NameQuery.
+// Constraints are given as the Tag of a NameQuery:
+//
+//   ""        or "latest": the highest released version
+//   "1.2.3"   or "=1.2.3": exactly 1.2.3
+//   "^1.2.3":             at least 1.2.3 but less than 2.0.0
+//   "^0.2.3":             at least 0.2.3 but less than 0.3.0
+//   "^0.0.3":             exactly 0.0.3, i.e., less than 0.0.4
+//   "~1.2.3":             at least 1.2.3 but less than 1.3.0
+//
+// Tags that are not semantic versions only match exactly. Pre-release versions
+// only match exact constraints.
+type Constraint struct {
+	tag      string   // Original tag
+	min, max *Version // Bounds of range, inclusive and exclusive respectively
+	exact    bool
+}
+
+// ParseConstraint parses a version constraint
+func ParseConstraint(tag string) (*Constraint, error) {
+	c := &Constraint{tag: tag}
+	switch {
+	case tag == "" || tag == "latest":
+		return c, nil
+	case strings.HasPrefix(tag, "^"), strings.HasPrefix(tag, "~"):
+		min, err := ParseVersion(tag[1:])
+		if err != nil {
+			return nil, err
+		}
+		max := &Version{Major: min.Major + 1}
+		if tag[0] == '^' && min.Major == 0 && min.Minor == 0 {
+			max = &Version{Patch: min.Patch + 1}
+		} else if tag[0] == '~' || min.Major == 0 {
+			max = &Version{Major: min.Major, Minor: min.Minor + 1}
+		}
+		c.min, c.max = min, max
+		return c, nil
+	}
+
+	c.exact = true
+	if v, err := ParseVersion(strings.TrimPrefix(tag, "=")); err == nil {
+		c.min = v
+	} else if strings.HasPrefix(tag, "=") {
+		return nil, err
+	}
+	return c, nil
+}
+
+// String returns the original form of the constraint
+func (a *Constraint) String() string {
+	return a.tag
+}
+
+// Match returns if a tag satisfies the constraint
+func (a *Constraint) Match(tag string) bool {
+	v, err := ParseVersion(tag)
+	switch {
+	case a.exact && a.min == nil:
+		return tag == a.tag
+	case a.exact:
+		return err == nil && v.Compare(*a.min) == 0
+	case err != nil:
+		// Unversioned runners satisfy the latest constraint but have lower
+		// precedence than any versioned runner (see LessTag)
+		return a.min == nil && tag == ""
+	case v.Pre != "":
+		return false
+	case a.min == nil:
+		return true
+	}
+	return v.Compare(*a.min) >= 0 && v.Compare(*a.max) < 0
+}
+
+// LessTag returns if tag a has lower precedence than tag b. Semantic versions
+// are ordered by precedence and have higher precedence than other tags, which
+// are ordered lexically.
+func LessTag(a, b string) bool {
+	av, aErr := ParseVersion(a)
+	bv, bErr := ParseVersion(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return av.Compare(*bv) < 0
+	case aErr == nil:
+		return false
+	case bErr == nil:
+		return true
+	}
+	return a < b
+}
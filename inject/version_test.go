@@ -0,0 +1,153 @@
+package inject
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for s, golden := range map[string]Version{
+		"1.2.3":         {Major: 1, Minor: 2, Patch: 3},
+		"v1.2.3":        {Major: 1, Minor: 2, Patch: 3},
+		"1":             {Major: 1},
+		"1.2":           {Major: 1, Minor: 2},
+		"1.2.3-beta.1":  {Major: 1, Minor: 2, Patch: 3, Pre: "beta.1"},
+		"1.2.3+build.5": {Major: 1, Minor: 2, Patch: 3},
+	} {
+		if v, err := ParseVersion(s); err != nil {
+			t.Errorf("%q: %s", s, err)
+		} else if *v != golden {
+			t.Errorf("%q: expecting %s but got %s", s, golden, v)
+		}
+	}
+
+	for _, s := range []string{"", "latest", "1.2.3.4", "1.x", "1.2.3-", "-1.0.0"} {
+		if _, err := ParseVersion(s); err == nil {
+			t.Errorf("%q: expecting error", s)
+		}
+	}
+}
+
+func TestCompareVersion(t *testing.T) {
+	// In increasing order of precedence
+	vs := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1",
+		"1.1.0", "2.0.0",
+	}
+	for i := range vs {
+		for j := range vs {
+			a, _ := ParseVersion(vs[i])
+			b, _ := ParseVersion(vs[j])
+			golden := compareInts(i, j)
+			if c := a.Compare(*b); c != golden {
+				t.Errorf("%s compared to %s: expecting %d but got %d", vs[i], vs[j], golden, c)
+			}
+		}
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	type match struct {
+		Tag   string
+		Match bool
+	}
+	for tag, matches := range map[string][]match{
+		"": {
+			{"1.2.3", true}, {"", true}, {"1.2.3-beta", false}, {"dev", false},
+		},
+		"latest": {
+			{"1.2.3", true}, {"", true},
+		},
+		"1.2.3": {
+			{"1.2.3", true}, {"v1.2.3", true}, {"1.2.4", false}, {"", false},
+		},
+		"=1.2": {
+			{"1.2.0", true}, {"1.2.1", false},
+		},
+		"dev": {
+			{"dev", true}, {"", false}, {"1.0.0", false},
+		},
+		"^1.2.3": {
+			{"1.2.3", true}, {"1.9.0", true}, {"1.2.2", false}, {"2.0.0", false}, {"1.3.0-rc.1", false},
+		},
+		"^0.2.3": {
+			{"0.2.9", true}, {"0.3.0", false},
+		},
+		"^0.0.3": {
+			{"0.0.3", true}, {"0.0.4", false}, {"0.1.0", false},
+		},
+		"~1.2.3": {
+			{"1.2.9", true}, {"1.3.0", false}, {"1.2.0", false},
+		},
+	} {
+		c, err := ParseConstraint(tag)
+		if err != nil {
+			t.Fatalf("%q: %s", tag, err)
+		}
+		for _, m := range matches {
+			if c.Match(m.Tag) != m.Match {
+				t.Errorf("%q matching %q: expecting %t", tag, m.Tag, m.Match)
+			}
+		}
+	}
+
+	for _, tag := range []string{"^x", "~1.2.3.4", "=y"} {
+		if _, err := ParseConstraint(tag); err == nil {
+			t.Errorf("%q: expecting error", tag)
+		}
+	}
+}
+
+func TestFindVersion(t *testing.T) {
+	ctx := NewContext(context.Background())
+	add := func(ctx context.Context, tag string) {
+		if err := Add(ctx, Name{Repo: "elem", Tag: tag}, &FuncRunner{
+			RunFunc: func(context.Context, Value) (Value, error) {
+				return Value{"Tag": tag}, nil
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, tag := range []string{"1.0.0", "", "2.1.0", "1.4.2", "2.2.0-beta", "dev"} {
+		add(ctx, tag)
+	}
+	if err := Add(ctx, Name{Repo: "elem", Tag: "1.0.0"}, &FuncRunner{}); err != errAlreadyAdded {
+		t.Errorf("expecting %s but got %v", errAlreadyAdded, err)
+	}
+	inner := NewContext(ctx)
+	add(inner, "1.4.1")
+
+	// Runners in the inner context take precedence whatever their version
+	for tag, golden := range map[string]string{
+		"":           "1.4.1",
+		"latest":     "1.4.1",
+		"^1.0.0":     "1.4.1",
+		"~1.4.0":     "1.4.1",
+		"^2.0.0":     "2.1.0",
+		"1.0.0":      "1.0.0",
+		"2.2.0-beta": "2.2.0-beta",
+		"dev":        "dev",
+		"~1.3.0":     "",
+	} {
+		out, err := Call(inner, NameQuery{Repo: "elem", Tag: tag}, nil)
+		if golden == "" {
+			if err == nil {
+				t.Errorf("%q: expecting error but got %v", tag, out["Tag"])
+			}
+		} else if err != nil {
+			t.Errorf("%q: %s", tag, err)
+		} else if out["Tag"] != golden {
+			t.Errorf("%q: expecting %q but got %q", tag, golden, out["Tag"])
+		}
+	}
+
+	ctx = NewContext(context.Background())
+	add(ctx, "")
+	if out, err := Call(ctx, NameQuery{Repo: "elem"}, nil); err != nil {
+		t.Error(err)
+	} else if out["Tag"] != "" {
+		t.Errorf("expecting unversioned runner but got %q", out["Tag"])
+	}
+}
@@ -217,6 +217,9 @@ func (ins *ChannelBlockInstruction) Generate(ctx context.Context, policy *wtype.
 	var channels []*wtype.LHChannelParameter
 	var tiptypes []string
 
+	// transfers from the same source may share an aspirate
+	md := newMultiDispenser(pol, prms)
+
 	for t := 0; t < len(ins.Volume); t++ {
 		if len(ins.What[t]) == 0 {
 			continue
@@ -278,6 +281,8 @@ func (ins *ChannelBlockInstruction) Generate(ctx context.Context, policy *wtype.
 			}
 
 			if changeTips {
+				ret = append(ret, md.flush()...)
+
 				// drop the last tips if there are any loaded
 				if tiptypes != nil && channels != nil {
 					if tipdrp, err := DropTips(tiptypes, prms, channels); err != nil {
@@ -322,7 +327,7 @@ func (ins *ChannelBlockInstruction) Generate(ctx context.Context, policy *wtype.
 			}
 			mci.Prms = channelprms
 
-			ret = append(ret, mci)
+			ret = append(ret, md.add(mci)...)
 
 			tipUseCounter++
 			lastThing = thisThing
@@ -342,6 +347,8 @@ func (ins *ChannelBlockInstruction) Generate(ctx context.Context, policy *wtype.
 		}
 	}
 
+	ret = append(ret, md.flush()...)
+
 	// remove tips
 	tipdrp, err := DropTips(tiptypes, prms, channels)
 
@@ -1756,6 +1763,9 @@ type BlowInstruction struct {
 	Multi      int
 	TipType    string
 	Component  []string
+	// Partial is set when liquid remains in the tip to be dispensed
+	// elsewhere, in which case the tip is not reset afterwards
+	Partial bool
 }
 
 func NewBlowInstruction(cti *ChannelTransferInstruction) *BlowInstruction {
@@ -2285,7 +2295,7 @@ func (ins *BlowInstruction) Generate(ctx context.Context, policy *wtype.LHPolicy
 
 	overridereset := SafeGetBool(pol, "RESET_OVERRIDE")
 
-	if weneedtoreset && !overridereset {
+	if weneedtoreset && !overridereset && !ins.Partial {
 		resetinstruction := NewResetInstruction()
 
 		resetinstruction.AddMultiTransferParams(ins.Params())
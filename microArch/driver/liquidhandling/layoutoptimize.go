@@ -0,0 +1,259 @@
+package liquidhandling
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// defaultHeadSpeed is the speed of the head in mm/s assumed in X and Y when
+// the liquid handler does not specify velocity limits
+const defaultHeadSpeed = 100.0
+
+// TransferCounts counts the moves of the head between pairs of objects on the
+// deck, indexed by the ID of the object moved from and then moved to
+type TransferCounts map[string]map[string]int
+
+// Add counts a move from one object to another, moves within an object are
+// ignored
+func (tc TransferCounts) Add(from, to string, n int) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	if tc[from] == nil {
+		tc[from] = make(map[string]int)
+	}
+	tc[from][to] += n
+}
+
+type transferCount struct {
+	from, to string
+	n        int
+}
+
+// sorted returns the counts ordered by the objects moved from and to
+func (tc TransferCounts) sorted() []transferCount {
+	var ret []transferCount
+	for from, tos := range tc {
+		for to, n := range tos {
+			ret = append(ret, transferCount{from: from, to: to, n: n})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].from != ret[j].from {
+			return ret[i].from < ret[j].from
+		}
+		return ret[i].to < ret[j].to
+	})
+	return ret
+}
+
+// CountTransfers counts the moves of the head between objects on the deck made
+// by the instructions, which may be either low level moves or transfers
+func CountTransfers(props *LHProperties, instructions []TerminalRobotInstruction) TransferCounts {
+	tc := make(TransferCounts)
+
+	var last string
+	visit := func(id string) {
+		if id == "" {
+			return
+		}
+		tc.Add(last, id, 1)
+		last = id
+	}
+
+	for _, ins := range instructions {
+		ins.Visit(RobotInstructionBaseVisitor{
+			HandleMove: func(ins *MoveInstruction) {
+				// the channels move together, so the first is enough
+				for _, position := range ins.Pos {
+					if position != "" {
+						visit(props.PosLookup[position])
+						break
+					}
+				}
+			},
+			HandleTransfer: func(ins *TransferInstruction) {
+				for _, mtf := range ins.Transfers {
+					if len(mtf.Transfers) != 0 {
+						visit(props.PosLookup[mtf.Transfers[0].PltFrom])
+						visit(props.PosLookup[mtf.Transfers[0].PltTo])
+					}
+				}
+			},
+		})
+	}
+
+	return tc
+}
+
+// A LayoutOptimization is the result of optimizing the positions of plates on
+// the deck
+type LayoutOptimization struct {
+	Before time.Duration     // estimated time moving between objects in the original layout
+	After  time.Duration     // estimated time moving between objects in the optimized layout
+	Moved  map[string]string // the new position of each plate which was moved, by plate ID
+}
+
+// Saving returns the estimated time saved by the optimized layout
+func (lo *LayoutOptimization) Saving() time.Duration {
+	return lo.Before - lo.After
+}
+
+// headSpeed returns the speed of the slowest head in X and Y in mm/s
+func (lhp *LHProperties) headSpeed() (x, y float64) {
+	x, y = math.Inf(1), math.Inf(1)
+	for _, ha := range lhp.HeadAssemblies {
+		if ha == nil || ha.VelocityLimits == nil || ha.VelocityLimits.Max == nil {
+			continue
+		}
+		max := ha.VelocityLimits.Max
+		if max.X.ConcreteMeasurement != nil && max.X.RawValue() > 0.0 {
+			x = math.Min(x, max.X.ConvertToString("mm/s"))
+		}
+		if max.Y.ConcreteMeasurement != nil && max.Y.RawValue() > 0.0 {
+			y = math.Min(y, max.Y.ConvertToString("mm/s"))
+		}
+	}
+	if math.IsInf(x, 1) {
+		x = defaultHeadSpeed
+	}
+	if math.IsInf(y, 1) {
+		y = defaultHeadSpeed
+	}
+	return x, y
+}
+
+// travelTime estimates the time the head spends moving between objects at
+// the given positions, indexed by object ID. The axes move independently so
+// each move takes as long as the slower of them.
+func (lhp *LHProperties) travelTime(counts TransferCounts, positionOf map[string]string) time.Duration {
+	vx, vy := lhp.headSpeed()
+
+	centre := func(id string) (wtype.Coordinates3D, bool) {
+		pos, ok := lhp.Positions[positionOf[id]]
+		if !ok {
+			return wtype.Coordinates3D{}, false
+		}
+		return pos.Location.Add(wtype.Coordinates3D{X: pos.Size.X / 2.0, Y: pos.Size.Y / 2.0}), true
+	}
+
+	// sum in a fixed order so that the estimate is always the same
+	var seconds float64
+	for _, tc := range counts.sorted() {
+		cf, okf := centre(tc.from)
+		ct, okt := centre(tc.to)
+		if !okf || !okt {
+			continue
+		}
+		d := ct.Subtract(cf)
+		seconds += float64(tc.n) * math.Max(math.Abs(d.X)/vx, math.Abs(d.Y)/vy)
+	}
+
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// OptimizeLayout permutes the positions of plates on the deck to minimize the
+// estimated time the head spends moving between objects, given the number of
+// moves between each pair of them. Only the plates listed in allowed are
+// moved, and each only to the positions listed for it; everything else on the
+// deck, including plates pinned by the user, stays where it is.
+//
+// The search repeatedly moves a plate to an empty position, or swaps it with
+// another plate, whenever that reduces the estimated time, until no such
+// change helps. Plates and positions are considered in a fixed order so the
+// result is deterministic.
+func (lhp *LHProperties) OptimizeLayout(counts TransferCounts, allowed map[string]Addresses) (*LayoutOptimization, error) {
+	positionOf := make(map[string]string, len(lhp.PlateIDLookup))
+	occupant := make(map[string]string, len(lhp.PlateIDLookup))
+	for id, pos := range lhp.PlateIDLookup {
+		positionOf[id] = pos
+		occupant[pos] = id
+	}
+
+	movable := make([]string, 0, len(allowed))
+	canMoveTo := make(map[string]map[string]bool, len(allowed))
+	for id, addresses := range allowed {
+		if _, ok := lhp.PlateIDLookup[id]; !ok {
+			return nil, wtype.LHErrorf(wtype.LH_ERR_DIRE, "while optimizing layout: no plate with id %s on deck", id)
+		} else if _, ok := lhp.PlateLookup[id].(*wtype.Plate); !ok {
+			return nil, wtype.LHErrorf(wtype.LH_ERR_DIRE, "while optimizing layout: object with id %s is not a plate", id)
+		}
+		movable = append(movable, id)
+		canMoveTo[id] = addresses.Map()
+	}
+	sort.Strings(movable)
+
+	swap := func(id, pos string) {
+		from := positionOf[id]
+		other, occupied := occupant[pos]
+		positionOf[id] = pos
+		occupant[pos] = id
+		if occupied {
+			positionOf[other] = from
+			occupant[from] = other
+		} else {
+			delete(occupant, from)
+		}
+	}
+
+	ret := &LayoutOptimization{
+		Before: lhp.travelTime(counts, positionOf),
+		Moved:  make(map[string]string),
+	}
+	best := ret.Before
+
+	for improved := true; improved; {
+		improved = false
+		for _, id := range movable {
+			for _, pos := range allowed[id] {
+				from := positionOf[id]
+				if pos == from {
+					continue
+				}
+				if _, ok := lhp.Positions[pos]; !ok {
+					continue
+				}
+				if other, occupied := occupant[pos]; occupied && !canMoveTo[other][from] {
+					continue
+				}
+
+				swap(id, pos)
+				if t := lhp.travelTime(counts, positionOf); t < best {
+					best = t
+					improved = true
+				} else {
+					// swapping back restores both plates
+					swap(id, from)
+				}
+			}
+		}
+	}
+	ret.After = best
+
+	// take all the moved plates off the deck before putting any back so
+	// that they don't collide
+	plates := make(map[string]*wtype.Plate)
+	for _, id := range movable {
+		if positionOf[id] != lhp.PlateIDLookup[id] {
+			plates[id] = lhp.PlateLookup[id].(*wtype.Plate)
+			ret.Moved[id] = positionOf[id]
+		}
+	}
+	for _, id := range movable {
+		if _, ok := plates[id]; ok {
+			lhp.RemovePlateWithID(id)
+		}
+	}
+	for _, id := range movable {
+		if plate, ok := plates[id]; ok {
+			if err := lhp.AddPlateTo(positionOf[id], plate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ret, nil
+}
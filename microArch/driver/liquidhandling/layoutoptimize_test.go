@@ -0,0 +1,78 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/inventory"
+	"github.com/antha-lang/antha/inventory/testinventory"
+)
+
+func TestOptimizeLayout(t *testing.T) {
+	ctx := testinventory.NewContext(context.Background())
+	lhp := MakeGilsonForTest(defaultTipList())
+
+	plates := make(map[string]*wtype.Plate)
+	for _, pos := range []string{"position_1", "position_9", "position_4"} {
+		p, err := inventory.NewPlate(ctx, "pcrplate_skirted")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := lhp.AddPlateTo(pos, p); err != nil {
+			t.Fatal(err)
+		}
+		plates[pos] = p
+	}
+	// the source is pinned while the busy plate starts at the far corner
+	source, busy, idle := plates["position_1"], plates["position_9"], plates["position_4"]
+
+	var transfers []MultiTransferParams
+	for i := 0; i < 10; i++ {
+		transfers = append(transfers, MultiTransferParams{Transfers: []TransferParams{{
+			PltFrom:  "position_1",
+			WellFrom: "A1",
+			PltTo:    "position_9",
+			WellTo:   "A1",
+			Volume:   wunit.NewVolume(10.0, "ul"),
+		}}})
+	}
+	counts := CountTransfers(lhp, []TerminalRobotInstruction{&TransferInstruction{Transfers: transfers}})
+
+	if n := counts[source.ID][busy.ID]; n != 10 {
+		t.Errorf("expected 10 moves from source to busy plate, got %d", n)
+	}
+	if n := counts[busy.ID][source.ID]; n != 9 {
+		t.Errorf("expected 9 moves from busy plate to source, got %d", n)
+	}
+
+	allowed := map[string]Addresses{
+		busy.ID: {"position_9", "position_5", "position_4"},
+		idle.ID: {"position_4", "position_5", "position_9"},
+	}
+	opt, err := lhp.OptimizeLayout(counts, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pos := opt.Moved[busy.ID]; pos != "position_4" {
+		t.Errorf("expected busy plate to move next to the source at position_4, got %q", pos)
+	}
+	if _, ok := opt.Moved[source.ID]; ok || lhp.PosLookup["position_1"] != source.ID {
+		t.Error("pinned source plate was moved")
+	}
+	if lhp.PosLookup["position_4"] != busy.ID || lhp.PlateIDLookup[busy.ID] != "position_4" {
+		t.Error("busy plate not moved on deck")
+	}
+	if opt.After >= opt.Before || opt.Saving() != opt.Before-opt.After {
+		t.Errorf("expected a saving, estimated %s before and %s after", opt.Before, opt.After)
+	}
+
+	// nothing more to gain from the optimized layout
+	if again, err := lhp.OptimizeLayout(counts, allowed); err != nil {
+		t.Fatal(err)
+	} else if len(again.Moved) != 0 || again.Before != opt.After {
+		t.Errorf("expected optimized layout to be kept, got %v", again.Moved)
+	}
+}
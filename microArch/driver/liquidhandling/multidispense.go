@@ -0,0 +1,184 @@
+package liquidhandling
+
+import (
+	"reflect"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// multiDispenser collects consecutive transfers from the same source which
+// can be made with a single aspirate, as permitted by the CAN_MULTI_DISPENSE
+// policy
+type multiDispenser struct {
+	enabled bool
+	// volume returned to the source straight after aspirating
+	conditioning wunit.Volume
+	// volume left in the tip after the last dispense
+	excess wunit.Volume
+	// volume added to each aspirate by the policy
+	extra   wunit.Volume
+	pending []*ChannelTransferInstruction
+	// the robot, used to find the residual volumes of source wells
+	robot *LHProperties
+}
+
+func newMultiDispenser(pol wtype.LHPolicy, robot *LHProperties) *multiDispenser {
+	return &multiDispenser{
+		robot: robot,
+		// blowing out empties the tip so each dispense must be its own aspirate
+		enabled:      SafeGetBool(pol, "CAN_MULTI_DISPENSE") && !SafeGetBool(pol, "JUSTBLOWOUT"),
+		conditioning: SafeGetVolume(pol, "MULTI_DSP_CONDITIONING_VOL"),
+		excess:       SafeGetVolume(pol, "MULTI_DSP_EXCESS_VOLUME"),
+		extra:        SafeGetVolume(pol, "EXTRA_ASP_VOLUME"),
+	}
+}
+
+// add a transfer, returning any instructions which can no longer be
+// combined with it
+func (md *multiDispenser) add(cti *ChannelTransferInstruction) []RobotInstruction {
+	if !md.enabled {
+		return []RobotInstruction{cti}
+	}
+	var ret []RobotInstruction
+	if !md.canJoin(cti) {
+		ret = md.flush()
+	}
+	md.pending = append(md.pending, cti)
+	return ret
+}
+
+// canJoin returns true if the transfer can share an aspirate with those
+// pending: the same liquid must come from the same wells on the same
+// channels, the tips must be able to hold it all and the source must hold it
+// all above its residual volume. The conditioning and excess volumes are
+// returned to the source but are drawn from it along with the rest.
+func (md *multiDispenser) canJoin(cti *ChannelTransferInstruction) bool {
+	if len(md.pending) == 0 {
+		return true
+	}
+	first := md.pending[0]
+	channels := first.Channels()
+	if !reflect.DeepEqual(channels, cti.Channels()) {
+		return false
+	}
+	for _, i := range channels {
+		if cti.What[i] != first.What[i] || cti.PltFrom[i] != first.PltFrom[i] || cti.WellFrom[i] != first.WellFrom[i] || cti.TipType[i] != first.TipType[i] {
+			return false
+		}
+		total := wunit.AddVolumes(cti.Volume[i], md.conditioning, md.excess, md.extra)
+		for _, p := range md.pending {
+			total = wunit.AddVolumes(total, p.Volume[i])
+		}
+		if cti.Prms[i] == nil || total.GreaterThan(cti.Prms[i].Maxvol) {
+			return false
+		}
+		if total.GreaterThan(md.available(first, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// available returns the volume which can be aspirated on channel i from the
+// source of a transfer, as it was before the transfer was made
+func (md *multiDispenser) available(cti *ChannelTransferInstruction, i int) wunit.Volume {
+	ret := wunit.CopyVolume(cti.FVolume[i])
+	if plate, ok := md.robot.Plates[cti.PltFrom[i]]; ok {
+		if well, ok := plate.Wellcoords[cti.WellFrom[i]]; ok {
+			ret.Subtract(well.ResidualVolume())
+		}
+	}
+	return ret
+}
+
+// flush returns the instructions for the pending transfers. A single
+// transfer is made as usual while several are made by aspirating all of
+// their volume, plus the conditioning and excess volumes, and dispensing to
+// each destination in turn.
+func (md *multiDispenser) flush() []RobotInstruction {
+	pending := md.pending
+	md.pending = nil
+
+	switch len(pending) {
+	case 0:
+		return nil
+	case 1:
+		return []RobotInstruction{pending[0]}
+	}
+
+	first := pending[0]
+	channels := first.Channels()
+
+	asp := first.dup()
+	for _, i := range channels {
+		for _, cti := range pending[1:] {
+			asp.Volume[i].Add(cti.Volume[i])
+		}
+		asp.Volume[i].Add(md.conditioning)
+		asp.Volume[i].Add(md.excess)
+	}
+	ret := []RobotInstruction{NewSuckInstruction(asp)}
+
+	// follow the volume in the source so that liquid is returned at the
+	// right height
+	source := VolumeSet(first.FVolume).GetACopy()
+	for _, i := range channels {
+		source[i].Subtract(asp.Volume[i])
+	}
+
+	toSource := func(v wunit.Volume) *BlowInstruction {
+		cti := first.dup()
+		cti.PltTo = cti.PltFrom
+		cti.WellTo = cti.WellFrom
+		cti.TPlateType = cti.FPlateType
+		cti.TVolume = source.GetACopy()
+		for _, i := range channels {
+			cti.Volume[i] = wunit.CopyVolume(v)
+		}
+		return NewBlowInstruction(cti)
+	}
+
+	if md.conditioning.IsPositive() {
+		blow := toSource(md.conditioning)
+		blow.Partial = true
+		ret = append(ret, blow)
+		for _, i := range channels {
+			source[i].Add(md.conditioning)
+		}
+	}
+
+	for _, cti := range pending {
+		blow := NewBlowInstruction(cti)
+		blow.Partial = true
+		ret = append(ret, blow)
+	}
+
+	if md.excess.IsPositive() {
+		ret = append(ret, toSource(md.excess))
+	} else {
+		ret[len(ret)-1].(*BlowInstruction).Partial = false
+	}
+
+	return ret
+}
+
+// dup returns a copy of the instruction with its own volumes
+func (ins *ChannelTransferInstruction) dup() *ChannelTransferInstruction {
+	ret := NewChannelTransferInstruction()
+	ret.What = append(ret.What, ins.What...)
+	ret.PltFrom = append(ret.PltFrom, ins.PltFrom...)
+	ret.PltTo = append(ret.PltTo, ins.PltTo...)
+	ret.WellFrom = append(ret.WellFrom, ins.WellFrom...)
+	ret.WellTo = append(ret.WellTo, ins.WellTo...)
+	ret.Volume = VolumeSet(ins.Volume).GetACopy()
+	ret.FPlateType = append(ret.FPlateType, ins.FPlateType...)
+	ret.TPlateType = append(ret.TPlateType, ins.TPlateType...)
+	ret.FVolume = VolumeSet(ins.FVolume).GetACopy()
+	ret.TVolume = VolumeSet(ins.TVolume).GetACopy()
+	ret.Multi = ins.Multi
+	ret.Prms = append(ret.Prms, ins.Prms...)
+	ret.TipType = append(ret.TipType, ins.TipType...)
+	ret.Component = append(ret.Component, ins.Component...)
+	return ret
+}
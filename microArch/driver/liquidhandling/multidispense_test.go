@@ -0,0 +1,142 @@
+package liquidhandling
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// getMultiDispenseRobot returns a robot with a source plate at position_1
+// whose wells have a residual volume of 5ul
+func getMultiDispenseRobot() *LHProperties {
+	shape := wtype.NewShape(wtype.CylinderShape, "mm", 5.5, 5.5, 20.4)
+	welltype := wtype.NewLHWell("ul", 200, 5, shape, wtype.UWellBottom, 5.5, 5.5, 20.4, 1.4, "mm")
+	plate := wtype.NewLHPlate("pcrplate", "Unknown", 8, 12, wtype.Coordinates3D{X: 127.76, Y: 85.48, Z: 25.7}, welltype, 9, 9, 0.0, 0.0, 38.5)
+	return &LHProperties{
+		Plates: map[string]*wtype.Plate{"position_1": plate},
+	}
+}
+
+func getMultiDispenseTransfer(ch *wtype.LHChannelParameter, from, to string, volume, source float64) *ChannelTransferInstruction {
+	cti := NewChannelTransferInstruction()
+	cti.What = []string{"water"}
+	cti.PltFrom = []string{"position_1"}
+	cti.WellFrom = []string{from}
+	cti.PltTo = []string{"position_2"}
+	cti.WellTo = []string{to}
+	cti.FPlateType = []string{"pcrplate"}
+	cti.TPlateType = []string{"pcrplate"}
+	cti.Volume = []wunit.Volume{wunit.NewVolume(volume, "ul")}
+	cti.FVolume = []wunit.Volume{wunit.NewVolume(source, "ul")}
+	cti.TVolume = []wunit.Volume{wunit.ZeroVolume()}
+	cti.TipType = []string{"Gilson200"}
+	cti.Component = []string{"water"}
+	cti.Prms = []*wtype.LHChannelParameter{ch}
+	cti.Multi = 1
+	return cti
+}
+
+func TestMultiDispense(t *testing.T) {
+	ch := getChannelForTest()
+	pol := wtype.LHPolicy{
+		"CAN_MULTI_DISPENSE":         true,
+		"MULTI_DSP_CONDITIONING_VOL": wunit.NewVolume(5.0, "ul"),
+		"MULTI_DSP_EXCESS_VOLUME":    wunit.NewVolume(10.0, "ul"),
+	}
+	md := newMultiDispenser(pol, getMultiDispenseRobot())
+
+	var ret []RobotInstruction
+	for i, to := range []string{"A1", "B1", "C1"} {
+		ret = append(ret, md.add(getMultiDispenseTransfer(ch, "A1", to, 50.0, 200.0-50.0*float64(i)))...)
+	}
+	// too much for one tip alongside the others
+	ret = append(ret, md.add(getMultiDispenseTransfer(ch, "A1", "D1", 50.0, 50.0))...)
+	ret = append(ret, md.flush()...)
+
+	// suck, condition, three dispenses and return, then the remaining transfer
+	if len(ret) != 7 {
+		t.Fatalf("expected 7 instructions, got %d: %v", len(ret), ret)
+	}
+
+	if suck, ok := ret[0].(*SuckInstruction); !ok {
+		t.Errorf("expected a suck first, got %T", ret[0])
+	} else if e := wunit.NewVolume(165.0, "ul"); !suck.Volume[0].EqualTo(e) {
+		t.Errorf("expected to aspirate %v, got %v", e, suck.Volume[0])
+	}
+
+	expected := []struct {
+		well    string
+		volume  float64
+		partial bool
+	}{
+		{"A1", 5.0, true},
+		{"A1", 50.0, true},
+		{"B1", 50.0, true},
+		{"C1", 50.0, true},
+		{"A1", 10.0, false},
+	}
+	for i, e := range expected {
+		blow, ok := ret[i+1].(*BlowInstruction)
+		if !ok {
+			t.Errorf("instruction %d: expected a blow, got %T", i+1, ret[i+1])
+			continue
+		}
+		if blow.WellTo[0] != e.well || !blow.Volume[0].EqualTo(wunit.NewVolume(e.volume, "ul")) || blow.Partial != e.partial {
+			t.Errorf("instruction %d: expected %g ul to %s (partial %t), got %v to %s (partial %t)", i+1, e.volume, e.well, e.partial, blow.Volume[0], blow.WellTo[0], blow.Partial)
+		}
+	}
+	// the conditioning and excess volumes go back to the source
+	if blow := ret[1].(*BlowInstruction); blow.PltTo[0] != "position_1" {
+		t.Errorf("expected conditioning volume to return to position_1, got %s", blow.PltTo[0])
+	}
+
+	if _, ok := ret[6].(*ChannelTransferInstruction); !ok {
+		t.Errorf("expected single transfer to be made as usual, got %T", ret[6])
+	}
+}
+
+func TestMultiDispenseDisabled(t *testing.T) {
+	md := newMultiDispenser(wtype.LHPolicy{"CAN_MULTI_DISPENSE": false}, getMultiDispenseRobot())
+	ch := getChannelForTest()
+
+	for _, to := range []string{"A1", "B1"} {
+		if ret := md.add(getMultiDispenseTransfer(ch, "A1", to, 50.0, 200.0)); len(ret) != 1 {
+			t.Errorf("expected transfer to %s to be made immediately, got %v", to, ret)
+		}
+	}
+	if ret := md.flush(); len(ret) != 0 {
+		t.Errorf("expected nothing pending, got %v", ret)
+	}
+}
+
+func TestMultiDispenseSourceVolume(t *testing.T) {
+	ch := getChannelForTest()
+	pol := wtype.LHPolicy{
+		"CAN_MULTI_DISPENSE":         true,
+		"MULTI_DSP_CONDITIONING_VOL": wunit.NewVolume(5.0, "ul"),
+		"MULTI_DSP_EXCESS_VOLUME":    wunit.NewVolume(10.0, "ul"),
+	}
+	md := newMultiDispenser(pol, getMultiDispenseRobot())
+
+	// the source holds 120ul of which 5ul is residual: enough for two
+	// transfers and the conditioning and excess volumes, but not for three
+	var ret []RobotInstruction
+	for i, to := range []string{"A1", "B1", "C1"} {
+		ret = append(ret, md.add(getMultiDispenseTransfer(ch, "A1", to, 50.0, 120.0-50.0*float64(i)))...)
+	}
+	ret = append(ret, md.flush()...)
+
+	// suck, condition, two dispenses and return, then the remaining transfer
+	if len(ret) != 6 {
+		t.Fatalf("expected 6 instructions, got %d: %v", len(ret), ret)
+	}
+	if suck, ok := ret[0].(*SuckInstruction); !ok {
+		t.Errorf("expected a suck first, got %T", ret[0])
+	} else if e := wunit.NewVolume(115.0, "ul"); !suck.Volume[0].EqualTo(e) {
+		t.Errorf("expected to aspirate %v, got %v", e, suck.Volume[0])
+	}
+	if _, ok := ret[5].(*ChannelTransferInstruction); !ok {
+		t.Errorf("expected single transfer to be made as usual, got %T", ret[5])
+	}
+}
@@ -1,6 +1,7 @@
 package sampletracker
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
@@ -13,12 +14,14 @@ type SampleTracker struct {
 	records  map[string]string
 	forwards map[string]string
 	plates   []*wtype.Plate
+	covers   map[string]wtype.PlateCover
 }
 
 func NewSampleTracker() *SampleTracker {
 	return &SampleTracker{
 		records:  make(map[string]string),
 		forwards: make(map[string]string),
+		covers:   make(map[string]wtype.PlateCover),
 	}
 }
 
@@ -29,6 +32,7 @@ func (st *SampleTracker) SetInputPlate(p *wtype.Plate) {
 	defer st.lock.Unlock()
 
 	st.plates = append(st.plates, p)
+	st.covers[p.ID] = p.Cover
 
 	for _, w := range p.HWells {
 		if !w.IsEmpty() {
@@ -97,3 +101,29 @@ func (st *SampleTracker) UpdateIDOf(ID string, newID string) {
 		st.forwards[newID] = ID
 	}
 }
+
+// GetPlateOf return the ID of the plate holding the component with the given
+// ID, if known
+func (st *SampleTracker) GetPlateOf(ID string) (string, bool) {
+	loc, ok := st.GetLocationOf(ID)
+	if !ok {
+		return "", false
+	}
+	return strings.Split(loc, ":")[0], true
+}
+
+// SetCoverOf record the seal or lid on the plate with the given ID
+func (st *SampleTracker) SetCoverOf(plateID string, cover wtype.PlateCover) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	st.covers[plateID] = cover
+}
+
+// GetCoverOf return the seal or lid on the plate with the given ID, if it
+// has been recorded
+func (st *SampleTracker) GetCoverOf(plateID string) (wtype.PlateCover, bool) {
+	st.lock.Lock()
+	defer st.lock.Unlock()
+	cover, ok := st.covers[plateID]
+	return cover, ok
+}
@@ -0,0 +1,226 @@
+package liquidhandling
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/antha/AnthaStandardLibrary/Packages/eng"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// Conditions on the deck assumed when estimating evaporation. Liquids are
+// treated as water since that is what most of them mostly are.
+var (
+	evaporationTemperature = wunit.NewTemperature(25.0, "C")
+	evaporationHumidity    = 0.5
+	evaporationAirVelocity = wunit.NewVelocity(0.2, "m/s")
+	evaporationPressure    = wunit.NewPressure(101325.0, "Pa")
+	evaporationLiquidClass = "water"
+)
+
+// evaporationThreshold is the fraction of the volume of a well that may
+// evaporate before the planner compensates for the loss or warns about it
+const evaporationThreshold = 0.05
+
+// An EvaporationLoss is the volume predicted to evaporate from a well while
+// it is open on the deck of the liquid handler
+type EvaporationLoss struct {
+	Well   *wtype.LHWell // the well in the initial state of the liquid handler
+	Time   time.Duration // how long the liquid in the well is exposed
+	Volume wunit.Volume  // the volume lost
+	// Compensated is true if the initial volume of the well was increased
+	// to make up for the loss
+	Compensated bool
+	// Warning is set if the loss exceeds the threshold and could not be
+	// compensated for
+	Warning string
+}
+
+func (l EvaporationLoss) String() string {
+	return fmt.Sprintf("%s from well %s of plate %q over %s", l.Volume, l.Well.Crds.FormatA1(), wtype.NameOf(l.Well.Plate), l.Time)
+}
+
+// evaporationLoss estimates the volume which evaporates from the well in the
+// given time, reduced by the cover of its plate if it has one
+func evaporationLoss(well *wtype.LHWell, exposure time.Duration) (wunit.Volume, error) {
+	area, err := well.CalculateMaxCrossSectionArea()
+	if err != nil {
+		return wunit.ZeroVolume(), err
+	}
+	vol := eng.EvaporationVolume(evaporationTemperature, evaporationLiquidClass, evaporationHumidity, exposure.Seconds(), evaporationAirVelocity, area, evaporationPressure)
+	if plate, ok := well.Plate.(*wtype.Plate); ok {
+		vol = wunit.MultiplyVolume(vol, plate.Cover.EvaporationFactor())
+	}
+	return vol, nil
+}
+
+// wellAccesses records when wells are first and last accessed by the
+// instructions of a request, measured from the start of the request
+type wellAccesses struct {
+	firstDispense map[*wtype.LHWell]time.Duration
+	lastAspirate  map[*wtype.LHWell]time.Duration
+	total         time.Duration
+}
+
+func (wa *wellAccesses) aspirate(well *wtype.LHWell, at time.Duration) {
+	if well != nil {
+		wa.lastAspirate[well] = at
+	}
+}
+
+func (wa *wellAccesses) dispense(well *wtype.LHWell, at time.Duration) {
+	if _, seen := wa.firstDispense[well]; well != nil && !seen {
+		wa.firstDispense[well] = at
+	}
+}
+
+// findWellAccesses walks through the instructions using the timer to
+// estimate when each well is used
+func findWellAccesses(props *liquidhandling.LHProperties, timer liquidhandling.LHTimer, instructions []liquidhandling.TerminalRobotInstruction) *wellAccesses {
+	wa := &wellAccesses{
+		firstDispense: make(map[*wtype.LHWell]time.Duration),
+		lastAspirate:  make(map[*wtype.LHWell]time.Duration),
+	}
+
+	wellAt := func(plateID, address string) *wtype.LHWell {
+		if plate, ok := props.PlateLookup[plateID].(*wtype.LHPlate); ok {
+			return plate.Wellcoords[address]
+		}
+		return nil
+	}
+
+	var lastWells []*wtype.LHWell
+	for _, ins := range instructions {
+		d := timer.TimeFor(ins)
+		ins.Visit(liquidhandling.RobotInstructionBaseVisitor{
+			HandleMove: func(ins *liquidhandling.MoveInstruction) {
+				lastWells = make([]*wtype.LHWell, len(ins.Pos))
+				for i, position := range ins.Pos {
+					lastWells[i] = wellAt(props.PosLookup[position], ins.Well[i])
+				}
+			},
+			HandleAspirate: func(ins *liquidhandling.AspirateInstruction) {
+				for _, well := range lastWells {
+					wa.aspirate(well, wa.total)
+				}
+			},
+			HandleDispense: func(ins *liquidhandling.DispenseInstruction) {
+				for _, well := range lastWells {
+					wa.dispense(well, wa.total)
+				}
+			},
+			HandleTransfer: func(ins *liquidhandling.TransferInstruction) {
+				// share the time of the instruction between its transfers
+				if len(ins.Transfers) == 0 {
+					return
+				}
+				step := d / time.Duration(len(ins.Transfers))
+				for i, mtf := range ins.Transfers {
+					at := wa.total + time.Duration(i)*step
+					for _, tf := range mtf.Transfers {
+						wa.aspirate(wellAt(props.PosLookup[tf.PltFrom], tf.WellFrom), at)
+						wa.dispense(wellAt(props.PosLookup[tf.PltTo], tf.WellTo), at)
+					}
+				}
+			},
+		})
+		wa.total += d
+	}
+
+	return wa
+}
+
+// modelEvaporation estimates the volume lost from each well used by the
+// request. Wells which start with liquid in them are exposed until they are
+// last aspirated from, while wells which are filled by the request are
+// exposed from when they are first dispensed to until the end. Losses from
+// autoallocated inputs which exceed the threshold are compensated for by
+// increasing the initial volume, otherwise a warning is raised. As in
+// shrinkVolumes, the instructions are not regenerated so the final state,
+// which does not model evaporation, holds the extra volume too.
+func (this *Liquidhandler) modelEvaporation(rq *LHRequest) error {
+	timer := this.Properties.GetTimer()
+	if timer == nil {
+		return nil
+	}
+
+	wa := findWellAccesses(this.Properties, timer, rq.Instructions)
+
+	positions := make([]string, 0, len(this.Properties.Plates))
+	for pos := range this.Properties.Plates {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+
+	var losses []EvaporationLoss
+	for _, pos := range positions {
+		for _, row := range this.Properties.Plates[pos].Wells() {
+			for _, well := range row {
+				var loss EvaporationLoss
+				var volume wunit.Volume
+				if !well.IsEmpty() {
+					at, ok := wa.lastAspirate[well]
+					if !ok {
+						continue
+					}
+					loss.Time = at
+					volume = well.CurrentVolume()
+				} else if at, ok := wa.firstDispense[well]; ok {
+					loss.Time = wa.total - at
+					finalWell, err := this.getFinalWell(well)
+					if err != nil {
+						return err
+					}
+					volume = finalWell.CurrentVolume()
+				} else {
+					continue
+				}
+
+				vol, err := evaporationLoss(well, loss.Time)
+				if err != nil {
+					return err
+				}
+				loss.Well = well
+				loss.Volume = vol
+
+				if vol.GreaterThan(wunit.MultiplyVolume(volume, evaporationThreshold)) {
+					compensated := wunit.AddVolumes(volume, vol)
+					if !well.IsEmpty() && well.IsAutoallocated() && !compensated.GreaterThan(well.MaxVolume()) {
+						if err := this.compensateEvaporation(well, vol); err != nil {
+							return err
+						}
+						loss.Compensated = true
+					} else {
+						loss.Warning = fmt.Sprintf("predicted evaporation of %s is more than %g%% of the volume of the well", loss, evaporationThreshold*100)
+					}
+				}
+
+				losses = append(losses, loss)
+			}
+		}
+	}
+
+	rq.Evaporation = losses
+	return nil
+}
+
+// compensateEvaporation adds the volume predicted to evaporate to a well of
+// the initial state and to the same well in the final state
+func (this *Liquidhandler) compensateEvaporation(well *wtype.LHWell, vol wunit.Volume) error {
+	finalWell, err := this.getFinalWell(well)
+	if err != nil {
+		return err
+	}
+
+	for _, w := range []*wtype.LHWell{well, finalWell} {
+		contents := w.Contents().Dup()
+		contents.SetVolume(wunit.AddVolumes(w.CurrentVolume(), vol))
+		if err := w.SetContents(contents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
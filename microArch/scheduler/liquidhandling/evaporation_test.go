@@ -0,0 +1,158 @@
+package liquidhandling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// fixedTimer takes the same time for every instruction
+type fixedTimer time.Duration
+
+func (t fixedTimer) TimeFor(liquidhandling.RobotInstruction) time.Duration {
+	return time.Duration(t)
+}
+
+func TestEvaporationLoss(t *testing.T) {
+	well := GetPlateForTest().Wellcoords["A1"]
+
+	if none, err := evaporationLoss(well, 0); err != nil {
+		t.Fatal(err)
+	} else if !none.IsZero() {
+		t.Errorf("expected no loss without exposure found %s", none)
+	}
+
+	hour, err := evaporationLoss(well, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	} else if hour.RawValue() <= 0.0 {
+		t.Fatalf("expected loss over an hour found %s", hour)
+	}
+
+	twoHours, err := evaporationLoss(well, 2*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	} else if expected := wunit.MultiplyVolume(hour, 2.0); !twoHours.EqualTo(expected) {
+		t.Errorf("expected loss over two hours of %s found %s", expected, twoHours)
+	}
+
+	// Covers reduce the loss
+	for cover, factor := range map[wtype.PlateCover]float64{wtype.Lidded: 0.1, wtype.Sealed: 0.0} {
+		plate := GetPlateForTest()
+		plate.Cover = cover
+		covered, err := evaporationLoss(plate.Wellcoords["A1"], time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		} else if expected := wunit.MultiplyVolume(hour, factor); !covered.EqualTo(expected) {
+			t.Errorf("expected loss over an hour from %v plate of %s found %s", cover, expected, covered)
+		}
+	}
+}
+
+func TestModelEvaporation(t *testing.T) {
+	ctx := GetContextForTest()
+	lh := GetLiquidHandlerForTest(ctx)
+
+	plate := PrefillPlateForTest(ctx, GetPlateForTest(), "water", map[string]float64{"A1": 20.0, "B1": 20.0})
+	plate.Wellcoords["A1"].DeclareAutoallocated()
+	position := lh.Properties.Preferences.Inputs[0]
+	if err := lh.Properties.AddPlateTo(position, plate); err != nil {
+		t.Fatal(err)
+	}
+	lh.FinalProperties = lh.Properties.Dup()
+
+	// Both wells are open for two hours before they are aspirated from
+	wait := liquidhandling.NewWaitInstruction()
+	wait.Time = (2 * time.Hour).Seconds()
+	move := liquidhandling.NewMoveInstruction()
+	move.Pos = []string{position, position}
+	move.Well = []string{"A1", "B1"}
+	rq := GetLHRequestForTest()
+	rq.Instructions = []liquidhandling.TerminalRobotInstruction{wait, move, liquidhandling.NewAspirateInstruction()}
+
+	if err := lh.modelEvaporation(rq); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rq.Evaporation) != 2 {
+		t.Fatalf("expected losses from %d wells found %d", 2, len(rq.Evaporation))
+	}
+	for _, loss := range rq.Evaporation {
+		address := loss.Well.Crds.FormatA1()
+		final, err := lh.getFinalWell(loss.Well)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := wunit.NewVolume(20.0, "ul")
+		switch address {
+		case "A1":
+			// Autoallocated inputs are topped up in both initial and final states
+			if !loss.Compensated || loss.Warning != "" {
+				t.Errorf("expected loss from A1 to be compensated")
+			}
+			expected = wunit.AddVolumes(expected, loss.Volume)
+		case "B1":
+			if loss.Compensated || loss.Warning == "" {
+				t.Errorf("expected warning about loss from B1")
+			}
+		}
+		if v := loss.Well.CurrentVolume(); !v.EqualTo(expected) {
+			t.Errorf("expected initial volume in %s of %s found %s", address, expected, v)
+		}
+		if v := final.CurrentVolume(); !v.EqualTo(expected) {
+			t.Errorf("expected final volume in %s of %s found %s", address, expected, v)
+		}
+	}
+}
+
+func TestFindWellAccesses(t *testing.T) {
+	plate := GetPlateForTest()
+	props := &liquidhandling.LHProperties{
+		PlateLookup: map[string]interface{}{plate.ID: plate},
+		PosLookup:   map[string]string{"position_1": plate.ID},
+	}
+
+	transfer := func(from, to string) liquidhandling.TransferParams {
+		return liquidhandling.TransferParams{
+			PltFrom:  "position_1",
+			WellFrom: from,
+			PltTo:    "position_1",
+			WellTo:   to,
+			Volume:   wunit.NewVolume(10.0, "ul"),
+		}
+	}
+
+	insts := []liquidhandling.TerminalRobotInstruction{
+		&liquidhandling.TransferInstruction{
+			Transfers: []liquidhandling.MultiTransferParams{
+				{Transfers: []liquidhandling.TransferParams{transfer("A1", "B1")}},
+				{Transfers: []liquidhandling.TransferParams{transfer("A1", "C1")}},
+			},
+		},
+		&liquidhandling.TransferInstruction{
+			Transfers: []liquidhandling.MultiTransferParams{
+				{Transfers: []liquidhandling.TransferParams{transfer("A1", "B1")}},
+			},
+		},
+	}
+
+	wa := findWellAccesses(props, fixedTimer(time.Minute), insts)
+
+	if wa.total != 2*time.Minute {
+		t.Errorf("expected total time %s found %s", 2*time.Minute, wa.total)
+	}
+	if at := wa.lastAspirate[plate.Wellcoords["A1"]]; at != time.Minute {
+		t.Errorf("expected A1 last aspirated at %s found %s", time.Minute, at)
+	}
+	for well, expected := range map[string]time.Duration{
+		"B1": 0,
+		"C1": 30 * time.Second,
+	} {
+		if at, ok := wa.firstDispense[plate.Wellcoords[well]]; !ok || at != expected {
+			t.Errorf("expected %s first dispensed to at %s found %s", well, expected, at)
+		}
+	}
+}
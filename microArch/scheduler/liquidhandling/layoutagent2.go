@@ -42,7 +42,11 @@ func ImprovedLayoutAgent(ctx context.Context, request *LHRequest, params *liquid
 	ch := request.InstructionChain
 	pc := make([]PlateChoice, 0, 3)
 	mp := make(map[string]string)
-	var err error
+
+	err := request.setupOutputLayouts()
+	if err != nil {
+		return err
+	}
 
 	// stage zero: seed in user plates if destinations are required
 	pc = map_in_user_plates(request, pc)
@@ -286,6 +290,12 @@ func LayoutStage(ctx context.Context, request *LHRequest, params *liquidhandling
 		}
 	}
 
+	for i, v := range request.OutputLayouts {
+		if id, ok := remap[v.PlateID]; ok {
+			request.OutputLayouts[i].PlateID = id
+		}
+	}
+
 	return plate_choices, mapchoices, nil
 }
 
@@ -526,7 +536,7 @@ func choose_plates(ctx context.Context, request *LHRequest, pc []PlateChoice, or
 
 		// chop the assignments up
 
-		pc2 = append(pc2, modpc(v, plate.Nwells)...)
+		pc2 = append(pc2, modpc(v, request.outputCapacity(plate, v.Assigned))...)
 	}
 
 	// copy the choices in
@@ -703,6 +713,11 @@ func make_layouts(ctx context.Context, request *LHRequest, pc []PlateChoice) err
 			}
 		}
 
+		// mixes with an output layout other than the default are placed
+		// together once the rest have been
+		var layouts []wtype.OutputLayout
+		laidOut := make(map[wtype.OutputLayout][]int)
+
 		for i := range c.Assigned {
 			sID := c.Assigned[i]
 			well := ""
@@ -710,6 +725,14 @@ func make_layouts(ctx context.Context, request *LHRequest, pc []PlateChoice) err
 				well = c.Wells[i]
 			}
 
+			if layout := request.outputLayoutFor(request.LHInstructions[sID]); well == "" && !layout.IsDefault() {
+				if _, ok := laidOut[layout]; !ok {
+					layouts = append(layouts, layout)
+				}
+				laidOut[layout] = append(laidOut[layout], i)
+				continue
+			}
+
 			var assignment string
 
 			if well == "" {
@@ -733,6 +756,29 @@ func make_layouts(ctx context.Context, request *LHRequest, pc []PlateChoice) err
 
 			opa[assignment] = append(opa[assignment], sID)
 		}
+
+		for _, layout := range layouts {
+			mixes := make([]*wtype.LHInstruction, 0, len(laidOut[layout]))
+			for _, i := range laidOut[layout] {
+				mixes = append(mixes, request.LHInstructions[c.Assigned[i]])
+			}
+
+			record, err := layoutOutputs(plat, request.OutputIteratorFactory(plat), layout, mixes)
+			if err != nil {
+				return err
+			}
+			record.PlateID = c.ID
+			request.OutputLayouts = append(request.OutputLayouts, *record)
+
+			for k, i := range laidOut[layout] {
+				sID := c.Assigned[i]
+				well := record.Mixes[k].Well
+				request.LHInstructions[sID].Welladdress = well
+				c.Wells[i] = well
+				assignment := c.ID + ":" + well
+				opa[assignment] = append(opa[assignment], sID)
+			}
+		}
 	}
 
 	request.OutputAssignments = opa
@@ -0,0 +1,72 @@
+package liquidhandling
+
+import (
+	"context"
+
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+// optimizeLayout moves the plates placed by the setup agent to reduce the
+// estimated time the head spends moving between objects on the deck, then
+// regenerates the instructions for the new layout. Plates may only move
+// between the positions the setup agent could have chosen for them, so user
+// preferences and plate constraints are respected, and plates placed by the
+// user are left where they are. The tipboxes added while building the
+// instructions must already have been copied into the initial state so that
+// moves to and from them are counted.
+func (this *Liquidhandler) optimizeLayout(ctx context.Context, request *LHRequest) error {
+	allowed := make(map[string]liquidhandling.Addresses, len(request.PlateLookup))
+	for id := range request.PlateLookup {
+		var preferences liquidhandling.Addresses
+		plate, ok := request.InputPlates[id]
+		if ok {
+			preferences = this.Properties.Preferences.Inputs
+		} else if plate, ok = request.OutputPlates[id]; ok {
+			preferences = this.Properties.Preferences.Outputs
+		} else {
+			continue
+		}
+
+		constraints, isConstrained := plate.IsConstrainedOn(this.Properties.Model)
+		positions := make(liquidhandling.Addresses, 0, len(preferences))
+		for _, pos := range preferences {
+			if !isConstrained || isInStrArr(pos, constraints) {
+				positions = append(positions, pos)
+			}
+		}
+		allowed[id] = positions
+	}
+
+	counts := liquidhandling.CountTransfers(this.Properties, request.Instructions)
+	opt, err := this.Properties.OptimizeLayout(counts, allowed)
+	if err != nil {
+		return err
+	}
+	request.LayoutOptimization = opt
+
+	if len(opt.Moved) == 0 {
+		return nil
+	}
+
+	for id, pos := range opt.Moved {
+		request.PlateLookup[id] = pos
+	}
+
+	root, err := liquidhandling.NewITreeRoot(request.InstructionChain)
+	if err != nil {
+		return err
+	}
+	final, err := root.Build(ctx, request.Policies(), this.Properties)
+	if err != nil {
+		return err
+	}
+	request.InstructionTree = root
+	request.Instructions = root.Leaves()
+	this.FinalProperties = final
+
+	// the tipboxes already in the initial state are used again, so only any
+	// extra ones need copying
+	this.copyTipboxes()
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package liquidhandling
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/mixer"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+)
+
+func TestPlanOptimizeLayout(t *testing.T) {
+	ctx := GetContextForTest()
+	lh := GetLiquidHandlerForTest(ctx)
+	rq := GetLHRequestForTest()
+
+	// few enough mixes that the tips come from one tipbox, leaving room to
+	// move the plates
+	water := GetComponentForTest(ctx, "water", wunit.NewVolume(1000.0, "ul"))
+	part := GetComponentForTest(ctx, "dna", wunit.NewVolume(1000.0, "ul"))
+	for k := 0; k < 8; k++ {
+		ins := mixer.GenericMix(mixer.MixOptions{
+			Inputs: []*wtype.Liquid{
+				mixer.Sample(water, wunit.NewVolume(20.0, "ul")),
+				mixer.Sample(part, wunit.NewVolume(5.0, "ul")),
+			},
+			PlateType: "pcrplate_skirted_riser",
+		})
+		ins.Outputs[0].SetName(fmt.Sprintf("mix_%d", k))
+		rq.Add_instruction(ins)
+	}
+	rq.InputPlatetypes = append(rq.InputPlatetypes, GetPlateForTest())
+	rq.OutputPlatetypes = append(rq.OutputPlatetypes, GetPlateForTest())
+	rq.Options.OptimizeLayout = true
+
+	if err := lh.Plan(ctx, rq); err != nil {
+		t.Fatal(err)
+	}
+
+	opt := rq.LayoutOptimization
+	if opt == nil {
+		t.Fatal("expected the layout to be optimized")
+	} else if len(opt.Moved) == 0 || opt.After >= opt.Before {
+		t.Errorf("expected plates to be moved to save time, got %v taking %s rather than %s", opt.Moved, opt.After, opt.Before)
+	}
+
+	// moves to and from the tipboxes are counted
+	counts := liquidhandling.CountTransfers(lh.Properties, rq.Instructions)
+	for _, tb := range lh.Properties.Tipboxes {
+		if len(counts[tb.ID]) == 0 {
+			t.Errorf("expected moves from tipbox %s to be counted", tb.ID)
+		}
+	}
+
+	// plates stay within the positions the setup agent could choose from
+	check := func(plates map[string]bool, allowed liquidhandling.Addresses) {
+		for id := range plates {
+			pos := lh.Properties.PlateIDLookup[id]
+			if !isInStrArr(pos, allowed) {
+				t.Errorf("plate %s moved to %s, expected one of %v", id, pos, allowed)
+			}
+			if moved, ok := opt.Moved[id]; ok && moved != pos {
+				t.Errorf("plate %s recorded as moved to %s but is at %s", id, moved, pos)
+			}
+		}
+	}
+	inputs := make(map[string]bool, len(rq.InputPlates))
+	for id := range rq.InputPlates {
+		inputs[id] = true
+	}
+	outputs := make(map[string]bool, len(rq.OutputPlates))
+	for id := range rq.OutputPlates {
+		outputs[id] = true
+	}
+	check(inputs, lh.Properties.Preferences.Inputs)
+	check(outputs, lh.Properties.Preferences.Outputs)
+
+	// the plan made for the new layout is consistent
+	test := &PlanningTest{Liquidhandler: lh}
+	test.checkPlateIDMap(t)
+	test.checkPositionConsistency(t)
+	test.checkSummaryGeneration(t, rq)
+
+	bs, err := SummarizeDeviceLayout("", lh.Properties, lh.FinalProperties, lh.PlateIDMap(), nil, nil, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary struct {
+		LayoutOptimization *struct {
+			Before float64           `json:"before_s"`
+			After  float64           `json:"after_s"`
+			Moved  map[string]string `json:"moved"`
+		} `json:"layout_optimization"`
+	}
+	if err := json.Unmarshal(bs, &summary); err != nil {
+		t.Fatal(err)
+	} else if s := summary.LayoutOptimization; s == nil {
+		t.Error("expected the layout optimization in the summary")
+	} else if s.Before != opt.Before.Seconds() || s.After != opt.After.Seconds() || len(s.Moved) != len(opt.Moved) {
+		t.Errorf("expected layout optimization %v in the summary, got %v", opt, s)
+	}
+}
@@ -3,6 +3,7 @@ package liquidhandling
 import (
 	"context"
 	"fmt"
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/inventory"
 	"github.com/antha-lang/antha/inventory/testinventory"
 	"testing"
@@ -25,6 +26,31 @@ func GetIndependentLiquidHandlerForTest(ctx context.Context) *Liquidhandler {
 	return Init(gilson)
 }
 
+// GetSingleChannelLiquidHandlerForTest a liquid handler with a single head
+// of one channel, which can be simulated without the heads of the test
+// Gilson colliding with tips in the tipbox
+func GetSingleChannelLiquidHandlerForTest(ctx context.Context) *Liquidhandler {
+	gilson := makeGilson(ctx)
+
+	config := getHVConfig()
+	config.Multi = 1
+	adaptor := wtype.NewLHAdaptor("DummyAdaptor", "Gilson", config)
+	head := wtype.NewLHHead("HVHead", "Gilson", config)
+	head.Adaptor = adaptor
+
+	ha := wtype.NewLHHeadAssembly(nil)
+	ha.AddPosition(wtype.Coordinates3D{})
+	if err := ha.LoadHead(head); err != nil {
+		panic(err)
+	}
+
+	gilson.Heads = []*wtype.LHHead{head}
+	gilson.Adaptors = []*wtype.LHAdaptor{adaptor}
+	gilson.HeadAssemblies = []*wtype.LHHeadAssembly{ha}
+
+	return Init(gilson)
+}
+
 func GetLHRequestForTest() *LHRequest {
 	req := NewLHRequest()
 	return req
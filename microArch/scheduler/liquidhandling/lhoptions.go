@@ -1,5 +1,9 @@
 package liquidhandling
 
+import (
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
 type LHOptions struct {
 	ModelEvaporation         bool
 	OutputSort               bool
@@ -8,6 +12,9 @@ type LHOptions struct {
 	LegacyVolume             bool
 	FixVolumes               bool
 	IgnorePhysicalSimulation bool
+	PeelSealedPlates         bool
+	OptimizeLayout           bool
+	OutputLayout             wtype.OutputLayout // for mixes which don't specify their own
 }
 
 func NewLHOptions() LHOptions {
@@ -70,7 +70,13 @@ type LHRequest struct {
 	NUserPlates           int
 	OutputSort            bool
 	TipsUsed              []wtype.TipEstimate
-	InputSolutions        *InputSolutions //store properties related to the Liquids for the request
+	InputSolutions        *InputSolutions   //store properties related to the Liquids for the request
+	Uncoverings           []Uncovering      // seals and lids to remove before running the request
+	Evaporation           []EvaporationLoss // predicted losses from open wells if Options.ModelEvaporation is set
+	// estimated head movement saved if Options.OptimizeLayout is set
+	LayoutOptimization *liquidhandling.LayoutOptimization
+	// where mixes were placed by output layouts other than the default
+	OutputLayouts []OutputLayoutRecord
 }
 
 func (req *LHRequest) GetPlate(id string) (*wtype.Plate, bool) {
@@ -39,6 +39,7 @@ import (
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
 	"github.com/antha-lang/antha/microArch/simulator"
 	simulator_lh "github.com/antha-lang/antha/microArch/simulator/liquidhandling"
+	"github.com/antha-lang/antha/profile"
 )
 
 // the liquid handler structure defines the interface to a particular liquid handling
@@ -295,27 +296,58 @@ func (this *Liquidhandler) Execute(request *LHRequest) error {
 	return nil
 }
 
+// getFinalWell returns the well in the final state which corresponds to the
+// given well of the initial state
+func (this *Liquidhandler) getFinalWell(initialWell *wtype.LHWell) (*wtype.LHWell, error) {
+	// assumption: plate locations don't change
+	plateID := wtype.IDOf(initialWell.GetParent())
+	if platePos, ok := this.Properties.PlateIDLookup[plateID]; !ok {
+		return nil, errors.Errorf("couldn't find position of plate %s", plateID)
+	} else if finalPlate, ok := this.FinalProperties.Plates[platePos]; !ok {
+		return nil, errors.Errorf("couldn't find final plate for initial plate %s at %s", plateID, platePos)
+	} else if finalWell, ok := finalPlate.WellAt(initialWell.Crds); !ok {
+		return nil, errors.Errorf("couldn't find well %s in final plate at %s", initialWell.Crds.FormatA1(), platePos)
+	} else {
+		return finalWell, nil
+	}
+}
+
 // shrinkVolumes reduce autoallocated volumes to the amount we actually need, removing
 // any unused wells or plates
 func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 
 	// first, iterate through the generated instructions and count up how much
-	// of each autoallocated liquid was actually used
+	// of each autoallocated liquid was actually used. Liquid may be returned
+	// to its source, e.g. the conditioning and excess volumes of a
+	// multi-dispense, so each well must hold the most that was drawn from it
+	// at any one time rather than the total aspirated
 	var lastWells []*wtype.LHWell
+	netVols := make(map[*wtype.LHWell]wunit.Volume)
+	netRawVols := make(map[*wtype.LHWell]wunit.Volume)
 	vols := make(map[*wtype.LHWell]wunit.Volume)
 	rawVols := make(map[*wtype.LHWell]wunit.Volume)
 	useVol := func(well *wtype.LHWell, vol wunit.Volume, carry wunit.Volume) {
-		v, ok := vols[well]
-		r := rawVols[well]
+		v, ok := netVols[well]
+		r := netRawVols[well]
 		if !ok {
 			v = wunit.NewVolume(0.0, "ul")
 			r = wunit.NewVolume(0.0, "ul")
-			vols[well] = v
-			rawVols[well] = r
+			netVols[well] = v
+			netRawVols[well] = r
 		}
 		v.Add(vol)
 		v.Add(carry)
 		r.Add(vol)
+		if peak, ok := vols[well]; !ok || v.GreaterThan(peak) {
+			vols[well] = wunit.CopyVolume(v)
+			rawVols[well] = wunit.CopyVolume(r)
+		}
+	}
+	returnVol := func(well *wtype.LHWell, vol wunit.Volume) {
+		if v, ok := netVols[well]; ok {
+			v.Subtract(vol)
+			netRawVols[well].Subtract(vol)
+		}
 	}
 	usedPlates := make(map[*wtype.LHPlate]bool)
 
@@ -340,6 +372,13 @@ func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 					}
 				}
 			},
+			HandleDispense: func(ins *liquidhandling.DispenseInstruction) {
+				for i, lastWell := range lastWells {
+					if lastWell.IsAutoallocated() && i < len(ins.Volume) {
+						returnVol(lastWell, ins.Volume[i])
+					}
+				}
+			},
 			HandleTransfer: func(ins *liquidhandling.TransferInstruction) {
 				for _, mtf := range ins.Transfers {
 					for _, tf := range mtf.Transfers {
@@ -355,21 +394,6 @@ func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 		})
 	}
 
-	getFinalWell := func(initialWell *wtype.LHWell) (*wtype.LHWell, error) {
-		// assumption: plate locations don't change
-		plateID := wtype.IDOf(initialWell.GetParent())
-		if platePos, ok := this.Properties.PlateIDLookup[plateID]; !ok {
-			return nil, errors.Errorf("couldn't find position of plate %s", plateID)
-		} else if finalPlate, ok := this.FinalProperties.Plates[platePos]; !ok {
-			return nil, errors.Errorf("couldn't find final plate for initial plate %s at %s", plateID, platePos)
-		} else if finalWell, ok := finalPlate.WellAt(initialWell.Crds); !ok {
-			return nil, errors.Errorf("couldn't find well %s in final plate at %s", initialWell.Crds.FormatA1(), platePos)
-		} else {
-			return finalWell, nil
-		}
-
-	}
-
 	// second, set volumes for each autoallocated input as calculated
 	for initialWell, volUsed := range vols {
 		if initialWell.IsAutoallocated() {
@@ -405,7 +429,7 @@ func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 			// since we aren't yet re-generating the instructions, we need to update the final volume as well
 			finalContents := initialContents.Dup()
 			finalContents.SetVolume(remainingVolume)
-			if finalWell, err := getFinalWell(initialWell); err != nil {
+			if finalWell, err := this.getFinalWell(initialWell); err != nil {
 				return err
 			} else if err := finalWell.SetContents(finalContents); err != nil {
 				return err
@@ -424,7 +448,7 @@ func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 					initialWell.Clear()
 
 					// as in step 2, we need to update the final well volume as well
-					if finalWell, err := getFinalWell(initialWell); err != nil {
+					if finalWell, err := this.getFinalWell(initialWell); err != nil {
 						return err
 					} else {
 						finalWell.Clear()
@@ -453,6 +477,19 @@ func (this *Liquidhandler) shrinkVolumes(rq *LHRequest) error {
 }
 
 // updateIDs
+// copyTipboxes copies the tipboxes added to the final state while building the
+// instructions accross to the initial properties, refilled with tips
+func (this *Liquidhandler) copyTipboxes() {
+	for pos, tb := range this.FinalProperties.Tipboxes {
+		if _, ok := this.Properties.Tipboxes[pos]; ok {
+			continue
+		}
+		initialTb := tb.DupKeepIDs()
+		initialTb.Refresh()
+		this.Properties.AddTipBoxTo(pos, initialTb)
+	}
+}
+
 func (this *Liquidhandler) updateIDs() error {
 
 	// keep track of object ID changes
@@ -514,6 +551,7 @@ func (this *Liquidhandler) update_metadata() error {
 //
 
 func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
+	defer profile.Start(ctx, "planner", "plan").End()
 
 	//add in a plateCache for instruction generation
 	ctx = plateCache.NewContext(ctx)
@@ -561,7 +599,10 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 		return err
 	}
 
-	if instructions, stockconcs, err := request.solutionSetup(); err != nil {
+	span := profile.Start(ctx, "planner", "solution setup")
+	instructions, stockconcs, err := request.solutionSetup()
+	span.End()
+	if err != nil {
 		return errors.WithMessage(err, "during solution setup")
 	} else if err := instructions.AssertVolumesNonNegative(); err != nil {
 		return errors.WithMessage(err, "after solution setup")
@@ -576,7 +617,10 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 
 	// set up the mapping of the outputs
 	// tried moving here to see if we can use results in fixVolumes
-	if err := this.Layout(ctx, request); err != nil {
+	span = profile.Start(ctx, "planner", "layout")
+	err = this.Layout(ctx, request)
+	span.End()
+	if err != nil {
 		return err
 	}
 
@@ -592,7 +636,10 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 
 	if request.Options.FixVolumes {
 		// see if volumes can be corrected
-		if err := FixVolumes(request, this.Properties.CarryVolume()); err != nil {
+		span = profile.Start(ctx, "planner", "fix volumes")
+		err = FixVolumes(request, this.Properties.CarryVolume())
+		span.End()
+		if err != nil {
 			return err
 		} else {
 			if request.Options.PrintInstructions {
@@ -627,10 +674,18 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 	}
 
 	// define the input plates
-	if err := request.inputPlateSetup(ctx, this.Properties.CarryVolume()); err != nil {
+	span = profile.Start(ctx, "planner", "input plate setup")
+	err = request.inputPlateSetup(ctx, this.Properties.CarryVolume())
+	span.End()
+	if err != nil {
 		return errors.WithMessage(err, "while setting up input plates")
 	}
 
+	// any seals or lids must come off before the plates can be used
+	if err := request.uncoverPlates(ctx); err != nil {
+		return err
+	}
+
 	// next we need to determine the liquid handler setup
 	if err := this.Setup(ctx, request); err != nil {
 		return err
@@ -648,29 +703,51 @@ func (this *Liquidhandler) Plan(ctx context.Context, request *LHRequest) error {
 	}
 
 	// make the instructions for executing this request by first building the ITree root, then generating the lower level instructions
-	if root, err := liquidhandling.NewITreeRoot(request.InstructionChain); err != nil {
-		return err
-	} else if final, err := root.Build(ctx, request.Policies(), this.Properties); err != nil {
+	span = profile.Start(ctx, "planner", "build instruction tree")
+	root, err := liquidhandling.NewITreeRoot(request.InstructionChain)
+	var final *liquidhandling.LHProperties
+	if err == nil {
+		final, err = root.Build(ctx, request.Policies(), this.Properties)
+	}
+	span.End()
+	if err != nil {
 		return err
-	} else {
-		request.InstructionTree = root
-		request.Instructions = root.Leaves()
-		this.FinalProperties = final
 	}
+	request.InstructionTree = root
+	request.Instructions = root.Leaves()
+	this.FinalProperties = final
 
 	// tipboxes are added during the tree building, so only exist in the final state
-	// copy them accross to the initial properties
-	for pos, tb := range this.FinalProperties.Tipboxes {
-		initialTb := tb.DupKeepIDs()
-		initialTb.Refresh()
-		this.Properties.AddTipBoxTo(pos, initialTb)
+	this.copyTipboxes()
+
+	// rearrange the plates to reduce head movement
+	if request.Options.OptimizeLayout {
+		span = profile.Start(ctx, "planner", "optimize layout")
+		err = this.optimizeLayout(ctx, request)
+		span.End()
+		if err != nil {
+			return err
+		}
 	}
 
 	// revise the volumes - this makes sure the volumes requested are correct
-	if err := this.shrinkVolumes(request); err != nil {
+	span = profile.Start(ctx, "planner", "shrink volumes")
+	err = this.shrinkVolumes(request)
+	span.End()
+	if err != nil {
 		return err
 	}
 
+	// allow for the liquid which evaporates from open wells
+	if request.Options.ModelEvaporation {
+		span = profile.Start(ctx, "planner", "model evaporation")
+		err = this.modelEvaporation(request)
+		span.End()
+		if err != nil {
+			return err
+		}
+	}
+
 	// make certain the IDs have been changed by the liquidhandling step
 	if err := this.updateIDs(); err != nil {
 		return err
@@ -1214,6 +1214,58 @@ func TestExecutionPlanning(t *testing.T) {
 
 }
 
+func TestMultiDispensePlanning(t *testing.T) {
+	ctx := GetContextForTest()
+	PlanningTests{
+		{
+			Name:          "single aspirate",
+			Liquidhandler: GetSingleChannelLiquidHandlerForTest(ctx),
+			Instructions: Mixes("pcrplate_skirted_riser", TestMixComponents{
+				{
+					LiquidName:    "water",
+					VolumesByWell: map[string]float64{"A1": 45.0, "A2": 45.0, "A3": 45.0, "A4": 45.0},
+					LiquidType:    wtype.LTMultiDispense,
+					Sampler:       mixer.Sample,
+				},
+			}),
+			InputPlates:  []*wtype.LHPlate{GetPlateForTest()},
+			OutputPlates: []*wtype.LHPlate{GetPlateForTest()},
+			Assertions: Assertions{
+				NumberOfAssertion(liquidhandling.ASP, 1),
+				// conditioning, four destinations and excess
+				NumberOfAssertion(liquidhandling.DSP, 6),
+				// 180ul plus 5ul conditioning, 5ul excess, carry and residual
+				InitialInputVolumesAssertion(0.001, map[string]float64{"A1": 195.5}),
+				SimulationAssertion(),
+			},
+		},
+		{
+			// all four transfers fit in the working volume of the source but
+			// not alongside the conditioning and excess volumes
+			Name:          "source too small for one aspirate",
+			Liquidhandler: GetSingleChannelLiquidHandlerForTest(ctx),
+			Instructions: Mixes("pcrplate_skirted_riser", TestMixComponents{
+				{
+					LiquidName:    "water",
+					VolumesByWell: map[string]float64{"A1": 47.0, "A2": 47.0, "A3": 47.0, "A4": 47.0},
+					LiquidType:    wtype.LTMultiDispense,
+					Sampler:       mixer.Sample,
+				},
+			}),
+			InputPlates:  []*wtype.LHPlate{GetPlateForTest()},
+			OutputPlates: []*wtype.LHPlate{GetPlateForTest()},
+			Assertions: Assertions{
+				NumberOfAssertion(liquidhandling.ASP, 2),
+				NumberOfAssertion(liquidhandling.DSP, 6),
+				// the conditioning and excess volumes are returned before
+				// the last transfer, so are only needed once
+				InitialInputVolumesAssertion(0.001, map[string]float64{"A1": 194.0}),
+				SimulationAssertion(),
+			},
+		},
+	}.Run(ctx, t)
+}
+
 func TestFixDuplicatePlateNames(t *testing.T) {
 	rq := NewLHRequest()
 	for i := 0; i < 100; i++ {
@@ -0,0 +1,221 @@
+package liquidhandling
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+// An OutputLayoutRecord records where mixes were placed on an output plate
+// according to an output layout, so that analysis can undo the placement
+type OutputLayoutRecord struct {
+	PlateID string             // the output plate
+	Layout  wtype.OutputLayout // the layout used, including its seed
+	Mixes   []OutputLayoutMix  // where each mix went in the order the mixes are made
+}
+
+// An OutputLayoutMix records where one mix was placed by an output layout
+type OutputLayoutMix struct {
+	Well        string // the well of the mix
+	Block       int    // the block of the mix, counting from 1, if the layout uses blocks
+	ComponentID string // the ID of the liquid made by the mix
+	Name        string // the name of the liquid made by the mix
+}
+
+// setupOutputLayouts checks the output layouts of the request and chooses
+// the seed for random layouts which don't set their own
+func (request *LHRequest) setupOutputLayouts() error {
+	if err := request.Options.OutputLayout.Validate(); err != nil {
+		return err
+	}
+	for _, ins := range request.LHInstructions {
+		if ins.OutputLayout != nil {
+			if err := ins.OutputLayout.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	// keep seeds small enough to be represented exactly in any JSON reader
+	if request.Options.OutputLayout.Seed == 0 {
+		request.Options.OutputLayout.Seed = time.Now().UnixNano()%math.MaxInt32 + 1
+	}
+	return nil
+}
+
+// outputLayoutFor returns the output layout for the instruction
+func (request *LHRequest) outputLayoutFor(ins *wtype.LHInstruction) wtype.OutputLayout {
+	layout := request.Options.OutputLayout
+	if ins != nil && ins.OutputLayout != nil {
+		layout = *ins.OutputLayout
+		if layout.Seed == 0 {
+			layout.Seed = request.Options.OutputLayout.Seed
+		}
+	}
+	if !layout.IsRandom() {
+		layout.Seed = 0
+	}
+	return layout
+}
+
+// skipsOuterWells returns true if the layout leaves the outer wells of the
+// plate empty. Plates without inner wells, such as troughs, are filled as
+// usual.
+func skipsOuterWells(plate *wtype.Plate, layout wtype.OutputLayout) bool {
+	return layout.SkipOuterWells && plate.WellsX() > 2 && plate.WellsY() > 2
+}
+
+func isOuterWell(plate *wtype.Plate, wc wtype.WellCoords) bool {
+	return wc.X == 0 || wc.Y == 0 || wc.X == plate.WellsX()-1 || wc.Y == plate.WellsY()-1
+}
+
+// outputCapacity returns how many of the assigned mixes fit on the plate
+func (request *LHRequest) outputCapacity(plate *wtype.Plate, assigned []string) int {
+	for _, id := range assigned {
+		if ins, ok := request.LHInstructions[id]; ok && skipsOuterWells(plate, request.outputLayoutFor(ins)) {
+			return (plate.WellsX() - 2) * (plate.WellsY() - 2)
+		}
+	}
+	return plate.Nwells
+}
+
+// freeOutputWells returns the empty wells of the plate which the layout may
+// use, in the order of the iterator
+func freeOutputWells(plate *wtype.Plate, it wtype.AddressIterator, layout wtype.OutputLayout) []wtype.WellCoords {
+	var ret []wtype.WellCoords
+	n := 0
+	for wc := it.Curr(); it.Valid() && n < plate.Nwells; wc = it.Next() {
+		n++
+		if skipsOuterWells(plate, layout) && isOuterWell(plate, wc) {
+			continue
+		}
+		if well, ok := plate.WellAt(wc); ok && well.IsEmpty() {
+			ret = append(ret, wc)
+		}
+	}
+	return ret
+}
+
+// treatmentOf identifies mixes which are replicates of each other by what
+// goes into them
+func treatmentOf(ins *wtype.LHInstruction) string {
+	parts := make([]string, 0, len(ins.Inputs))
+	for _, c := range ins.Inputs {
+		parts = append(parts, fmt.Sprintf("%s:%s:%s", c.CName, c.Volume(), c.TotalVolume()))
+	}
+	return strings.Join(parts, ",")
+}
+
+// levelOf returns the volume of the named component in the mix
+func levelOf(ins *wtype.LHInstruction, factor string) string {
+	for _, c := range ins.Inputs {
+		if c.CName == factor {
+			return fmt.Sprintf("%s:%s", c.Volume(), c.TotalVolume())
+		}
+	}
+	return ""
+}
+
+// orderOutputs returns the order in which the mixes, given in the order they
+// are made, are placed in the free wells of a plate, and the block of each
+// mix if the layout uses blocks
+func orderOutputs(layout wtype.OutputLayout, mixes []*wtype.LHInstruction) (order []int, blocks []int) {
+	// replicate[i] counts the earlier mixes with the same treatment as i
+	replicate := make([]int, len(mixes))
+	seen := make(map[string]int, len(mixes))
+	for i, ins := range mixes {
+		t := treatmentOf(ins)
+		replicate[i] = seen[t]
+		seen[t]++
+	}
+
+	order = make([]int, len(mixes))
+	for i := range order {
+		order[i] = i
+	}
+
+	rng := rand.New(rand.NewSource(layout.Seed))
+
+	switch layout.Strategy {
+	case wtype.LayoutRandom:
+		order = rng.Perm(len(mixes))
+
+	case wtype.LayoutInterleave:
+		sort.SliceStable(order, func(i, j int) bool {
+			return replicate[order[i]] < replicate[order[j]]
+		})
+
+	case wtype.LayoutRandomBlocks:
+		// blocks are numbered in the order they are first used
+		blocks = make([]int, len(mixes))
+		numbers := make(map[string]int)
+		for i, ins := range mixes {
+			key := fmt.Sprint(replicate[i])
+			if layout.BlockFactor != "" {
+				key = levelOf(ins, layout.BlockFactor)
+			}
+			if _, ok := numbers[key]; !ok {
+				numbers[key] = len(numbers) + 1
+			}
+			blocks[i] = numbers[key]
+		}
+
+		sort.SliceStable(order, func(i, j int) bool {
+			return blocks[order[i]] < blocks[order[j]]
+		})
+
+		// shuffle the mixes within each block
+		for s := 0; s < len(order); {
+			e := s
+			for e < len(order) && blocks[order[e]] == blocks[order[s]] {
+				e++
+			}
+			block := append([]int(nil), order[s:e]...)
+			for k, p := range rng.Perm(len(block)) {
+				order[s+k] = block[p]
+			}
+			s = e
+		}
+	}
+
+	return order, blocks
+}
+
+// layoutOutputs places the mixes on the plate according to the layout,
+// marking the wells used and returning a record of where each went
+func layoutOutputs(plate *wtype.Plate, it wtype.AddressIterator, layout wtype.OutputLayout, mixes []*wtype.LHInstruction) (*OutputLayoutRecord, error) {
+	free := freeOutputWells(plate, it, layout)
+	if len(free) < len(mixes) {
+		return nil, wtype.LHError(wtype.LH_ERR_DIRE, fmt.Sprintf("too many assignments made to output plate \"%s\": %d mixes for %d wells", plate.Type, len(mixes), len(free)))
+	}
+
+	order, blocks := orderOutputs(layout, mixes)
+
+	record := &OutputLayoutRecord{
+		Layout: layout,
+		Mixes:  make([]OutputLayoutMix, len(mixes)),
+	}
+	for i, ins := range mixes {
+		if len(ins.Outputs) != 0 {
+			record.Mixes[i].ComponentID = ins.Outputs[0].ID
+			record.Mixes[i].Name = ins.Outputs[0].CName
+		}
+		if blocks != nil {
+			record.Mixes[i].Block = blocks[i]
+		}
+	}
+	for k, i := range order {
+		well, _ := plate.WellAt(free[k])
+		if err := markWellUsed(well); err != nil {
+			return nil, err
+		}
+		record.Mixes[i].Well = free[k].FormatA1()
+	}
+
+	return record, nil
+}
@@ -0,0 +1,148 @@
+package liquidhandling
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+)
+
+func getMixesForLayoutTest(names ...string) []*wtype.LHInstruction {
+	var ret []*wtype.LHInstruction
+	for _, name := range names {
+		c := wtype.NewLHComponent()
+		c.CName = name
+		c.Vol = 10.0
+		c.Vunit = "ul"
+		ins := wtype.NewLHMixInstruction()
+		ins.Inputs = []*wtype.Liquid{c}
+		out := c.Dup()
+		out.ID = wtype.GetUUID()
+		out.CName = name + "_mix"
+		ins.AddOutput(out)
+		ret = append(ret, ins)
+	}
+	return ret
+}
+
+func TestOrderOutputs(t *testing.T) {
+	mixes := getMixesForLayoutTest("a", "a", "b", "b", "c", "c")
+
+	if order, _ := orderOutputs(wtype.OutputLayout{Strategy: wtype.LayoutInterleave}, mixes); !reflect.DeepEqual(order, []int{0, 2, 4, 1, 3, 5}) {
+		t.Errorf("expected replicates to be interleaved, got %v", order)
+	}
+
+	random := wtype.OutputLayout{Strategy: wtype.LayoutRandom, Seed: 42}
+	order, _ := orderOutputs(random, mixes)
+	if again, _ := orderOutputs(random, mixes); !reflect.DeepEqual(order, again) {
+		t.Errorf("expected the same order from the same seed, got %v and %v", order, again)
+	}
+	sorted := append([]int(nil), order...)
+	sort.Ints(sorted)
+	if !reflect.DeepEqual(sorted, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("expected a permutation of the mixes, got %v", order)
+	}
+
+	order, blocks := orderOutputs(wtype.OutputLayout{Strategy: wtype.LayoutRandomBlocks, Seed: 42}, mixes)
+	if !reflect.DeepEqual(blocks, []int{1, 2, 1, 2, 1, 2}) {
+		t.Fatalf("expected each replicate to form a block, got %v", blocks)
+	}
+	for k, i := range order {
+		if e := k/3 + 1; blocks[i] != e {
+			t.Errorf("expected mix %d placed %d to be in block %d, got %d", i, k, e, blocks[i])
+		}
+	}
+}
+
+func TestLayoutOutputsSkipOuterWells(t *testing.T) {
+	plate := GetPlateForTest()
+	mixes := getMixesForLayoutTest("a", "b", "c")
+	layout := wtype.OutputLayout{Strategy: wtype.LayoutRandom, Seed: 7, SkipOuterWells: true}
+
+	record, err := layoutOutputs(plate, columnWiseIterator(plate), layout, mixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if record.Layout != layout {
+		t.Errorf("expected layout %v to be recorded, got %v", layout, record.Layout)
+	}
+	if len(record.Mixes) != len(mixes) {
+		t.Fatalf("expected %d mixes to be recorded, got %d", len(mixes), len(record.Mixes))
+	}
+	used := make(map[string]bool)
+	for i, mix := range record.Mixes {
+		if out := mixes[i].Outputs[0]; mix.ComponentID != out.ID || mix.Name != out.CName {
+			t.Errorf("expected mix %d to make %s (%s), got %s (%s)", i, out.CName, out.ID, mix.Name, mix.ComponentID)
+		}
+		if mix.Block != 0 {
+			t.Errorf("expected no block for mix %d without blocks, got %d", i, mix.Block)
+		}
+		wc := wtype.MakeWellCoords(mix.Well)
+		if isOuterWell(plate, wc) {
+			t.Errorf("mix placed in outer well %s", mix.Well)
+		}
+		if w, _ := plate.WellAt(wc); w.IsEmpty() {
+			t.Errorf("well %s not marked as used", mix.Well)
+		}
+		used[mix.Well] = true
+	}
+	if len(used) != len(mixes) {
+		t.Errorf("expected %d distinct wells, got %v", len(mixes), record.Mixes)
+	}
+
+	if capacity := (&LHRequest{}).outputCapacity(plate, nil); capacity != plate.Nwells {
+		t.Errorf("expected capacity of %d without a layout, got %d", plate.Nwells, capacity)
+	}
+}
+
+func TestSummarizeOutputLayouts(t *testing.T) {
+	plate := GetPlateForTest()
+	mixes := getMixesForLayoutTest("a", "a", "b", "b")
+	layout := wtype.OutputLayout{Strategy: wtype.LayoutRandomBlocks, Seed: 3}
+
+	record, err := layoutOutputs(plate, columnWiseIterator(plate), layout, mixes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.PlateID = plate.ID
+
+	props := GetLiquidHandlerForTest(GetContextForTest()).Properties
+	bs, err := SummarizeDeviceLayout("", props, props, map[string]string{}, nil, []OutputLayoutRecord{*record}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summary struct {
+		OutputLayouts []*outputLayoutSummary `json:"output_layouts"`
+	}
+	if err := json.Unmarshal(bs, &summary); err != nil {
+		t.Fatal(err)
+	} else if len(summary.OutputLayouts) != 1 {
+		t.Fatalf("expected 1 output layout in the summary, got %d", len(summary.OutputLayouts))
+	}
+
+	wells := summary.OutputLayouts[0].Wells
+	if len(wells) != len(mixes) {
+		t.Fatalf("expected %d wells in the summary, got %d", len(mixes), len(wells))
+	}
+	for i, ws := range wells {
+		mix := record.Mixes[i]
+		wc := wtype.MakeWellCoords(mix.Well)
+		expected := outputLayoutWellSummary{
+			Row:         wc.Y,
+			Column:      wc.X,
+			Block:       mix.Block,
+			ComponentID: mixes[i].Outputs[0].ID,
+			Name:        mixes[i].Outputs[0].CName,
+		}
+		if *ws != expected {
+			t.Errorf("mix %d: expected %+v, got %+v", i, expected, *ws)
+		}
+		if mix.Block == 0 {
+			t.Errorf("mix %d: expected a block", i)
+		}
+	}
+}
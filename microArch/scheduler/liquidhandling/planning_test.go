@@ -15,6 +15,8 @@ import (
 	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/antha/anthalib/wunit"
 	"github.com/antha-lang/antha/microArch/driver/liquidhandling"
+	"github.com/antha-lang/antha/microArch/simulator"
+	simulator_lh "github.com/antha-lang/antha/microArch/simulator/liquidhandling"
 )
 
 type PlanningTest struct {
@@ -426,6 +428,48 @@ func ActionsSummaryAssertion(path string) Assertion { //nolint
 	}
 }
 
+// SimulationAssertion run the generated instructions through the physical
+// simulator and check that it reports no errors and no warnings about
+// aspirates, such as liquid being taken from the residual volume of a well.
+// Setup instructions are added to the request, so this should come after any
+// assertions which count instructions
+func SimulationAssertion() Assertion {
+	return func(t *testing.T, lh *Liquidhandler, request *LHRequest) {
+		if err := lh.AddSetupInstructions(request); err != nil {
+			t.Fatal(err)
+		}
+
+		settings := simulator_lh.DefaultSimulatorSettings()
+		settings.EnablePipetteSpeedWarning(simulator_lh.WarnNever)
+		settings.EnableAutoChannelWarning(simulator_lh.WarnNever)
+		settings.EnableTipboxCollision(false)
+		vlh, err := simulator_lh.NewVirtualLiquidHandler(lh.Properties.DupKeepIDs(), settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tris := make([]liquidhandling.TerminalRobotInstruction, 0, len(request.Instructions))
+		for i, ins := range request.Instructions {
+			tri, ok := ins.(liquidhandling.TerminalRobotInstruction)
+			if !ok {
+				t.Fatalf("instruction %d not terminal", i)
+			}
+			tris = append(tris, tri)
+		}
+		if err := vlh.Simulate(tris); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, err := range vlh.GetErrors() {
+			if err.Severity() >= simulator.SeverityError {
+				t.Error(err)
+			} else if lhErr, ok := err.(simulator_lh.LiquidhandlingError); ok && lhErr.Instruction().Type() == liquidhandling.ASP {
+				t.Error(err)
+			}
+		}
+	}
+}
+
 type TestMixComponent struct {
 	LiquidName    string
 	VolumesByWell map[string]float64
@@ -0,0 +1,78 @@
+package liquidhandling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+)
+
+// An Uncovering is the removal of a seal or lid from a plate so that the
+// liquid handler can access its wells
+type Uncovering struct {
+	Plate *wtype.Plate
+	Cover wtype.PlateCover
+}
+
+func (u Uncovering) String() string {
+	if u.Cover == wtype.Sealed {
+		return fmt.Sprintf("peel the seal from plate %q", u.Plate.PlateName)
+	}
+	return fmt.Sprintf("remove the lid from plate %q", u.Plate.PlateName)
+}
+
+// uncoverPlates finds the plates accessed by the request that are covered.
+// Lids are always removed. Seals are only peeled if the request allows it,
+// otherwise the plan is rejected.
+func (request *LHRequest) uncoverPlates(ctx context.Context) error {
+	st := sampletracker.FromContext(ctx)
+
+	plates := make(map[string]*wtype.Plate)
+	for id, p := range request.InputPlates {
+		plates[id] = p
+	}
+	for id, p := range request.OutputPlates {
+		plates[id] = p
+	}
+
+	var ids []string
+	for id := range plates {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var uncoverings []Uncovering
+	for _, id := range ids {
+		plate := plates[id]
+		cover := plate.Cover
+		// The sample tracker records the latest change by any instruction
+		if c, ok := st.GetCoverOf(id); ok {
+			cover = c
+		}
+
+		switch cover {
+		case wtype.Uncovered:
+			continue
+		case wtype.Sealed:
+			if !request.Options.PeelSealedPlates {
+				return fmt.Errorf("cannot access plate %q because it is sealed: peel the plate first or allow the planner to peel sealed plates", plate.PlateName)
+			}
+		}
+
+		uncoverings = append(uncoverings, Uncovering{
+			Plate: plate,
+			Cover: cover,
+		})
+	}
+
+	// Only change the plates once the plan is known to be feasible
+	for _, u := range uncoverings {
+		u.Plate.Cover = wtype.Uncovered
+		st.SetCoverOf(u.Plate.ID, wtype.Uncovered)
+	}
+	request.Uncoverings = uncoverings
+
+	return nil
+}
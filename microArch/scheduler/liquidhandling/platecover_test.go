@@ -0,0 +1,52 @@
+package liquidhandling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+)
+
+func TestUncoverPlates(t *testing.T) {
+	ctx := sampletracker.NewContext(context.Background())
+	st := sampletracker.FromContext(ctx)
+
+	lidded := GetPlateForTest()
+	lidded.PlateName = "lidded"
+	lidded.Cover = wtype.Lidded
+
+	sealed := GetPlateForTest()
+	sealed.PlateName = "sealed"
+	st.SetCoverOf(sealed.ID, wtype.Sealed)
+
+	open := GetPlateForTest()
+	open.PlateName = "open"
+
+	request := NewLHRequest()
+	request.InputPlates[lidded.ID] = lidded
+	request.InputPlates[open.ID] = open
+	request.OutputPlates[sealed.ID] = sealed
+
+	if err := request.uncoverPlates(ctx); err == nil {
+		t.Fatal("expected error accessing sealed plate")
+	}
+
+	request.Options.PeelSealedPlates = true
+	if err := request.uncoverPlates(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(request.Uncoverings); n != 2 {
+		t.Fatalf("expected %d uncoverings found %d", 2, n)
+	}
+	for _, u := range request.Uncoverings {
+		if u.Plate == open {
+			t.Errorf("open plate should not be uncovered")
+		} else if u.Plate.IsCovered() {
+			t.Errorf("plate %q is still %s", u.Plate.PlateName, u.Plate.Cover)
+		} else if cover, _ := st.GetCoverOf(u.Plate.ID); cover != wtype.Uncovered {
+			t.Errorf("plate %q is still %s in sample tracker", u.Plate.PlateName, cover)
+		}
+	}
+}
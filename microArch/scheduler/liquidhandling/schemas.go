@@ -1,7 +1,7 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
 // schemas/actions.schema.json (15.986kB)
-// schemas/layout.schema.json (8.11kB)
+// schemas/layout.schema.json (12.674kB)
 
 package liquidhandling
 
@@ -90,7 +90,7 @@ func actionsSchemaJson() (*asset, error) {
 	return a, nil
 }
 
-var _layoutSchemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x59\x5b\x6f\xdb\xb6\x17\x7f\x96\x3f\x05\xa1\x16\xc8\xc3\xdf\x89\xd3\x7f\x1f\x86\xe5\xad\x40\x5f\xba\x0d\x6b\xb1\x0e\xdb\x43\x90\x05\xb4\x74\x14\xb3\x95\x48\x95\xa4\xe2\xb8\x85\xbf\xfb\x70\x48\x4a\x22\x25\x4a\xbe\xb4\x1d\x30\x2c\xc0\x66\x59\x3e\x97\xdf\xb9\xf2\x1c\xf6\xcb\x22\x49\x9f\xb3\x3c\xbd\x21\xe9\x46\xeb\x5a\xdd\xac\x56\x94\xeb\x0d\xbd\xca\x44\xb5\x2a\xe9\x4e\x34\xfa\x52\x65\x1b\xa8\x68\xba\x44\x52\xf7\xec\xc8\x6f\x56\xab\x0f\x4a\x70\x47\x71\x25\xe4\xc3\x2a\x97\xb4\xd0\x97\xd7\x3f\xac\xec\xbb\x67\x86\x2d\x07\x95\x49\x56\x6b\x26\x38\xb2\xfe\xf4\xfe\xed\xaf\xe4\xbd\xf9\x9d\x14\x42\x92\x1c\xb2\x8f\xc4\x2a\x23\x3e\x29\xb2\xea\x5d\x0d\xc8\x23\xd6\x1f\x20\xd3\xe6\x95\x84\x4f\x0d\x93\x80\xa0\x6f\xd3\x35\x14\x42\x42\xba\x24\x29\x2d\x34\x48\x7c\xe0\xb0\xbd\x67\xb9\xc2\xc7\x47\x90\x0a\x25\xdd\x21\x5f\x2d\x45\x0d\x52\x33\x50\xe9\x0d\xf9\xb2\x20\xee\xaf\x23\xf2\x5f\x9a\x1f\x06\xb0\xf5\x06\x88\xa3\x25\xa2\x20\x7a\xc3\x14\x51\xbd\x15\x8f\xb4\x64\x39\x75\xc0\x03\x39\x99\xe0\x4a\xa3\x84\x17\x57\xd7\x69\xf7\xd3\x7e\xb9\x48\x92\x16\x3f\xea\x4e\x92\x24\x7d\x2e\xa1\x40\xca\x67\xab\x1c\x0a\xc6\x19\x8a\x53\x2b\x74\xd0\xfb\xa6\xaa\xa8\xdc\xa5\x8b\x24\xb1\x9c\xd6\xde\x33\x18\x5b\xff\xb4\xac\x63\x17\x27\xa3\x90\x55\xb4\x56\xa4\x90\xa2\x22\xe8\x05\x4b\x49\xde\xbc\x56\x84\x71\x72\x61\x6d\xb8\x20\x5a\x90\x0b\x83\xea\xc2\x09\xa1\x79\x6e\x80\xd0\xf2\x5d\xe8\xfb\xc4\xd7\xab\xb4\x64\xfc\xc1\xb2\x8c\x14\xbf\xe2\xe4\xcd\x6b\xa3\xc5\x49\x46\xaa\xfd\xc2\xfc\xb7\xb7\xb9\xd5\x99\xeb\x44\xa7\xbe\xd5\xa7\x18\xf9\xda\x7c\x5d\x83\x32\x46\xba\x84\x14\x05\xa1\xa4\x64\x9f\x1a\x96\x6f\x28\xcf\x4b\xc6\x1f\x6c\xc2\x52\x4d\x28\x79\x60\x8f\xc0\x49\x2d\x18\xd7\x08\x52\xb3\x0a\x9c\xec\x20\x4b\x6b\xa1\x1c\xc4\x3b\xfb\x6b\x1d\xf1\x47\x4f\xd4\xbe\x8a\xc3\x1e\x03\xff\x19\x76\x8a\x50\x09\x06\x37\xa7\x15\x28\x84\xdd\xc9\x23\x82\x9b\x5f\x0c\x6c\x93\xb9\x10\xb3\xa8\x93\x3e\x1b\xb6\xd9\x5c\x7b\xe7\x54\xa6\x96\x74\xbf\xe8\xfe\xbf\xef\xd2\x2f\xa0\x3b\x25\x3c\xb4\xb3\xa8\x35\x68\xd2\x88\xb8\xf7\xb1\x23\x28\xf6\x19\x8e\x09\x82\x17\x83\xb8\xb9\x99\x10\x32\x67\x9c\x6a\x50\x2f\xad\xb9\x7b\x97\xc1\x46\xc5\x09\xec\xff\x0f\xd9\x99\x86\xea\x70\x06\x08\x0e\x6f\x51\xec\x6d\x1b\x95\x29\x45\x75\x49\x35\xb8\x78\xb4\x3a\x92\xe4\x10\x97\x66\xf5\x5a\x3c\x9d\xc3\xb6\xa5\xca\xd3\x67\x3f\xef\xbc\x3c\x98\xed\x0c\x05\x2d\x15\x74\x99\x12\xf8\xe8\x94\x4c\xf9\x7d\x2b\x48\xce\x2a\xe0\xca\xa8\x20\x9e\xa0\x58\x7e\x3c\xdd\x57\x15\xe6\xc6\x0e\x3f\x67\x72\xc3\xd0\x8d\x23\xc3\x9b\x6a\x8d\x27\x4f\xbc\x36\x9f\x3c\xed\xd8\x22\xaa\x2a\x0c\xf7\xee\x1c\xa1\xbb\x29\xa1\x5f\xe1\xe2\x97\xa7\xb9\x78\x23\x01\xfe\xa3\x4e\x76\x42\x3f\x9f\x23\xf4\xf3\xb7\x8a\x9c\xdf\x26\xe2\x11\x0b\xdc\xcf\x72\x33\x1a\xd1\xca\xcc\x4a\x86\x7e\x89\xc7\x3a\x6f\x0a\x9a\xe9\x46\xda\xd1\x29\x98\xbe\x48\x2a\xc5\xd6\x8c\x51\x99\x28\x9b\x8a\x9b\xc7\x2e\xe2\xe6\xdb\x16\xca\xf2\x3e\xf2\x4a\x69\x2a\x75\xf7\xcd\x68\x9b\x09\xb9\x99\x3f\x87\x6e\x0c\xa6\x82\x48\xfa\x01\x69\x38\xfb\xd4\x00\x8e\x07\xee\x4c\x73\xd6\x07\x41\x32\x16\x9f\x23\x1d\x19\xbb\x31\xaf\xa6\x52\xb3\xac\x29\xa9\x8c\x2a\x71\x52\x4f\x57\x82\x94\xa1\x26\x2b\x7e\x49\xe0\xea\xe1\x8a\x5c\xd4\x99\x34\xfd\xfb\x22\xd4\x17\x84\xed\xeb\x8c\x03\xe2\x0b\x1b\xc0\x08\xb5\x86\x72\x4e\x53\xfa\x8a\xe0\x29\xbd\xf3\xc7\xfb\x59\x5d\x26\xf3\x4e\xad\x2d\x63\x99\xa1\x40\xd9\x28\x02\x3f\x31\x05\x15\x11\x92\x68\x56\x9b\x79\xd5\x57\xda\x4a\xaa\x9a\x52\xb3\xba\xb4\x67\xea\x8b\xab\xeb\xee\x3d\xe3\xac\x6a\xb0\xd0\xae\xaf\xae\x03\x84\x6d\x4d\x7c\x1d\x48\x27\xe5\x3b\xe2\xf4\xaa\xb3\x87\x1a\xc1\x84\x53\xcb\x30\x24\xcb\x33\x27\xa0\x61\x5b\x38\x52\x31\x1c\xe7\x83\xb3\xd0\xd8\x8e\x34\x0b\xa4\x9f\x2e\x2d\x98\x0c\xb8\x6e\x4b\x02\x48\x53\xd7\x20\x49\x09\x85\xbe\xac\x84\xd2\x06\xaa\x43\xfa\x4d\x81\x0e\x7a\xc9\x44\xd3\x58\xa2\x6a\x49\xf5\x06\xa4\x41\xa7\x36\xd4\xbc\xf4\xdd\xd8\xa2\xb2\x13\xe0\x72\xbe\x5e\x81\x9b\xec\xb9\x4d\xb3\x5d\xc9\x78\x6e\x8f\x83\xac\x59\x0b\x96\xa7\x77\xb1\x31\xde\x4a\x6d\x0f\xa0\x01\xcc\xdf\xa0\x96\xa0\x80\xe3\x8a\x64\x08\xfd\x15\xa4\x5d\x0e\xcb\xd2\x4e\xb0\xc1\xb2\x8c\x7f\x5f\xc8\x84\xeb\xcc\x99\x47\x9c\xbb\x5a\x0f\x45\x4e\x94\x24\x49\x3f\x32\xee\x9d\x2a\x63\x84\x8d\x82\x1c\x17\xd6\x47\x90\xac\xd8\x19\x6c\xf6\x50\xec\x18\xba\xad\x7d\x38\x41\x0f\xe1\x76\x7b\xbe\x04\xc5\xf2\x86\x96\xf7\x8f\x58\xd5\x30\xba\x50\x18\x31\x0c\x30\x59\x36\xb2\xdd\xb0\x6c\x43\x32\xca\xb9\xd0\x64\x0d\x44\x42\x25\x1e\x21\xef\xd7\x6f\x57\x25\x45\xeb\xdc\x74\x1a\x93\x51\x13\x77\x66\x05\x54\x35\x12\x2a\xe0\x3a\x9d\xe4\xef\x26\x7f\x74\x87\x06\xae\x55\xe0\xd4\x9a\x6a\x0d\x92\x47\xd7\xc4\x24\x49\xff\xba\xbd\xbe\xfc\xf1\xee\x7f\xcf\x83\xb7\x91\xbd\xce\x76\xc1\xae\x09\xf6\x41\x98\xdc\x7f\x8e\xd0\x3e\xa9\x7f\x8c\xc0\x54\xbb\xb3\xcf\xaf\x21\xdc\xf1\xf1\xb9\xdd\xf2\x5d\x7f\x60\x36\x97\x2d\x6a\x1f\xd1\x64\xcd\xdb\x45\x35\xf5\x28\xf7\xfd\xf3\xde\xb7\xe9\xc0\xec\x37\x60\xee\x59\x8f\x61\x74\x6c\x2d\x53\x38\x20\x9a\x82\x59\x8e\x93\x98\x78\xd5\x7f\xd7\x55\xbf\xdb\x0f\x8f\x28\x7f\x4b\x39\x57\xff\xf3\x8b\xb2\xa9\x79\xbf\x4d\xfe\x63\x75\x3f\xb1\x03\xa7\x15\x53\x8a\xf1\x87\x7b\x3c\xa3\xe6\x54\xfd\xc2\x94\xb6\x57\x49\xfd\x4d\x0c\xd5\xae\xbc\xcd\x01\x47\x25\x10\x2c\x72\xe7\x2d\x0f\x44\x9b\xf5\x54\x4a\xba\xf3\xde\xa3\x37\x06\x45\x36\xd0\xfa\x27\xe6\xad\x77\xc6\x74\x63\x9e\x45\x8d\x8a\xbd\x94\x9d\x2e\xaf\x30\x3b\xa4\xd8\xba\x45\xc0\x0d\xf2\x33\x31\x70\xf3\xdb\xb0\x18\xe3\xe3\x91\x73\x69\x7c\xac\x69\xfd\x3d\x9c\x6d\xc6\x65\x83\xc0\xbe\xb7\xbe\x45\x44\xf3\x51\xf5\xba\x3f\xba\xfe\xa6\x6a\xcd\x5e\xaa\x1c\x57\x6d\x86\xf6\x5f\x5a\x6f\xe1\xe5\x51\x57\x71\x39\x53\x19\x95\x39\xe4\xe3\x9a\x9b\x8a\xf2\xfc\xd8\xdd\x09\xf4\x06\x4d\x20\x56\x7b\x2f\x62\x32\x4b\xa2\x29\x72\x66\x74\xdd\xd1\x30\x11\xdb\xf6\x4a\xba\xbb\x83\x46\xac\xed\x4c\x85\x47\x94\x0b\xee\xe1\x0b\x80\x6e\xf5\x17\xba\x6f\xee\x33\x4b\xf9\x60\x71\x8e\xb8\x33\x67\xaa\x2e\xe9\x2e\x58\x26\x9d\x35\x13\xc3\x66\x30\xed\x06\x40\xe6\x07\x5e\xa4\x24\x6e\x3a\x6a\x73\x3d\xae\xf0\x88\x51\x27\x09\x96\xb8\xaa\x16\x3c\x1c\x6b\xa2\x3b\x4a\xb3\xbe\xec\x69\x5d\x0b\xaf\xe8\x47\x5c\x0b\xec\x90\x3d\x61\x78\xd0\xbf\x47\xdd\x7b\xbc\x25\xab\x66\x4d\x3a\x45\x5d\x2a\x4e\x75\xe9\x78\x84\x33\xc1\x71\x75\x91\xb4\xbd\xc6\x69\x38\xd3\x7d\xdb\x9e\x68\xda\x83\x80\x4f\x4c\x49\xb1\x98\x07\xce\x89\x9d\x2d\x7e\xf4\xc3\xa1\x25\x84\x7a\x48\x79\x40\x7d\xb4\xf6\x51\xff\x8f\x37\x78\x0f\x95\xf1\xd7\x21\x30\x48\x64\x3a\x87\x3b\xcf\x47\xf8\x98\x22\xf0\x84\xd9\xaa\x20\x3f\xc6\x2b\xa3\xae\x77\xf8\x68\xd9\x1f\xf8\x17\x9b\x9e\xf4\xe4\xeb\x45\xbf\x64\x26\x7a\xd3\x2b\xe2\x11\x2d\xb1\xad\xb3\x8c\x96\xe5\xce\x6e\x26\x7f\xd8\xc2\x3e\xb6\x3d\x3d\xd2\xb2\x81\x41\xb2\x46\xfb\x92\x25\x9c\xad\x56\x43\xd2\xcd\x3d\x9e\x21\xc3\xea\x74\xa9\x11\xb4\x84\x30\xf8\x13\xf7\x8e\xc3\xc8\x7b\x4a\xa2\x71\x8f\x37\xc2\x13\x62\xb2\x48\xf6\x8b\xfd\xe2\xef\x00\x00\x00\xff\xff\x94\x3f\x47\xf0\xae\x1f\x00\x00")
+var _layoutSchemaJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xe5\x1a\x4b\x8f\xe3\xb6\xf9\x3c\xf3\x2b\x08\x67\x81\x39\xc4\xf3\xd8\xe6\x50\x34\xb7\x2d\x72\x49\x1b\x74\x83\x6e\x90\x1e\x16\x13\x83\x96\xe8\x31\x33\x92\xa8\x8a\x94\x3d\xde\x85\xff\x7b\xbe\x8f\x0f\x89\x94\x48\xd9\xf2\x6c\xfa\x40\x16\x41\x46\x96\xc8\xef\xfd\x26\x3f\x5f\x5f\x2d\xde\xf0\x7c\xf1\x2d\x59\x6c\x95\xaa\xe5\xb7\xf7\xf7\xb4\x52\x5b\x7a\x97\x89\xf2\xbe\xa0\x07\xd1\xaa\x5b\x99\x6d\x59\x49\x17\x4b\x5c\x6a\x9f\xed\x72\x58\xfd\xab\x14\x95\x5d\x71\x27\x9a\xa7\xfb\xbc\xa1\x1b\x75\xfb\xf0\xe7\x7b\xf3\xee\x2b\xbd\x2d\x67\x32\x6b\x78\xad\xb8\xa8\x70\xeb\xdf\x3e\xbc\xff\x07\xf9\xa0\xbf\x93\x8d\x68\x48\xce\xb2\x67\x62\x90\x11\x7f\x29\x6e\x55\x87\x9a\xe1\x1e\xb1\xfe\x95\x65\x4a\xbf\x6a\xd8\xbf\x5b\xde\x30\x24\xfa\xe3\x62\xcd\x00\x02\x5b\x2c\xc9\x02\xf0\xb2\x06\x1f\x2a\xb6\x5f\xf1\x5c\xe2\xe3\x8e\x35\x12\x21\x3d\xe2\xbe\xba\x11\x35\x6b\x14\x67\x12\x76\x7e\xbe\x26\xf6\x5f\xb7\xc8\x7f\xa9\x3f\x0c\xc8\x56\x5b\x46\xec\x5a\x22\x36\x44\x6d\xb9\x24\xb2\xe7\x62\x47\x0b\x9e\x53\x4b\x78\x00\x27\x13\x95\x54\x08\xe1\xed\xdd\xc3\xa2\xfb\x74\x04\x9a\xae\x1c\xfd\x88\xfb\x0a\x7e\xbe\x69\xd8\x06\x57\x7e\x75\x9f\xb3\x0d\xaf\x38\x82\x93\xf7\x28\xa0\x0f\x6d\x59\xd2\xe6\xb0\x80\x65\x66\xa7\xe1\xf7\x82\x8d\x39\xdb\xf1\xac\x47\xe9\x24\x2c\x55\xc3\xab\x27\x94\xf0\xd5\x55\x8c\xf5\x82\x83\xd8\x73\xb2\xa5\x55\x5e\xb0\x06\xd8\xa7\x8a\x80\x3c\x81\xfc\x52\x12\x5c\x80\xd2\xd5\xfc\x2f\x09\xdf\x90\xe7\x4a\xec\xab\x1e\x29\xdb\xd1\x5a\x98\xcf\x23\xcc\xb4\x69\xe8\x21\x8d\x78\x27\x8a\xb6\x64\x92\xd4\xa0\x73\x9e\x29\x96\x13\x25\x88\x83\xc7\xc8\xa6\x11\x25\xe2\xae\xc8\x9e\x15\x85\xd4\xc8\x4b\x91\xc3\x33\x98\x88\x01\xca\x15\x2b\xa5\x43\x9b\x92\x95\x4f\x21\xae\x3b\x76\xb4\x83\x5d\xd6\xad\x5a\x19\x0b\x95\x73\xc8\xdf\x6f\x59\xc3\x48\xc9\x5f\x80\xfc\x3d\x3e\xd6\x05\xcd\x80\x01\xb4\x20\x0d\x14\x5f\x28\xf8\xb8\x3e\x58\x07\x90\x44\x00\xcf\x5a\xbc\x95\x96\x2a\x90\x48\xdb\x42\x69\xb6\x68\x75\x98\xc3\x91\x41\xf1\x83\x86\x3b\x60\xc9\x20\x5b\x09\xa0\xb4\xe4\x9f\x42\xb5\xc4\x81\x99\x1d\xef\xfd\x0d\x1d\x34\xe7\x71\x43\xc9\xf4\x4e\x3b\x16\x4d\x49\x6b\x69\x74\xa7\x6d\x47\xaf\x24\xdf\x7f\x27\x09\xaf\xc8\x8d\xf1\x8a\x1b\x54\xf4\x8d\xb6\xf3\x1b\x0b\x84\xe6\xb9\xa6\x87\x16\x3f\x86\xde\x7c\x95\x32\xe5\x11\xe2\x77\x15\xa0\xd1\x58\x2c\xe4\x4e\x32\xd7\x9a\x9d\x85\xc7\xb5\x05\xbd\xf0\xfd\x68\x0e\x93\xdf\xe9\x9f\x6b\x66\x1c\xc4\x86\x38\x88\x1d\xd4\x7a\x93\x76\x26\x20\xd5\x84\x40\x70\x28\x4a\x9e\xf8\x0e\x4c\xb9\x16\xbc\x52\x48\x24\x88\x9b\x59\xd8\x41\xdc\xab\x85\xb4\x24\x3e\x9a\xaf\x75\x44\x1e\xfd\x22\xf7\x2a\x4e\xf6\x98\xf0\xbf\xb3\x83\x24\x14\x0c\x16\xe9\xae\x28\xba\x1f\x90\xdd\xc1\x43\x03\x36\xc6\x09\x64\xeb\x58\xc8\x62\x1c\x75\xd0\x27\xd5\x36\x19\xbd\x7e\xb4\x28\x17\x66\xe9\xf1\xba\xfb\xff\xd1\x0b\x68\xde\xba\x39\xea\xa1\x1d\x47\x8e\xa1\x24\x13\x71\xe9\x63\x8e\x91\xfc\x13\x3b\x47\x09\x9e\x0e\xe2\xec\x66\x42\x34\x39\xaf\x30\x1e\x7c\x63\xd8\x3d\x5a\x0b\xd6\x28\x66\x6c\xff\x53\xb8\x1d\x83\xc5\x69\x0b\x10\x15\x7b\x8f\x60\x3f\x3a\xad\xa4\x10\xe9\x90\x65\xf5\xe1\x70\x5c\x5d\x9d\xda\xa5\x78\xbd\x16\x2f\x97\x6c\xdb\x53\xe9\xe1\x33\x7f\x1f\x3d\x3b\x98\x8c\x0c\x1b\x5a\x48\xd6\x59\x4a\x20\xa3\x39\x96\xf2\xd3\x5e\x90\x1c\x5c\xb1\x92\x1a\x05\xf1\x00\xc5\xec\xe3\x65\x55\x96\x68\x1b\x07\xfc\x3b\x61\x1b\x7a\xdd\x58\x33\x55\x5b\xae\xb1\x96\x89\xfb\xe6\x8b\x87\x1d\x43\x04\x80\x08\xd4\x7d\xb8\x04\xe8\x21\x05\xf4\x15\x22\xfe\x66\x9e\x88\xb7\x0d\x63\x7f\x50\x21\x5b\xa0\x9f\x2e\x01\xfa\xe9\x4b\x69\xce\x0f\x13\x71\x8d\x05\xe2\x87\xae\x01\x8b\x6d\x5a\xea\xea\x5b\xaf\x5f\x62\x5a\xaf\xda\x0d\xcd\x54\xdb\x98\x62\x3c\xa8\xe7\xc9\xa2\x11\x7b\x5d\x98\x67\x58\xd3\x55\xfa\xb1\xd3\xb8\xfe\x85\x35\xdc\x2a\xf2\x4a\x2a\xda\xa8\xee\x97\xc6\x36\xa1\x72\xdd\xd1\x0c\xc5\x18\x54\x05\x11\xf3\x63\xa4\xad\x20\xfc\x33\x2c\x0f\x6c\x4e\xb3\xdc\x07\x4a\xd2\x1c\x5f\x02\x1d\x37\x76\x8d\x43\x0d\xec\xf0\xac\x2d\x68\x13\x45\x62\xa1\xce\x47\x82\x2b\x43\x4c\x06\xfc\x92\xb0\xbb\xa7\x3b\x72\x53\x67\x8d\x8e\xdf\x37\x21\xbe\x40\x6d\xaf\x63\x0e\xea\x5d\x0f\xd8\x80\x8c\x10\x6b\x08\x67\x1e\xd2\x77\xba\x13\x39\xf8\x0d\xe3\x24\x2e\x6d\x79\x73\x7d\x4b\x73\xa6\x57\x20\x6c\x04\x81\x7f\x75\x9f\x41\xa0\xe5\x83\xe4\xa4\xeb\x55\x1f\xa9\x83\x54\x42\xd1\xce\xeb\xc2\xe4\x54\x68\xfc\xba\xf7\x90\xda\xca\x16\x1d\xed\xe1\xee\x21\xa0\xd0\xf9\xc4\xeb\x88\xb4\x50\x7e\x47\x3a\x3d\xef\xec\x49\x8d\xd0\x84\x55\xcb\x50\x25\xcb\x0b\x2b\xa0\x61\x58\x38\x13\x31\x3b\x4f\x06\x17\x51\x63\x22\xd2\x24\x21\x7d\x75\x69\x88\xc9\x58\xa5\x9c\x4b\x40\xb4\xa9\x21\x6e\x91\x82\x6d\xd4\x6d\x29\xa4\xd2\xa4\x5a\x4a\xbf\x28\xa1\x83\x58\x92\x08\x1a\x4b\x44\x0d\xed\xaf\x69\x3e\x41\x88\x5b\xaa\x5f\xfa\x62\x74\x54\x99\x0a\x70\x39\xed\xaf\xac\xd2\xd6\xf3\x71\x91\x1d\xa0\x96\xce\x4d\x3a\xc8\xda\xb5\x80\xe0\xfc\x18\x2b\xe3\x0d\x54\x97\x80\x06\x64\xfe\x93\x41\xfb\x2f\x41\x7e\xd0\x22\xe9\x85\x7e\x0b\xe2\x9a\xc3\xa2\x30\x15\x6c\x30\x7e\xc1\x7f\x9f\x49\x42\x74\x3a\xe7\x11\x2b\x2e\x27\xa1\x3a\xda\xa3\x3c\x03\x13\xde\xef\x11\x85\xad\x34\x93\x89\x1d\x6b\xf8\xe6\xa0\x69\x33\x49\xb1\xdb\xd0\xcd\x81\x86\x15\x34\x49\xfc\x83\x84\x2b\x79\xde\xd2\x62\x65\x66\x20\xa3\x11\xd5\x68\xc3\x80\x26\xb3\x8d\xec\xb7\x3c\xdb\x92\x8c\x56\x95\x50\x64\xcd\x48\xc3\x4a\xb1\x03\x6a\xbb\xf6\xdb\x7a\xc9\xc6\x09\x77\xb1\x9c\x46\x13\x17\x66\xc9\xa8\x84\x98\x0f\x4e\xaa\x16\xc9\xfd\x5d\xe5\x8f\xe2\x50\xb0\x54\x06\x42\xad\xa9\x02\x17\xa9\xa2\x6d\x22\x7c\xfe\xe5\xe3\xc3\xed\x5f\x1e\xbf\x7e\x13\xbc\x8d\xf4\x75\x26\x0a\x76\x41\xb0\x57\x42\xb2\xff\x39\x03\x7b\x12\x7f\x7c\x6e\xe5\xf8\xf3\x7d\x08\x7b\x7c\x7c\x76\x5d\xbe\x8d\x0f\xdc\xd8\xb2\xa1\xda\xa7\x28\x3d\x8e\xd1\x8d\xea\xc2\x5b\x79\xec\x9f\x8f\x3e\x4f\x27\x6a\xbf\xc1\xe6\x7e\xeb\x39\x1b\xed\x36\xb7\x29\x2c\x10\xb5\xc3\x2c\xc7\x46\x4c\x3c\xef\x7f\xec\xbc\xdf\xf6\x87\x67\xb8\xbf\x59\x39\xe5\xff\xd3\x8d\xb2\xf6\x79\x3f\x4c\xfe\xc7\xfc\x3e\xd1\x03\x43\xae\x95\x12\x42\xe7\x0a\x73\xd4\x14\xaa\x1f\xb8\x54\x66\x94\xd4\x4f\x62\xc0\xa0\x8c\x7b\xeb\x04\x87\x23\x1b\x74\x72\x2b\x2d\x8f\x88\xc8\xb4\xf2\x6a\x3c\x49\x8c\x61\xfd\x17\xda\xad\x97\x63\xba\x32\xcf\x50\x8d\x88\x3d\x93\x4d\xbb\x57\x68\x1d\x50\x4c\xd9\x46\xc0\x16\xf2\x13\x3a\xb0\xf5\xdb\xd0\x19\xe3\xe5\xd1\x64\x59\x93\xaa\x6d\xc6\x6e\x83\x84\xfd\xde\xf8\xae\x23\x98\xcf\xf2\xd7\xe3\xd9\xfe\x97\xf2\x35\x33\x54\x39\xcf\xdb\xf4\xda\xff\x53\x7f\x0b\x87\x47\x9d\xc7\xe5\x5c\x66\xb4\xc9\x59\x3e\xf6\xb9\x94\x96\xa7\xcb\xee\x0e\xa0\x57\x68\x42\xc8\xd7\xd8\x7b\x10\x49\x2b\x89\x9a\xc8\x85\xda\xb5\xa9\x21\xa1\x5b\x37\x92\xee\x66\xd0\x48\xab\xab\xa9\x30\x45\x59\xe5\x9e\x1e\x00\x74\xad\xbf\x50\x7d\x70\x9f\x68\xca\x07\x8d\x73\x44\x9c\x20\x44\xa0\xe3\x10\x34\x93\x96\x9b\x44\xb1\x19\x36\xcd\x3e\x21\xd3\x05\x2f\xae\xb4\x07\x4b\x2e\x56\x26\x10\x9e\x51\xea\x84\x4d\x5c\x59\x8b\x2a\x2c\x6b\xa2\x3d\x4a\xbb\xbe\xed\xd7\xda\x10\x5e\xd2\x67\x6c\x0b\x4c\x91\x9d\x60\x3c\x88\xdf\xa3\xe8\x3d\xee\x92\x01\x11\xe9\x10\x75\xa6\x98\x8a\xd2\x71\x0d\x83\x43\x61\xeb\x62\x0f\xc7\xe0\x45\x0b\x72\xe8\xc3\x76\x22\x68\x0f\x14\x9e\xa8\x92\x62\x3a\x0f\x84\x13\xcb\x2d\xbe\xf6\xc3\xa2\x25\x24\xf5\x14\xf2\x60\xf5\xd9\xd8\x47\xf1\x3f\x1e\xe0\x3d\xaa\xb4\xbc\x4e\x11\x83\x8b\x74\xe4\xb0\xf9\x7c\x44\x1f\x18\x05\x7b\x41\x6b\x95\x2c\x3f\x47\x2a\xa3\xa8\x77\x3a\xb5\x1c\x4f\x9c\xd8\xf4\x4b\x67\x8f\x17\x63\x27\xc0\x11\xc7\xe8\x9d\x32\x79\xd8\x4b\x4d\x29\x9d\xb7\xc8\xee\xe0\xc8\xe6\xec\xe8\x85\x79\x6c\x85\xee\xb3\x32\x83\x4c\xaf\x1e\x59\xba\xc6\x49\x47\x37\x5e\xb2\xd5\xe4\x29\x5b\x00\xe9\x92\xd9\x59\x3f\x6f\x34\x51\xd8\x3b\x01\x1d\xcd\xb0\x66\x4f\x87\x10\x2c\xec\xf3\x9b\x90\xd7\xcd\xa8\x2e\xa2\xa0\xef\xc7\xbe\x00\x11\xa3\x08\x3f\x33\x46\x5b\x95\xce\xe5\x63\x0b\x52\x2c\x04\x16\xbc\xfd\x6d\x08\x97\xe7\xd1\x1e\x8d\x7b\x0a\x2c\x4f\xe0\xb5\x64\xe0\xbc\xb9\x5c\x9c\x23\xd3\xb2\x66\x95\x04\xcd\xc7\xcc\x67\x2d\x44\xc1\x68\x95\x22\x6a\xbf\x65\xdd\xc8\x46\x73\xde\xe7\x35\xbf\xed\x84\x42\x04\x3e\x67\x0d\x88\xc4\xb8\x94\x4b\x35\x78\x73\x45\xf3\x23\xa4\x1c\xcc\x8e\x68\x53\xa1\xe1\xce\xb5\x68\xc9\x14\xde\x55\x70\x40\x51\x2c\x92\x3f\x55\x7c\xc3\x33\x0a\x59\x76\xdd\x2a\xb0\x86\xb6\xc8\x89\x1d\x46\x78\xec\x23\x35\x17\x1e\x5e\x04\x77\x1d\x26\xc2\x8b\xbe\x80\x51\xda\x74\x6f\x2e\x66\x40\x64\xa5\xe1\x7d\x0c\xbc\x8e\x41\xdd\x71\xbd\xc4\xf0\xcb\x9e\x0e\x17\x47\x16\x33\x81\xf8\x9f\x08\x20\x1d\x2f\x73\xb1\xe8\xc3\x10\x28\x6f\x9b\x3e\x3f\x8d\x6e\xb5\x2c\xf1\x9b\x73\x74\xb3\x76\xa3\x95\x0c\x16\x31\x9e\x0a\x36\x10\xb0\x85\x3e\xbf\x33\x4f\x7f\x2d\x44\xf6\xac\xcf\x7b\x38\x0e\x49\xc1\xe6\x77\xcc\xd5\xb7\x8e\x7a\x16\xf5\x90\xd3\xd1\x07\x37\x12\xdd\x37\x80\x62\x0d\x3a\xa7\x56\xce\x64\x32\x12\x05\xc8\xd7\x48\xdf\x0a\x8f\x35\x44\x73\x91\xf8\xba\x92\x02\xe4\x07\x41\xc2\x79\x29\xcf\xe1\x15\x38\x87\xbd\x23\xa2\xd1\xa0\x36\x19\x35\x32\x5e\x92\x86\xd5\x05\xf8\x0e\x76\xde\x78\xd9\xaa\x5f\x26\x7d\x01\x87\x92\x7a\xe6\xf5\x0a\x8c\x97\x35\x2b\x63\x7d\xaf\x8a\x2b\x76\x3a\x68\x42\x1d\xcb\x9f\x58\x68\x6d\xda\x04\x70\x8c\x4d\x58\x59\xab\xc3\x78\x02\x1d\x43\x1f\x96\xb1\x11\x71\x99\x69\x78\x2f\x07\x17\x6a\x8d\x65\x99\xf9\x03\x1a\x20\x4e\x3c\x4a\x9a\xb3\x74\x49\x7c\x56\xa9\x1b\x1b\x47\xa4\xea\xda\xe1\x28\x62\xa2\x30\x3c\x3f\x19\x4f\x0f\x11\x4e\x56\x98\xc3\x71\xc5\x3c\x9a\xd2\xe9\xf9\x95\x64\x69\x3b\x7d\x05\x61\x9d\x3b\x58\x85\x63\xea\x00\x77\x81\x24\xac\x2b\xc1\xb7\x4b\x97\x6a\x5c\x64\x43\x37\x97\xd6\x3d\x66\xf3\xf0\x36\x21\x5a\xeb\xb8\x41\x78\x4e\x3b\x7f\x82\x95\x3e\x48\xdb\xda\x01\xad\x16\x03\x92\x65\x2d\xd6\xcd\x8c\x3b\xa8\x59\x28\xc7\x7d\x74\x1a\xe9\x25\xcd\xc2\x65\xa9\x3a\x72\x93\x70\x22\x61\x43\xc9\x02\xa1\xa2\x7a\xea\x92\xb6\xbd\x31\xe9\xdf\x40\x83\x7a\x06\xfc\xb8\xcd\xcc\x75\x35\x3c\xdb\x28\xbd\x86\x7e\xcb\x68\xae\xed\x04\x1d\x9e\x49\xd5\x5d\x0b\x3d\x9d\xca\x4d\x12\x5d\xc9\xee\x7e\xb1\x79\xd4\xa7\x27\x53\x19\xbd\xdb\x77\x49\xb6\x02\x12\x79\xa9\xcb\x21\x2c\x54\xbd\x5a\xb2\xe3\x86\x48\xa8\x98\xe0\x37\xd0\x81\xae\xb0\x66\x6a\xcf\x58\x65\x0f\x0b\x65\x1f\x29\xf9\x13\xc7\x7b\x34\x46\xe0\x67\x54\xa2\x8e\xc5\xff\x26\xd5\xb6\xea\xf2\x4b\x98\xd3\x84\x1b\x85\xcc\xbd\xe4\x18\x5e\x41\x1d\xde\x3d\xf5\x73\x9c\x1b\xd0\xe8\x54\x67\xce\xce\xc0\xe6\xe0\x33\x6f\x48\xc5\xf6\xfd\x38\xfe\xec\x1b\x8f\x09\x3f\x1e\xcf\x6f\xba\x33\x22\x1d\x0b\x5e\xe5\x78\x7e\x43\x94\xf0\xb8\x77\xc4\x5b\xb4\xc4\xc1\x2a\x54\x1e\x45\x71\x30\x67\x83\x3f\xdb\xae\xf8\x4c\xef\xd9\xd1\xa2\x65\x83\x71\x51\xd4\x5d\xcc\xc2\xc9\x79\x99\x5e\xd2\x25\x02\x8f\x91\x65\xdc\x52\x03\xf3\x08\xc7\x2f\x89\x9b\x3f\xc3\xd9\x8b\x87\x24\x3a\x79\x89\x8f\x22\x67\xe8\xe4\x1a\xfe\x3b\x5e\xff\x06\xd3\xc5\x13\xf7\x82\x31\x00\x00")
 
 func layoutSchemaJsonBytes() ([]byte, error) {
 	return bindataRead(
@@ -105,8 +105,8 @@ func layoutSchemaJson() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "layout.schema.json", size: 8110, mode: os.FileMode(0644), modTime: time.Unix(1553246720, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1d, 0x9e, 0x59, 0x28, 0x2a, 0x44, 0x5c, 0xb1, 0xc3, 0xd6, 0x5a, 0xf2, 0xeb, 0x2b, 0x69, 0xed, 0xf5, 0x8a, 0x34, 0x89, 0x50, 0xdc, 0xfe, 0xa, 0x6e, 0xf5, 0x86, 0x6e, 0x23, 0x1e, 0x87, 0xf8}}
+	info := bindataFileInfo{name: "layout.schema.json", size: 12674, mode: os.FileMode(0644), modTime: time.Unix(1553246720, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x20, 0xf0, 0x73, 0xb9, 0xaf, 0xcb, 0x9, 0xc2, 0x60, 0x5, 0x17, 0x32, 0x65, 0x96, 0x69, 0x1e, 0x99, 0x1a, 0x28, 0xea, 0xe, 0xf1, 0x3b, 0x23, 0xac, 0xf0, 0x7, 0xf6, 0x1c, 0xf2, 0x5a, 0x7d}}
 	return a, nil
 }
 
@@ -52,10 +52,23 @@ func validateJSON(schemaName string, jsonToValidate []byte) error {
 // initialToFinalIDs maps object ids in the inisial state to the final state
 // errors are returned if the json cannot be constructed or the result fails to validate
 func SummarizeLayout(initialState, finalState *driver.LHProperties, initialToFinalIDs map[string]string) ([]byte, error) {
+	return SummarizeDeviceLayout("", initialState, finalState, initialToFinalIDs, nil, nil, nil)
+}
+
+// SummarizeDeviceLayout is SummarizeLayout for the named liquid handler. The
+// name identifies the device in the summary when several liquid handlers are
+// used. Evaporation losses predicted by the planner, the placement of mixes
+// by output layouts and the rearrangement of plates to reduce head movement,
+// if any, are included.
+func SummarizeDeviceLayout(device string, initialState, finalState *driver.LHProperties, initialToFinalIDs map[string]string, evaporation []EvaporationLoss, outputLayouts []OutputLayoutRecord, layoutOptimization *driver.LayoutOptimization) ([]byte, error) {
 	ls := &layoutSummary{
-		Before: newDeckSummary(initialState),
-		After:  newDeckSummary(finalState),
-		IDMap:  initialToFinalIDs,
+		Device:             device,
+		Before:             newDeckSummary(initialState),
+		After:              newDeckSummary(finalState),
+		IDMap:              initialToFinalIDs,
+		Evaporation:        newEvaporationSummaries(evaporation),
+		OutputLayouts:      newOutputLayoutSummaries(outputLayouts),
+		LayoutOptimization: newLayoutOptimizationSummary(layoutOptimization),
 	}
 
 	if bs, err := json.Marshal(ls); err != nil {
@@ -156,9 +169,13 @@ func SummarizeActions(initialState *driver.LHProperties, itree *driver.ITree) ([
 
 // layoutSummary summarize the layout of the deck before and after the liquidhandling step
 type layoutSummary struct {
-	Before *deckSummary      `json:"before"`  // the layout before the liquidhandling takes place
-	After  *deckSummary      `json:"after"`   // the layout after the liquidhandling takes place
-	IDMap  map[string]string `json:"new_ids"` // maps from ids in "before" to ids in "after"
+	Device             string                     `json:"device,omitempty"`              // the liquid handler that performs the operation
+	Before             *deckSummary               `json:"before"`                        // the layout before the liquidhandling takes place
+	After              *deckSummary               `json:"after"`                         // the layout after the liquidhandling takes place
+	IDMap              map[string]string          `json:"new_ids"`                       // maps from ids in "before" to ids in "after"
+	Evaporation        []*evaporationSummary      `json:"evaporation,omitempty"`         // volumes predicted to evaporate from wells
+	OutputLayouts      []*outputLayoutSummary     `json:"output_layouts,omitempty"`      // where output layouts placed mixes
+	LayoutOptimization *layoutOptimizationSummary `json:"layout_optimization,omitempty"` // how plates were moved to reduce head movement
 }
 
 func (ls *layoutSummary) MarshalJSON() ([]byte, error) {
@@ -172,6 +189,106 @@ func (ls *layoutSummary) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// evaporationSummary summarize the volume predicted to evaporate from a well
+type evaporationSummary struct {
+	DeckItemID  string              `json:"deck_item_id"`
+	Row         int                 `json:"row"`
+	Column      int                 `json:"col"`
+	Volume      *measurementSummary `json:"volume"`
+	Time        float64             `json:"time_s"` // how long the well is exposed in seconds
+	Compensated bool                `json:"compensated,omitempty"`
+	Warning     string              `json:"warning,omitempty"`
+}
+
+// newEvaporationSummaries create summaries of evaporation losses
+func newEvaporationSummaries(losses []EvaporationLoss) []*evaporationSummary {
+	var ret []*evaporationSummary
+	for _, loss := range losses {
+		ret = append(ret, &evaporationSummary{
+			DeckItemID:  wtype.IDOf(loss.Well.GetParent()),
+			Row:         loss.Well.Crds.Y,
+			Column:      loss.Well.Crds.X,
+			Volume:      newMeasurementSummary(loss.Volume),
+			Time:        loss.Time.Seconds(),
+			Compensated: loss.Compensated,
+			Warning:     loss.Warning,
+		})
+	}
+	return ret
+}
+
+// outputLayoutSummary summarize where mixes were placed on a plate by an
+// output layout
+type outputLayoutSummary struct {
+	DeckItemID     string                     `json:"deck_item_id"`
+	Strategy       string                     `json:"strategy,omitempty"`
+	Seed           int64                      `json:"seed,omitempty"`
+	BlockFactor    string                     `json:"block_factor,omitempty"`
+	SkipOuterWells bool                       `json:"skip_outer_wells,omitempty"`
+	Wells          []*outputLayoutWellSummary `json:"wells"` // in the order the mixes are made
+}
+
+// outputLayoutWellSummary summarize where one mix was placed
+type outputLayoutWellSummary struct {
+	Row         int    `json:"row"`
+	Column      int    `json:"col"`
+	Block       int    `json:"block,omitempty"`
+	ComponentID string `json:"component_id,omitempty"` // the liquid made by the mix
+	Name        string `json:"name,omitempty"`
+}
+
+// newOutputLayoutSummaries create summaries of the placement of mixes
+func newOutputLayoutSummaries(records []OutputLayoutRecord) []*outputLayoutSummary {
+	var ret []*outputLayoutSummary
+	for _, r := range records {
+		ols := &outputLayoutSummary{
+			DeckItemID:     r.PlateID,
+			Strategy:       string(r.Layout.Strategy),
+			Seed:           r.Layout.Seed,
+			BlockFactor:    r.Layout.BlockFactor,
+			SkipOuterWells: r.Layout.SkipOuterWells,
+			Wells:          make([]*outputLayoutWellSummary, 0, len(r.Mixes)),
+		}
+		for _, mix := range r.Mixes {
+			wc := wtype.MakeWellCoords(mix.Well)
+			ols.Wells = append(ols.Wells, &outputLayoutWellSummary{
+				Row:         wc.Y,
+				Column:      wc.X,
+				Block:       mix.Block,
+				ComponentID: mix.ComponentID,
+				Name:        mix.Name,
+			})
+		}
+		ret = append(ret, ols)
+	}
+	return ret
+}
+
+// layoutOptimizationSummary summarize the rearrangement of plates to reduce
+// head movement
+type layoutOptimizationSummary struct {
+	Before float64           `json:"before_s"` // estimated time moving between objects in the original layout
+	After  float64           `json:"after_s"`  // estimated time moving between objects in the optimized layout
+	Moved  map[string]string `json:"moved"`    // the new position of each plate which was moved, by plate ID
+}
+
+// newLayoutOptimizationSummary create a summary of the layout optimization,
+// if there was one
+func newLayoutOptimizationSummary(opt *driver.LayoutOptimization) *layoutOptimizationSummary {
+	if opt == nil {
+		return nil
+	}
+	moved := opt.Moved
+	if moved == nil {
+		moved = make(map[string]string)
+	}
+	return &layoutOptimizationSummary{
+		Before: opt.Before.Seconds(),
+		After:  opt.After.Seconds(),
+		Moved:  moved,
+	}
+}
+
 // deckSummary summarize the layout of the deck
 type deckSummary struct {
 	Positions map[string]*deckPosition `json:"positions"` // map from position name to object description
@@ -44,6 +44,8 @@ type ChannelState struct {
 	position wtype.Coordinates3D //position relative to the adaptor
 	adaptor  *AdaptorState       //the channel's adaptor
 	radius   float64
+	//true if liquid has been dispensed from the tip since it was last filled with more left to dispense
+	multiDispensing bool
 }
 
 func NewChannelState(number int, adaptor *AdaptorState, position wtype.Coordinates3D, radius float64) *ChannelState {
@@ -79,6 +81,16 @@ func (self *ChannelState) GetContents() *wtype.Liquid {
 	return self.contents
 }
 
+//IsMultiDispensing true if some of the liquid aspirated into the tip has been dispensed and some remains
+func (self *ChannelState) IsMultiDispensing() bool {
+	return self.multiDispensing
+}
+
+//SetMultiDispensing set whether some of the liquid aspirated into the tip has been dispensed and some remains
+func (self *ChannelState) SetMultiDispensing(md bool) {
+	self.multiDispensing = md
+}
+
 func (self *ChannelState) GetRadius() float64 {
 	return self.radius
 }
@@ -135,12 +147,14 @@ func (self *ChannelState) Dispense(volume *wunit.Volume) error {
 //LoadTip
 func (self *ChannelState) LoadTip(tip *wtype.LHTip) {
 	self.tip = tip
+	self.multiDispensing = false
 }
 
 //UnloadTip
 func (self *ChannelState) UnloadTip() *wtype.LHTip {
 	tip := self.tip
 	self.tip = nil
+	self.multiDispensing = false
 	return tip
 }
 
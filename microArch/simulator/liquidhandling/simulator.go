@@ -837,6 +837,7 @@ func (self *VirtualLiquidHandler) Aspirate(volume []float64, overstroke []bool,
 			} else if c, err := wells[i].RemoveVolume(aspVol); err != nil {
 				self.AddErrorf("%s: unexpected well error \"%s\"", describe(), err.Error())
 			} else {
+				arg.adaptor.GetChannel(i).SetMultiDispensing(false)
 				err := tip.AddComponent(c)
 				if tipVol.LessThan(tip.MinVol.MinusEpsilon()) {
 					// ignore the error returned from AddComponent and add a warning instead
@@ -996,7 +997,8 @@ func (self *VirtualLiquidHandler) Dispense(volume []float64, blowout []bool, hea
 	//dispense
 	for _, i := range arg.channels {
 		v := wunit.NewVolume(volume[i], "ul")
-		tip := arg.adaptor.GetChannel(i).GetTip()
+		channel := arg.adaptor.GetChannel(i)
+		tip := channel.GetTip()
 
 		if wells[i] != nil {
 			if _, tw := wells[i].Plate.(*wtype.LHTipwaste); tw {
@@ -1006,7 +1008,11 @@ func (self *VirtualLiquidHandler) Dispense(volume []float64, blowout []bool, hea
 
 		if v.GreaterThan(tip.CurrentWorkingVolume()) {
 			v = tip.CurrentWorkingVolume()
-			if !blowout[i] {
+			if !blowout[i] && channel.IsMultiDispensing() {
+				//the aspirate didn't take up enough for all the dispenses made from it
+				self.AddErrorf("%s: tip on channel %d contains only %s after previous dispenses",
+					describe(), i, tip.CurrentWorkingVolume())
+			} else if !blowout[i] {
 				//a bit strange
 				self.AddWarningf("%s: tip on channel %d contains only %s, but blowout flag is false",
 					describe(), i, tip.CurrentWorkingVolume())
@@ -1017,6 +1023,7 @@ func (self *VirtualLiquidHandler) Dispense(volume []float64, blowout []bool, hea
 		} else if err := wells[i].AddComponent(c); err != nil {
 			self.AddErrorf("%s: unexpected well error \"%s\"", describe(), err.Error())
 		}
+		channel.SetMultiDispensing(!blowout[i] && tip.CurrentWorkingVolume().IsPositive())
 	}
 
 	return ret
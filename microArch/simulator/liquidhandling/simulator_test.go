@@ -2911,6 +2911,56 @@ func Test_Dispense(t *testing.T) {
 				"(warn) Dispense[1]: 150 ul of water from head 0 channel 0 to A1@plate1: tip on channel 0 contains only 100 ul, but blowout flag is false",
 			},
 		},
+		{
+			Name: "Fail - not enough in tip for multi-dispense",
+			Setup: []*SetupFn{
+				testLayout(),
+				preloadFilledTips(0, "tipbox_1", []int{0}, "water", 100.),
+			},
+			Instructions: []TestRobotInstruction{
+				&Move{
+					deckposition: []string{"input_1", "", "", "", "", "", "", ""},
+					wellcoords:   []string{"A1", "", "", "", "", "", "", ""},
+					reference:    []int{0, 0, 0, 0, 0, 0, 0, 0},
+					offsetX:      []float64{0., 0., 0., 0., 0., 0., 0., 0.},
+					offsetY:      []float64{0., 0., 0., 0., 0., 0., 0., 0.},
+					offsetZ:      []float64{1., 1., 1., 1., 1., 1., 1., 1.},
+					plate_type:   []string{"plate", "", "", "", "", "", "", ""},
+					head:         0,
+				},
+				&Dispense{
+					volume:    []float64{60., 0., 0., 0., 0., 0., 0., 0.},
+					blowout:   []bool{false, false, false, false, false, false, false, false},
+					head:      0,
+					multi:     1,
+					platetype: []string{"plate", "", "", "", "", "", "", ""},
+					what:      []string{"water", "", "", "", "", "", "", ""},
+					llf:       []bool{false, false, false, false, false, false, false, false},
+				},
+				&Move{
+					deckposition: []string{"input_1", "", "", "", "", "", "", ""},
+					wellcoords:   []string{"B1", "", "", "", "", "", "", ""},
+					reference:    []int{0, 0, 0, 0, 0, 0, 0, 0},
+					offsetX:      []float64{0., 0., 0., 0., 0., 0., 0., 0.},
+					offsetY:      []float64{0., 0., 0., 0., 0., 0., 0., 0.},
+					offsetZ:      []float64{1., 1., 1., 1., 1., 1., 1., 1.},
+					plate_type:   []string{"plate", "", "", "", "", "", "", ""},
+					head:         0,
+				},
+				&Dispense{
+					volume:    []float64{60., 0., 0., 0., 0., 0., 0., 0.},
+					blowout:   []bool{false, false, false, false, false, false, false, false},
+					head:      0,
+					multi:     1,
+					platetype: []string{"plate", "", "", "", "", "", "", ""},
+					what:      []string{"water", "", "", "", "", "", "", ""},
+					llf:       []bool{false, false, false, false, false, false, false, false},
+				},
+			},
+			ExpectedErrors: []string{
+				"(err) Dispense[3]: 60 ul of water from head 0 channel 0 to B1@plate1: tip on channel 0 contains only 40 ul after previous dispenses",
+			},
+		},
 		{
 			Name: "Fail - well over-full",
 			Setup: []*SetupFn{
@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+)
+
+// A chromeEvent is an event in the Chrome trace event format
+type chromeEvent struct {
+	Name     string            `json:"name"`
+	Category string            `json:"cat,omitempty"`
+	Phase    string            `json:"ph"`
+	Time     int64             `json:"ts"` // Microseconds
+	Duration int64             `json:"dur,omitempty"`
+	PID      int               `json:"pid"`
+	TID      int               `json:"tid"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+type chromeTrace struct {
+	TraceEvents     []*chromeEvent `json:"traceEvents"`
+	DisplayTimeUnit string         `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace writes the spans of a profile in the Chrome trace event
+// format, which can be viewed with chrome://tracing. Each track is shown as a
+// separate thread.
+func (a *Profile) WriteChromeTrace(out io.Writer) error {
+	trace := &chromeTrace{
+		TraceEvents:     []*chromeEvent{},
+		DisplayTimeUnit: "ms",
+	}
+
+	tids := make(map[string]int)
+	for _, s := range a.Spans() {
+		tid, seen := tids[s.Track]
+		if !seen {
+			tid = len(tids) + 1
+			tids[s.Track] = tid
+			trace.TraceEvents = append(trace.TraceEvents, &chromeEvent{
+				Name:  "thread_name",
+				Phase: "M",
+				PID:   1,
+				TID:   tid,
+				Args:  map[string]string{"name": s.Track},
+			})
+		}
+		trace.TraceEvents = append(trace.TraceEvents, &chromeEvent{
+			Name:     s.Name,
+			Category: s.Category,
+			Phase:    "X",
+			Time:     int64(s.Start.Sub(a.start) / time.Microsecond),
+			Duration: int64(s.Duration / time.Microsecond),
+			PID:      1,
+			TID:      tid,
+		})
+	}
+
+	return json.NewEncoder(out).Encode(trace)
+}
+
+// A Stat summarizes the spans of the same category and name
+type Stat struct {
+	Category string
+	Name     string
+	Count    int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+// Summary returns statistics of the spans of a profile in decreasing order of
+// total duration
+func (a *Profile) Summary() []*Stat {
+	type key struct {
+		Category, Name string
+	}
+	stats := make(map[key]*Stat)
+	for _, s := range a.Spans() {
+		k := key{Category: s.Category, Name: s.Name}
+		st := stats[k]
+		if st == nil {
+			st = &Stat{Category: s.Category, Name: s.Name}
+			stats[k] = st
+		}
+		st.Count++
+		st.Total += s.Duration
+		if s.Duration > st.Max {
+			st.Max = s.Duration
+		}
+	}
+
+	var r []*Stat
+	for _, st := range stats {
+		r = append(r, st)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Total != r[j].Total {
+			return r[i].Total > r[j].Total
+		} else if r[i].Category != r[j].Category {
+			return r[i].Category < r[j].Category
+		}
+		return r[i].Name < r[j].Name
+	})
+	return r
+}
@@ -0,0 +1,110 @@
+// Package profile records how long the phases of running a workflow take.
+// Spans are recorded in a Profile carried by a context, so code that may or
+// may not be profiled can unconditionally write:
+//
+//   defer profile.Start(ctx, "planner", "layout").End()
+//
+// which does nothing if there is no Profile in the context.
+package profile
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTrack is the track of spans started from a context without a track
+const DefaultTrack = "main"
+
+type contextKey int
+
+const (
+	theProfileKey contextKey = iota
+	theTrackKey
+)
+
+// A Span is a timed phase of execution
+type Span struct {
+	Category string        // Kind of phase, e.g., "process" or "planner"
+	Name     string        // Name of the phase
+	Track    string        // Spans on different tracks may run concurrently
+	Start    time.Time     // Start time
+	Duration time.Duration // Duration, set when the span ends
+
+	profile *Profile
+}
+
+// End ends a span and records it in its profile. Safe to call on a nil span.
+func (a *Span) End() {
+	if a == nil {
+		return
+	}
+	a.Duration = time.Since(a.Start)
+	a.profile.add(a)
+}
+
+// A Profile is a collection of spans. It is safe to use concurrently.
+type Profile struct {
+	lock  sync.Mutex
+	start time.Time
+	spans []*Span
+}
+
+// New returns a new empty profile
+func New() *Profile {
+	return &Profile{start: time.Now()}
+}
+
+func (a *Profile) add(span *Span) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.spans = append(a.spans, span)
+}
+
+// Spans returns the spans that have ended in order of start time
+func (a *Profile) Spans() []*Span {
+	a.lock.Lock()
+	spans := append([]*Span(nil), a.spans...)
+	a.lock.Unlock()
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Start.Before(spans[j].Start)
+	})
+	return spans
+}
+
+// NewContext returns a context with a new profile
+func NewContext(parent context.Context) context.Context {
+	return context.WithValue(parent, theProfileKey, New())
+}
+
+// FromContext returns the profile of a context or nil if there is none
+func FromContext(ctx context.Context) *Profile {
+	p, _ := ctx.Value(theProfileKey).(*Profile)
+	return p
+}
+
+// WithTrack returns a context whose spans are recorded on the given track
+func WithTrack(parent context.Context, track string) context.Context {
+	return context.WithValue(parent, theTrackKey, track)
+}
+
+// Start starts a span. Returns nil if there is no profile in the context.
+func Start(ctx context.Context, category, name string) *Span {
+	p := FromContext(ctx)
+	if p == nil {
+		return nil
+	}
+	track, ok := ctx.Value(theTrackKey).(string)
+	if !ok {
+		track = DefaultTrack
+	}
+	return &Span{
+		Category: category,
+		Name:     name,
+		Track:    track,
+		Start:    time.Now(),
+		profile:  p,
+	}
+}
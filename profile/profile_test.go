@@ -0,0 +1,69 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNoProfile(t *testing.T) {
+	span := Start(context.Background(), "test", "a")
+	if span != nil {
+		t.Errorf("expected nil span found %v", span)
+	}
+	span.End()
+}
+
+func TestProfile(t *testing.T) {
+	ctx := NewContext(context.Background())
+	p := FromContext(ctx)
+
+	Start(ctx, "test", "a").End()
+	Start(WithTrack(ctx, "other"), "test", "b").End()
+	Start(ctx, "test", "a").End()
+	Start(ctx, "test", "unfinished")
+
+	spans := p.Spans()
+	if l := len(spans); l != 3 {
+		t.Fatalf("expected %d spans found %d", 3, l)
+	}
+	if tr := spans[1].Track; tr != "other" {
+		t.Errorf("expected track %q found %q", "other", tr)
+	}
+
+	stats := p.Summary()
+	counts := make(map[string]int)
+	for _, st := range stats {
+		counts[st.Name] = st.Count
+	}
+	if len(stats) != 2 || counts["a"] != 2 || counts["b"] != 1 {
+		t.Errorf("unexpected summary %v", counts)
+	}
+
+	var buf bytes.Buffer
+	if err := p.WriteChromeTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var trace chromeTrace
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	tids := make(map[string]int)
+	var spanEvents int
+	for _, ev := range trace.TraceEvents {
+		switch ev.Phase {
+		case "M":
+			tids[ev.Args["name"]] = ev.TID
+		case "X":
+			spanEvents++
+		}
+	}
+	if spanEvents != 3 {
+		t.Errorf("expected %d span events found %d", 3, spanEvents)
+	}
+	if len(tids) != 2 || tids[DefaultTrack] == tids["other"] {
+		t.Errorf("expected distinct threads per track found %v", tids)
+	}
+}
@@ -64,7 +64,7 @@ func New(opt Opt) (ret *Auto, err error) {
 	tryer := &tryer{
 		Auto:      ret,
 		MaybeArgs: opt.MaybeArgs,
-		HumanOpt:  human.Opt{CanMix: true, CanIncubate: true},
+		HumanOpt:  human.Opt{CanMix: true, CanIncubate: true, CanCentrifuge: true, CanThermocycle: true, CanSeal: true},
 	}
 
 	ctx := context.Background()
@@ -76,7 +76,7 @@ func New(opt Opt) (ret *Auto, err error) {
 		}
 		ret.Conns = append(ret.Conns, conn)
 
-		if err = tryer.Try(ctx, conn, ep.Arg); err != nil {
+		if err = tryer.Try(ctx, conn, ep); err != nil {
 			return
 		}
 	}
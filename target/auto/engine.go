@@ -0,0 +1,402 @@
+package auto
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/graph"
+	"github.com/antha-lang/antha/target"
+)
+
+var (
+	// ErrAborted is returned by Engine.Run when execution is aborted
+	ErrAborted = errors.New("execution aborted")
+	// ErrSkipped is returned by a Confirmer to skip an instruction
+	ErrSkipped = errors.New("instruction skipped")
+)
+
+// An Operator is the person who carries out manual instructions and answers
+// prompts during execution
+type Operator interface {
+	// Acknowledge shows a message to the operator and returns once they
+	// have acted on it or an error if they cannot
+	Acknowledge(ctx context.Context, message string) error
+}
+
+// A Confirmer is an Operator who is asked before each device run or plate
+// move whether to carry it out
+type Confirmer interface {
+	Operator
+	// Confirm returns nil to carry out the instruction described by message,
+	// ErrSkipped to skip it or any other error to stop execution
+	Confirm(ctx context.Context, message string) error
+}
+
+// A Clock measures time during execution
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives once the duration has passed
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// States of instructions in the progress log
+const (
+	EventStarted = "started"
+	EventDone    = "done"
+	EventFailed  = "failed"
+	EventSkipped = "skipped"
+)
+
+// An Event is an entry in the progress log of an execution
+type Event struct {
+	Time time.Time `json:"time"`
+	// Index of the instruction
+	Inst  int    `json:"inst"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// ReadProgress reads a progress log written by an Engine
+func ReadProgress(r io.Reader) ([]Event, error) {
+	var events []Event
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, s.Err()
+}
+
+// An EngineOpt are options for an Engine
+type EngineOpt struct {
+	// Operator for manual instructions and prompts. Required if there are
+	// any. If the operator is a Confirmer, they are also asked to confirm
+	// device runs and plate moves.
+	Operator Operator
+	// Clock for timed waits. If nil, real time.
+	Clock Clock
+	// If not nil, write progress events to Log as lines of JSON
+	Log io.Writer
+	// If not nil, called with each progress event
+	Notify func(Event)
+	// Progress of a previous execution of the same instructions.
+	// Instructions that are done in it are skipped.
+	Previous []Event
+}
+
+// An Engine executes instructions on the devices of an Auto. Instructions
+// run once their dependencies are done; instructions for different devices
+// run concurrently but each device runs one instruction at a time.
+type Engine struct {
+	auto  *Auto
+	insts []ast.Inst
+	opt   EngineOpt
+
+	logMu sync.Mutex
+	log   *json.Encoder
+
+	mu      sync.Mutex
+	paused  bool
+	resumed chan struct{} // Closed when not paused
+	aborted bool
+	cancel  context.CancelFunc
+
+	devices map[ast.Device]chan struct{}
+}
+
+// NewEngine returns an engine to execute a set of instructions
+func (a *Auto) NewEngine(insts []ast.Inst, opt EngineOpt) *Engine {
+	if opt.Clock == nil {
+		opt.Clock = realClock{}
+	}
+	e := &Engine{
+		auto:    a,
+		insts:   insts,
+		opt:     opt,
+		resumed: make(chan struct{}),
+		devices: make(map[ast.Device]chan struct{}),
+	}
+	close(e.resumed)
+	if opt.Log != nil {
+		e.log = json.NewEncoder(opt.Log)
+	}
+	for _, inst := range insts {
+		if d := inst.Device(); d != nil && e.devices[d] == nil {
+			e.devices[d] = make(chan struct{}, 1)
+		}
+	}
+	return e
+}
+
+// Pause stops instructions from starting until Resume is called. Running
+// instructions and timed waits continue.
+func (a *Engine) Pause() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.paused {
+		a.paused = true
+		a.resumed = make(chan struct{})
+	}
+}
+
+// Resume continues a paused execution
+func (a *Engine) Resume() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.paused {
+		a.paused = false
+		close(a.resumed)
+	}
+}
+
+// Abort stops execution, cancelling running instructions
+func (a *Engine) Abort() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.aborted = true
+	if a.cancel != nil {
+		a.cancel()
+	}
+}
+
+func (a *Engine) isAborted() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.aborted
+}
+
+// wait returns once execution is not paused
+func (a *Engine) wait(ctx context.Context) error {
+	a.mu.Lock()
+	resumed := a.resumed
+	a.mu.Unlock()
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *Engine) notify(idx int, state string, err error) {
+	e := Event{
+		Time:  a.opt.Clock.Now(),
+		Inst:  idx,
+		Name:  a.auto.Pretty(a.insts[idx]),
+		State: state,
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	a.logMu.Lock()
+	defer a.logMu.Unlock()
+	if a.log != nil {
+		a.log.Encode(e) // nolint: errcheck
+	}
+	if a.opt.Notify != nil {
+		a.opt.Notify(e)
+	}
+}
+
+// previouslyDone returns the instructions that are done in the previous
+// execution
+func (a *Engine) previouslyDone() (map[int]bool, error) {
+	done := make(map[int]bool)
+	for _, e := range a.opt.Previous {
+		if e.Inst < 0 || e.Inst >= len(a.insts) {
+			return nil, fmt.Errorf("previous progress has unknown instruction %d", e.Inst)
+		}
+		if name := a.auto.Pretty(a.insts[e.Inst]); name != e.Name {
+			return nil, fmt.Errorf("previous progress of instruction %d is for %q not %q", e.Inst, e.Name, name)
+		}
+		if e.State == EventDone || e.State == EventSkipped {
+			done[e.Inst] = true
+		}
+	}
+	return done, nil
+}
+
+// Run executes the instructions and returns once they are done, one fails
+// or execution is aborted
+func (a *Engine) Run(parent context.Context) error {
+	done, err := a.previouslyDone()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	a.mu.Lock()
+	a.cancel = cancel
+	aborted := a.aborted
+	a.mu.Unlock()
+	if aborted {
+		return ErrAborted
+	}
+
+	index := make(map[ast.Inst]int)
+	for i, inst := range a.insts {
+		index[inst] = i
+	}
+
+	type result struct {
+		Node graph.Node
+		Err  error
+	}
+
+	dag := graph.Schedule(graph.Reverse(&target.Graph{Insts: a.insts}))
+	ready := dag.Roots
+	results := make(chan result)
+	var running int
+	var firstErr error
+	for {
+		if firstErr == nil {
+			for _, n := range ready {
+				running++
+				go func(n graph.Node) {
+					idx := index[n.(ast.Inst)]
+					if done[idx] {
+						a.notify(idx, EventSkipped, nil)
+						results <- result{Node: n}
+						return
+					}
+					results <- result{Node: n, Err: a.run(ctx, idx)}
+				}(n)
+			}
+		}
+		ready = nil
+
+		if running == 0 {
+			break
+		}
+
+		r := <-results
+		running--
+		if r.Err != nil {
+			if firstErr == nil {
+				firstErr = r.Err
+				cancel()
+			}
+			continue
+		}
+		ready = dag.Visit(r.Node)
+	}
+
+	if a.isAborted() {
+		return ErrAborted
+	}
+	return firstErr
+}
+
+// run executes an instruction once execution is not paused and its device
+// is free
+func (a *Engine) run(ctx context.Context, idx int) error {
+	if err := a.wait(ctx); err != nil {
+		return err
+	}
+
+	inst := a.insts[idx]
+	if skip, err := a.confirm(ctx, inst); err != nil {
+		return err
+	} else if skip {
+		a.notify(idx, EventSkipped, nil)
+		return nil
+	}
+
+	if dev := a.devices[inst.Device()]; dev != nil {
+		select {
+		case dev <- struct{}{}:
+			defer func() { <-dev }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	a.notify(idx, EventStarted, nil)
+	err := a.execute(ctx, inst)
+	if err != nil {
+		a.notify(idx, EventFailed, err)
+		return err
+	}
+	a.notify(idx, EventDone, nil)
+	return nil
+}
+
+// confirm returns whether the operator skips a device run or plate move
+func (a *Engine) confirm(ctx context.Context, inst ast.Inst) (bool, error) {
+	c, ok := a.opt.Operator.(Confirmer)
+	if !ok {
+		return false, nil
+	}
+	switch inst.(type) {
+	case *target.Run, *target.Move:
+	default:
+		return false, nil
+	}
+
+	err := c.Confirm(ctx, a.auto.Pretty(inst))
+	if err == ErrSkipped {
+		return true, nil
+	}
+	return false, err
+}
+
+func (a *Engine) acknowledge(ctx context.Context, message string) error {
+	if a.opt.Operator == nil {
+		return fmt.Errorf("no operator to acknowledge %q", message)
+	}
+	return a.opt.Operator.Acknowledge(ctx, message)
+}
+
+func (a *Engine) execute(ctx context.Context, inst ast.Inst) error {
+	switch inst := inst.(type) {
+	case *target.Manual:
+		return a.acknowledge(ctx, prettyManual(inst))
+	case *target.Order:
+		return a.acknowledge(ctx, prettyManual(&inst.Manual))
+	case *target.PlatePrep:
+		return a.acknowledge(ctx, prettyManual(&inst.Manual))
+	case *target.SetupMixer:
+		return a.acknowledge(ctx, prettyManual(&inst.Manual))
+	case *target.SetupIncubator:
+		return a.acknowledge(ctx, prettyManual(&inst.Manual))
+	case *target.Prompt:
+		return a.acknowledge(ctx, inst.Message)
+	case *target.TimedWait:
+		select {
+		case <-a.opt.Clock.After(inst.Duration):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	default:
+		return a.auto.Execute(ctx, inst)
+	}
+}
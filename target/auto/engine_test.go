@@ -0,0 +1,334 @@
+package auto
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/codegen"
+	driver "github.com/antha-lang/antha/driver/antha_driver_v1"
+	shakerincubator "github.com/antha-lang/antha/driver/antha_shakerincubator_v1"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/human"
+	"google.golang.org/grpc"
+)
+
+// fakeIncubator is an in-process shaker incubator driver
+type fakeIncubator struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (a *fakeIncubator) Calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.calls...)
+}
+
+func (a *fakeIncubator) record(name string) (*shakerincubator.BoolReply, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, name)
+	return &shakerincubator.BoolReply{Result: true}, nil
+}
+
+func (a *fakeIncubator) DriverType(ctx context.Context, req *driver.TypeRequest) (*driver.TypeReply, error) {
+	return &driver.TypeReply{Type: "antha.shakerincubator.v1.ShakerIncubator"}, nil
+}
+
+func (a *fakeIncubator) Connect(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("Connect")
+}
+
+func (a *fakeIncubator) Disconnect(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("Disconnect")
+}
+
+func (a *fakeIncubator) Test(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("Test")
+}
+
+func (a *fakeIncubator) CarrierOpen(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("CarrierOpen")
+}
+
+func (a *fakeIncubator) CarrierClose(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("CarrierClose")
+}
+
+func (a *fakeIncubator) ShakeStart(context.Context, *shakerincubator.ShakerSettings) (*shakerincubator.BoolReply, error) {
+	return a.record("ShakeStart")
+}
+
+func (a *fakeIncubator) ShakeStop(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("ShakeStop")
+}
+
+func (a *fakeIncubator) TemperatureSet(context.Context, *shakerincubator.TemperatureSettings) (*shakerincubator.BoolReply, error) {
+	return a.record("TemperatureSet")
+}
+
+func (a *fakeIncubator) TemperatureReset(context.Context, *shakerincubator.Blank) (*shakerincubator.BoolReply, error) {
+	return a.record("TemperatureReset")
+}
+
+// serve starts an in-process driver and returns its address
+func serve(t *testing.T, f *fakeIncubator) (string, func()) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	driver.RegisterDriverServer(s, f)
+	shakerincubator.RegisterShakerIncubatorServer(s, f)
+	go s.Serve(lis) // nolint: errcheck
+	return lis.Addr().String(), s.Stop
+}
+
+// fakeClock does not wait but records the waits asked of it
+type fakeClock struct {
+	mu    sync.Mutex
+	waits []time.Duration
+}
+
+func (a *fakeClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (a *fakeClock) After(d time.Duration) <-chan time.Time {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.waits = append(a.waits, d)
+	c := make(chan time.Time, 1)
+	c <- time.Time{}
+	return c
+}
+
+// fakeOperator acknowledges every message
+type fakeOperator struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (a *fakeOperator) Acknowledge(ctx context.Context, message string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.messages = append(a.messages, message)
+	return nil
+}
+
+func TestEngine(t *testing.T) {
+	f := &fakeIncubator{}
+	uri, stop := serve(t, f)
+	defer stop()
+
+	a, err := New(Opt{Endpoints: []Endpoint{{URI: uri}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close() // nolint: errcheck
+
+	ctx := sampletracker.NewContext(context.Background())
+	insts, err := codegen.Compile(ctx, a.Target, []ast.Node{
+		&ast.Command{
+			Request: ast.Request{
+				Selector: []ast.NameValue{
+					target.DriverSelectorV1ShakerIncubator,
+				},
+			},
+			Inst: &ast.IncubateInst{
+				Temp: wunit.NewTemperature(37, "C"),
+				Time: wunit.NewTime(30, "min"),
+			},
+			From: []ast.Node{
+				&ast.UseComp{Value: &wtype.Liquid{ID: "cells", CName: "cells"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{}
+	op := &fakeOperator{}
+	var log bytes.Buffer
+	e := a.NewEngine(insts, EngineOpt{
+		Operator: op,
+		Clock:    clock,
+		Log:      &log,
+	})
+	if err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := strings.Join(f.Calls(), " ")
+	for _, expected := range []string{
+		"CarrierOpen CarrierClose TemperatureSet",
+		"TemperatureReset",
+	} {
+		if !strings.Contains(calls, expected) {
+			t.Errorf("expected calls %q in %q", expected, calls)
+		}
+	}
+	if len(clock.waits) != 1 || clock.waits[0] != 30*time.Minute {
+		t.Errorf("expected wait of %s found %v", 30*time.Minute, clock.waits)
+	}
+	if prompts := strings.Join(op.messages, "; "); !strings.Contains(prompts, "close incubator carrier?") {
+		t.Errorf("expected prompt to close carrier found %q", prompts)
+	}
+
+	events, err := ReadProgress(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(map[int]bool)
+	for _, ev := range events {
+		if ev.State == EventDone {
+			done[ev.Inst] = true
+		}
+	}
+	if len(done) != len(insts) {
+		t.Errorf("expected %d instructions done found %d", len(insts), len(done))
+	}
+
+	// Resuming a finished execution does nothing
+	ncalls := len(f.Calls())
+	nprompts := len(op.messages)
+	e = a.NewEngine(insts, EngineOpt{
+		Operator: op,
+		Clock:    clock,
+		Previous: events,
+	})
+	if err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(f.Calls()); n != ncalls {
+		t.Errorf("expected %d calls after resuming found %d", ncalls, n)
+	}
+	if n := len(op.messages); n != nprompts {
+		t.Errorf("expected %d prompts after resuming found %d", nprompts, n)
+	}
+}
+
+// chanOperator passes messages to a channel
+type chanOperator struct {
+	Messages chan string
+	Block    bool
+}
+
+func (a *chanOperator) Acknowledge(ctx context.Context, message string) error {
+	a.Messages <- message
+	if a.Block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func makePrompts() []ast.Inst {
+	first := &target.Prompt{Message: "first"}
+	second := &target.Prompt{Message: "second"}
+	second.SetDependsOn(first)
+	return []ast.Inst{first, second}
+}
+
+func TestEnginePause(t *testing.T) {
+	op := &chanOperator{Messages: make(chan string, 2)}
+	e := (&Auto{Target: target.New()}).NewEngine(makePrompts(), EngineOpt{Operator: op})
+
+	e.Pause()
+	errs := make(chan error)
+	go func() {
+		errs <- e.Run(context.Background())
+	}()
+
+	select {
+	case m := <-op.Messages:
+		t.Fatalf("expected no prompts while paused found %q", m)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	e.Resume()
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"first", "second"} {
+		if m := <-op.Messages; m != expected {
+			t.Errorf("expected prompt %q found %q", expected, m)
+		}
+	}
+}
+
+func TestEngineAbort(t *testing.T) {
+	op := &chanOperator{Messages: make(chan string, 2), Block: true}
+	e := (&Auto{Target: target.New()}).NewEngine(makePrompts(), EngineOpt{Operator: op})
+
+	errs := make(chan error)
+	go func() {
+		errs <- e.Run(context.Background())
+	}()
+
+	if m := <-op.Messages; m != "first" {
+		t.Errorf("expected prompt %q found %q", "first", m)
+	}
+	e.Abort()
+	if err := <-errs; err != ErrAborted {
+		t.Errorf("expected %v found %v", ErrAborted, err)
+	}
+	if n := len(op.Messages); n != 0 {
+		t.Errorf("expected no more prompts after abort found %d", n)
+	}
+}
+
+// skipOperator skips every instruction it is asked to confirm
+type skipOperator struct {
+	fakeOperator
+	confirmed []string
+}
+
+func (a *skipOperator) Confirm(ctx context.Context, message string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.confirmed = append(a.confirmed, message)
+	return ErrSkipped
+}
+
+func TestEngineSkip(t *testing.T) {
+	// Running the instruction would fail as the target has no such device
+	run := &target.Run{Dev: human.New(human.Opt{}), Label: "spin"}
+	prompt := &target.Prompt{Message: "check"}
+	prompt.SetDependsOn(run)
+
+	op := &skipOperator{}
+	var log bytes.Buffer
+	e := (&Auto{Target: target.New()}).NewEngine([]ast.Inst{run, prompt}, EngineOpt{
+		Operator: op,
+		Log:      &log,
+	})
+	if err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(op.confirmed) != 1 {
+		t.Errorf("expected %d confirmations found %d", 1, len(op.confirmed))
+	}
+	if len(op.messages) != 1 || op.messages[0] != "check" {
+		t.Errorf("expected prompt %q found %q", "check", op.messages)
+	}
+
+	events, err := ReadProgress(&log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 || events[0].Inst != 0 || events[0].State != EventSkipped {
+		t.Errorf("expected run to be skipped found %v", events)
+	}
+}
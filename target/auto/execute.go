@@ -7,6 +7,9 @@ import (
 	"time"
 
 	"github.com/antha-lang/antha/ast"
+	platemover "github.com/antha-lang/antha/driver/antha_platemover_v1"
+	platereader "github.com/antha-lang/antha/driver/antha_platereader_v1"
+	quantstudio "github.com/antha-lang/antha/driver/antha_quantstudio_v1"
 	runner "github.com/antha-lang/antha/driver/antha_runner_v1"
 	"github.com/antha-lang/antha/target"
 	"google.golang.org/grpc"
@@ -19,6 +22,8 @@ func (a *Auto) Execute(ctx context.Context, inst ast.Inst) error {
 		return a.executeMix(ctx, inst)
 	case *target.Run:
 		return a.executeRun(ctx, inst)
+	case *target.Move:
+		return a.executeMove(ctx, inst)
 	case *target.Manual:
 		return nil
 	case *target.Wait:
@@ -48,10 +53,32 @@ func (a *Auto) executeRun(ctx context.Context, inst *target.Run) error {
 		if err := grpc.Invoke(ctx, c.Method, c.Args, c.Reply, conn); err != nil {
 			return err
 		}
+		if r, ok := c.Reply.(*quantstudio.OptionalError); ok {
+			if _, failed := r.GetMaybeError().(*quantstudio.OptionalError_Error); failed {
+				return fmt.Errorf("error running %s: %s", inst.Label, r.GetError())
+			}
+		}
+		if r, ok := c.Reply.(*platereader.BoolReply); ok && !r.GetResult() {
+			return fmt.Errorf("error running %s", inst.Label)
+		}
 	}
 	return nil
 }
 
+func (a *Auto) executeMove(ctx context.Context, inst *target.Move) error {
+	conn, ok := a.handler[inst.Dev]
+	if !ok {
+		return fmt.Errorf("no handler for move of plate %s", inst.PlateName)
+	}
+
+	return grpc.Invoke(ctx, "/antha.platemover.v1.PlateMover/Move", &platemover.MoveRequest{
+		PlateId:     inst.PlateID,
+		Labware:     inst.Labware,
+		Source:      inst.From,
+		Destination: inst.To,
+	}, &platemover.BoolReply{}, conn)
+}
+
 func (a *Auto) executeMix(ctx context.Context, inst *target.Mix) error {
 	rs := a.runners[inst.Files.Type]
 	if len(rs) == 0 {
@@ -0,0 +1,65 @@
+package auto
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+)
+
+// A DeviceOpt is a set of limits of the device of a driver, e.g., the
+// temperatures that it can reach. Limits that are not set are not checked.
+type DeviceOpt struct {
+	MinTemp      wunit.Temperature
+	MaxTemp      wunit.Temperature
+	MaxShakeRate wunit.Rate
+	MaxForce     wunit.Acceleration
+}
+
+// parseMeasurement parses a value with units of the given measurement type,
+// e.g., "95C"
+func parseMeasurement(measurementType, s string) (*wunit.ConcreteMeasurement, error) {
+	v, unit := wunit.SplitValueAndUnit(s)
+	if err := wunit.GetGlobalUnitRegistry().AssertValidUnitForType(measurementType, unit); err != nil {
+		return nil, err
+	}
+	return wunit.NewTypedMeasurement(measurementType, v, unit), nil
+}
+
+// ParseDeviceOpt returns the device limits given as options of an endpoint,
+// e.g., the query parameters of tcp://localhost:50051?minTemp=4C&maxTemp=95C
+func ParseDeviceOpt(values url.Values) (opt DeviceOpt, err error) {
+	for name := range values {
+		var m *wunit.ConcreteMeasurement
+		v := values.Get(name)
+		switch name {
+		case "minTemp":
+			m, err = parseMeasurement("Temperature", v)
+			opt.MinTemp = wunit.Temperature{ConcreteMeasurement: m}
+		case "maxTemp":
+			m, err = parseMeasurement("Temperature", v)
+			opt.MaxTemp = wunit.Temperature{ConcreteMeasurement: m}
+		case "maxShakeRate":
+			m, err = parseMeasurement("Rate", v)
+			opt.MaxShakeRate = wunit.Rate{ConcreteMeasurement: m}
+		case "maxForce":
+			m, err = parseMeasurement("Acceleration", v)
+			opt.MaxForce = wunit.Acceleration{ConcreteMeasurement: m}
+		default:
+			err = fmt.Errorf("unknown option")
+		}
+		if err != nil {
+			return DeviceOpt{}, fmt.Errorf("invalid device option %s=%s: %s", name, v, err)
+		}
+	}
+	return
+}
+
+func getDeviceOpt(maybeArgs []interface{}) (ret DeviceOpt) {
+	for _, v := range maybeArgs {
+		if o, ok := v.(DeviceOpt); ok {
+			return o
+		}
+	}
+	return
+}
@@ -0,0 +1,56 @@
+package auto
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/target"
+)
+
+func TestDeviceOpt(t *testing.T) {
+	values, err := url.ParseQuery("minTemp=4C&maxTemp=60C&maxShakeRate=1200/min")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt, err := ParseDeviceOpt(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := drivers["antha.shakerincubator.v1.ShakerIncubator"].New(context.Background(), nil, DriverArg{
+		Args: []interface{}{opt},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tgt := target.New()
+	tgt.AddDevice(dev)
+
+	incubate := func(temp float64) ast.Request {
+		return ast.Request{
+			Selector: []ast.NameValue{target.DriverSelectorV1ShakerIncubator},
+			Capabilities: []ast.Capability{
+				ast.NewValueCapability(target.CapabilityTemperature, wunit.NewTemperature(temp, "C")),
+			},
+		}
+	}
+	if devs := tgt.CanCompile(incubate(37)); len(devs) != 1 {
+		t.Errorf("expecting incubator to reach 37C")
+	}
+	if devs := tgt.CanCompile(incubate(95)); len(devs) != 0 {
+		t.Errorf("expecting incubator not to reach 95C")
+	}
+
+	for _, bad := range []string{"maxTemp=95", "maxForce=95C", "lid=true"} {
+		values, err := url.ParseQuery(bad)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseDeviceOpt(values); err == nil {
+			t.Errorf("%s: expecting error", bad)
+		}
+	}
+}
@@ -12,11 +12,21 @@ import (
 func (a *Auto) Pretty(inst ast.Inst) string {
 	switch inst := inst.(type) {
 	case *target.Mix:
-		return prettyMix(inst)
+		return prettyMix(inst, a.Target.Name(inst.Dev))
 	case *target.Run:
 		return prettyRun(inst)
+	case *target.Move:
+		return prettyMove(inst)
 	case *target.Manual:
 		return prettyManual(inst)
+	case *target.Order:
+		return prettyManual(&inst.Manual)
+	case *target.PlatePrep:
+		return prettyManual(&inst.Manual)
+	case *target.SetupMixer:
+		return prettyManual(&inst.Manual)
+	case *target.SetupIncubator:
+		return prettyManual(&inst.Manual)
 	case *target.Wait:
 		return "Wait"
 	case *target.Prompt:
@@ -32,8 +42,12 @@ func prettyManual(inst *target.Manual) string {
 	return fmt.Sprintf("[%s] %s", inst.Label, strings.Replace(inst.Details, "\n", "; ", -1))
 }
 
-func prettyMix(inst *target.Mix) string {
-	return fmt.Sprintf("[mix] (size: %d)", len(inst.Files.Tarball))
+func prettyMove(inst *target.Move) string {
+	return fmt.Sprintf("[mov] %s (%s) from %s to %s", inst.PlateName, inst.Labware, inst.From, inst.To)
+}
+
+func prettyMix(inst *target.Mix, device string) string {
+	return fmt.Sprintf("[mix] %s (size: %d)", device, len(inst.Files.Tarball))
 }
 
 func prettyRun(inst *target.Run) string {
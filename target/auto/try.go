@@ -5,15 +5,106 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/antha-lang/antha/ast"
 	driver "github.com/antha-lang/antha/driver/antha_driver_v1"
+	platemoverpb "github.com/antha-lang/antha/driver/antha_platemover_v1"
 	runner "github.com/antha-lang/antha/driver/antha_runner_v1"
 	lhclient "github.com/antha-lang/antha/driver/liquidhandling/client"
+	"github.com/antha-lang/antha/target/centrifuge"
 	"github.com/antha-lang/antha/target/human"
 	"github.com/antha-lang/antha/target/mixer"
+	"github.com/antha-lang/antha/target/platemover"
+	"github.com/antha-lang/antha/target/qpcrdevice"
+	"github.com/antha-lang/antha/target/sealer"
 	"github.com/antha-lang/antha/target/shakerincubator"
+	"github.com/antha-lang/antha/target/thermocycler"
+	"github.com/antha-lang/antha/target/woplatereader"
 	"google.golang.org/grpc"
 )
 
+// A DriverArg is what is known about a driver when making its device
+type DriverArg struct {
+	// Subtypes reported by the driver
+	Subtypes []string
+	// Candidate arguments for the device: the argument of the endpoint
+	// followed by Opt.MaybeArgs
+	Args []interface{}
+}
+
+// A Driver describes how to add the device for a type of driver to a target
+type Driver struct {
+	// New makes a device whose instructions are executed on the given
+	// connection
+	New func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error)
+	// Replace removes the capabilities that the device takes over from the
+	// human device, if any
+	Replace func(opt *human.Opt)
+}
+
+// drivers maps driver types to their devices
+var drivers = map[string]Driver{
+	"antha.shakerincubator.v1.ShakerIncubator": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			opt := getDeviceOpt(arg.Args)
+			return shakerincubator.New(shakerincubator.Opt{
+				MinTemp:      opt.MinTemp,
+				MaxTemp:      opt.MaxTemp,
+				MaxShakeRate: opt.MaxShakeRate,
+			}), nil
+		},
+		Replace: func(opt *human.Opt) { opt.CanIncubate = false },
+	},
+	"antha.centrifuge.v1.Centrifuge": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			opt := getDeviceOpt(arg.Args)
+			return centrifuge.New(centrifuge.Opt{
+				MinTemp:  opt.MinTemp,
+				MaxTemp:  opt.MaxTemp,
+				MaxForce: opt.MaxForce,
+			}), nil
+		},
+		Replace: func(opt *human.Opt) { opt.CanCentrifuge = false },
+	},
+	"antha.thermocycler.v1.Thermocycler": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			opt := getDeviceOpt(arg.Args)
+			return thermocycler.New(thermocycler.Opt{
+				MinTemp: opt.MinTemp,
+				MaxTemp: opt.MaxTemp,
+			}), nil
+		},
+		Replace: func(opt *human.Opt) { opt.CanThermocycle = false },
+	},
+	"antha.sealer.v1.Sealer": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			return sealer.New(), nil
+		},
+		Replace: func(opt *human.Opt) { opt.CanSeal = false },
+	},
+	"antha.platereader.v1.PlateReader": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			return woplatereader.New(), nil
+		},
+	},
+	"antha.quantstudio.v1.QuantStudioService": {
+		New: func(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+			return qpcrdevice.New(), nil
+		},
+	},
+	"antha.platemover.v1.PlateMover": {
+		New: newPlateMover,
+	},
+	"antha.mixer.v1.Mixer": {
+		New:     newMixer,
+		Replace: func(opt *human.Opt) { opt.CanMix = false },
+	},
+}
+
+// RegisterDriver adds or replaces the device for a type of driver
+func RegisterDriver(typ string, d Driver) {
+	drivers[typ] = d
+}
+
 // Common state for tryers
 type tryer struct {
 	Auto      *Auto
@@ -23,16 +114,14 @@ type tryer struct {
 
 // Try queries a driver and adds the corresponding device to the target
 // based on the query response
-func (a *tryer) Try(ctx context.Context, conn *grpc.ClientConn, arg interface{}) error {
+func (a *tryer) Try(ctx context.Context, conn *grpc.ClientConn, ep Endpoint) error {
 	c := driver.NewDriverClient(conn)
 	reply, err := c.DriverType(ctx, &driver.TypeRequest{})
 	if err != nil {
 		return err
 	}
 
-	switch reply.GetType() {
-
-	case "antha.runner.v1.Runner":
+	if reply.GetType() == "antha.runner.v1.Runner" {
 		r := runner.NewRunnerClient(conn)
 		reply, err := r.SupportedRunTypes(ctx, &runner.SupportedRunTypesRequest{})
 		if err != nil {
@@ -42,69 +131,90 @@ func (a *tryer) Try(ctx context.Context, conn *grpc.ClientConn, arg interface{})
 			a.Auto.runners[typ] = append(a.Auto.runners[typ], r)
 		}
 		return nil
+	}
 
-	case "antha.shakerincubator.v1.ShakerIncubator":
-		s := &shakerincubator.ShakerIncubator{}
-		a.HumanOpt.CanIncubate = false
-		a.Auto.handler[s] = conn
-		a.Auto.Target.AddDevice(s)
+	d, ok := drivers[reply.GetType()]
+	if !ok {
 		return nil
+	}
 
-	case "antha.mixer.v1.Mixer":
-		return a.AddMixer(ctx, conn, arg, reply.GetSubtypes())
+	var args []interface{}
+	args = append(args, ep.Arg)
+	args = append(args, a.MaybeArgs...)
 
-	default:
-		return nil
+	dev, err := d.New(ctx, conn, DriverArg{
+		Subtypes: reply.GetSubtypes(),
+		Args:     args,
+	})
+	if err != nil {
+		return err
+	}
+
+	if d.Replace != nil {
+		d.Replace(&a.HumanOpt)
+	}
+	a.Auto.handler[dev] = conn
+	a.Auto.Target.AddDevice(dev)
+	return nil
+}
+
+func newPlateMover(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+	reply, err := platemoverpb.NewPlateMoverClient(conn).ListNests(ctx, &platemoverpb.Blank{})
+	if err != nil {
+		return nil, err
 	}
+	var nests []platemover.Nest
+	for _, n := range reply.Nests {
+		nests = append(nests, platemover.Nest{
+			Name:    n.Name,
+			Device:  n.Device,
+			Labware: n.Labware,
+		})
+	}
+	return platemover.New(nests), nil
 }
 
-var mixerMap = map[string]func(*tryer, context.Context, *grpc.ClientConn, interface{}) error{
-	"GilsonPipetmax": (*tryer).addLowLevelMixer,
-	"CyBio":          (*tryer).addLowLevelMixer,
-	"TecanEvo":       (*tryer).addLowLevelMixer,
-	"LabCyteEcho":    (*tryer).addHighLevelMixer,
-	"Hamilton":       (*tryer).addLowLevelMixer,
+var mixerMap = map[string]func(*grpc.ClientConn, DriverArg) (ast.Device, error){
+	"GilsonPipetmax": newLowLevelMixer,
+	"CyBio":          newLowLevelMixer,
+	"TecanEvo":       newLowLevelMixer,
+	"LabCyteEcho":    newHighLevelMixer,
+	"Hamilton":       newLowLevelMixer,
 }
 
-// AddMixer queries a mixer driver and adds the corresponding device to the target
-func (a *tryer) AddMixer(ctx context.Context, conn *grpc.ClientConn, arg interface{}, subtypes []string) error {
-	if len(subtypes) == 0 {
-		return errors.New("Cannot add mixer: no subtypes provided")
-	} else if fun, found := mixerMap[subtypes[0]]; !found {
-		return fmt.Errorf("Unknown mixer device: %v", subtypes)
+// newMixer makes the mixer device for the subtype of a mixer driver
+func newMixer(ctx context.Context, conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+	if len(arg.Subtypes) == 0 {
+		return nil, errors.New("Cannot add mixer: no subtypes provided")
+	} else if fun, found := mixerMap[arg.Subtypes[0]]; !found {
+		return nil, fmt.Errorf("Unknown mixer device: %v", arg.Subtypes)
 	} else {
-		return fun(a, ctx, conn, arg)
+		return fun(conn, arg)
 	}
 }
 
-func (a *tryer) addHighLevelMixer(ctx context.Context, conn *grpc.ClientConn, arg interface{}) error {
-	var candidates []interface{}
-	candidates = append(candidates, arg)
-	candidates = append(candidates, a.MaybeArgs...)
-
-	d, err := mixer.New(getMixerOpt(candidates), lhclient.NewHighLevelClientFromConn(conn))
+func newHighLevelMixer(conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+	m, err := mixer.New(getMixerOpt(arg.Args), lhclient.NewHighLevelClientFromConn(conn))
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	a.HumanOpt.CanMix = false
-	a.Auto.Target.AddDevice(d)
-	return nil
+	m.SetName(mixerName(conn, arg))
+	return m, nil
 }
-func (a *tryer) addLowLevelMixer(ctx context.Context, conn *grpc.ClientConn, arg interface{}) error {
-
-	var candidates []interface{}
-	candidates = append(candidates, arg)
-	candidates = append(candidates, a.MaybeArgs...)
 
-	d, err := mixer.New(getMixerOpt(candidates), lhclient.NewLowLevelClientFromConn(conn))
+func newLowLevelMixer(conn *grpc.ClientConn, arg DriverArg) (ast.Device, error) {
+	m, err := mixer.New(getMixerOpt(arg.Args), lhclient.NewLowLevelClientFromConn(conn))
 	if err != nil {
-		return err
+		return nil, err
 	}
+	m.SetName(mixerName(conn, arg))
+	return m, nil
+}
 
-	a.HumanOpt.CanMix = false
-	a.Auto.Target.AddDevice(d)
-	return nil
+// mixerName returns a name for a mixer that distinguishes it from mixers
+// of other drivers
+func mixerName(conn *grpc.ClientConn, arg DriverArg) string {
+	return fmt.Sprintf("%s mixer at %s", arg.Subtypes[0], conn.Target())
 }
 
 func getMixerOpt(maybeArgs []interface{}) (ret mixer.Opt) {
@@ -0,0 +1,169 @@
+package centrifuge
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/driver"
+	centrifuge "github.com/antha-lang/antha/driver/antha_centrifuge_v1"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/handler"
+)
+
+// standardGravity is the acceleration of one g in m/s^2
+const standardGravity = 9.80665
+
+// An Opt is the range of settings that a centrifuge can reach. Limits that
+// are not set are not checked.
+type Opt struct {
+	MinTemp  wunit.Temperature
+	MaxTemp  wunit.Temperature
+	MaxForce wunit.Acceleration
+}
+
+// A Centrifuge is a device that can spin things
+type Centrifuge struct {
+	handler.GenericHandler
+	opt Opt
+}
+
+// New returns a new centrifuge
+func New(opt Opt) *Centrifuge {
+	ret := &Centrifuge{opt: opt}
+	ret.GenericHandler = handler.GenericHandler{
+		Labels: []ast.NameValue{
+			target.DriverSelectorV1Centrifuge,
+		},
+		GenFunc: ret.generate,
+	}
+	return ret
+}
+
+// Capabilities implements a target.Capable
+func (a *Centrifuge) Capabilities() []ast.Capability {
+	return []ast.Capability{
+		ast.RangeCapability{Name: target.CapabilityTemperature, Min: a.opt.MinTemp, Max: a.opt.MaxTemp},
+		ast.RangeCapability{Name: target.CapabilityForce, Max: a.opt.MaxForce},
+	}
+}
+
+func (a *Centrifuge) lidOpen() driver.Call {
+	return driver.Call{
+		Method: "/antha.centrifuge.v1.Centrifuge/LidOpen",
+		Args:   &centrifuge.Blank{},
+		Reply:  &centrifuge.BoolReply{},
+	}
+}
+
+func (a *Centrifuge) lidClose() driver.Call {
+	return driver.Call{
+		Method: "/antha.centrifuge.v1.Centrifuge/LidClose",
+		Args:   &centrifuge.Blank{},
+		Reply:  &centrifuge.BoolReply{},
+	}
+}
+
+func (a *Centrifuge) reset() []driver.Call {
+	return []driver.Call{
+		{
+			Method: "/antha.centrifuge.v1.Centrifuge/SpinStop",
+			Args:   &centrifuge.Blank{},
+			Reply:  &centrifuge.BoolReply{},
+		},
+		{
+			Method: "/antha.centrifuge.v1.Centrifuge/TemperatureReset",
+			Args:   &centrifuge.Blank{},
+			Reply:  &centrifuge.BoolReply{},
+		},
+		a.lidOpen(),
+	}
+}
+
+func (a *Centrifuge) temperatureSet(temp wunit.Temperature) driver.Call {
+	return driver.Call{
+		Method: "/antha.centrifuge.v1.Centrifuge/TemperatureSet",
+		Args: &centrifuge.TemperatureSettings{
+			Temperature: temp.RawValue(), // in C
+		},
+		Reply: &centrifuge.BoolReply{},
+	}
+}
+
+func (a *Centrifuge) spinStart(inst *ast.CentrifugeInst) driver.Call {
+	return driver.Call{
+		Method: "/antha.centrifuge.v1.Centrifuge/SpinStart",
+		Args: &centrifuge.SpinSettings{
+			Rcf:              inst.Force.SIValue() / standardGravity,
+			Time:             driver.Seconds(inst.Time),
+			AccelerationTime: driver.Seconds(inst.AccelTime),
+			DecelerationTime: driver.Seconds(inst.DecelTime),
+		},
+		Reply: &centrifuge.BoolReply{},
+	}
+}
+
+func (a *Centrifuge) generate(cmd interface{}) ([]ast.Inst, error) {
+	spin, ok := cmd.(*ast.CentrifugeInst)
+	if !ok {
+		return nil, fmt.Errorf("expecting %T found %T instead", spin, cmd)
+	}
+
+	initializers := []ast.Inst{
+		&target.Run{
+			Dev:   a,
+			Label: "open centrifuge lid",
+			Calls: []driver.Call{
+				a.lidOpen(),
+			},
+		},
+	}
+
+	finalizers := []ast.Inst{
+		&target.Run{
+			Dev:   a,
+			Label: "turn off centrifuge",
+			Calls: a.reset(),
+		},
+	}
+
+	var insts ast.Insts
+	if !spin.Temp.IsNil() {
+		insts = append(insts, &target.Run{
+			Dev:   a,
+			Label: "set centrifuge temperature",
+			Calls: []driver.Call{
+				a.temperatureSet(spin.Temp),
+			},
+		})
+	}
+
+	insts = append(insts, &target.Run{
+		Dev:     a,
+		Label:   "spin",
+		Details: spin.String(),
+		Calls: []driver.Call{
+			a.lidClose(),
+			a.spinStart(spin),
+		},
+		Initializers: initializers,
+		Finalizers:   finalizers,
+	})
+
+	total := driver.Seconds(spin.Time) + driver.Seconds(spin.AccelTime) + driver.Seconds(spin.DecelTime)
+	insts = append(insts, &target.TimedWait{
+		Duration: time.Duration(total * float64(time.Second)),
+	})
+
+	insts = append(insts, &target.Run{
+		Dev:   a,
+		Label: "open centrifuge lid",
+		Calls: []driver.Call{
+			a.lidOpen(),
+		},
+	})
+
+	insts.SequentialOrder()
+	return insts, nil
+}
@@ -22,8 +22,11 @@ type Human struct {
 
 // An Opt is a set of options to configure a human device
 type Opt struct {
-	CanMix      bool
-	CanIncubate bool
+	CanMix         bool
+	CanIncubate    bool
+	CanCentrifuge  bool
+	CanThermocycle bool
+	CanSeal        bool
 }
 
 // New returns a new human device
@@ -48,6 +51,18 @@ func (a *Human) CanCompile(req ast.Request) bool {
 		can.Selector = append(can.Selector, target.DriverSelectorV1ShakerIncubator)
 	}
 
+	if a.opt.CanCentrifuge {
+		can.Selector = append(can.Selector, target.DriverSelectorV1Centrifuge)
+	}
+
+	if a.opt.CanThermocycle {
+		can.Selector = append(can.Selector, target.DriverSelectorV1Thermocycler)
+	}
+
+	if a.opt.CanSeal {
+		can.Selector = append(can.Selector, target.DriverSelectorV1Sealer)
+	}
+
 	if a.opt.CanMix {
 		can.Selector = append(can.Selector, target.DriverSelectorV1Mixer)
 	}
@@ -62,13 +77,14 @@ func (a *Human) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, erro
 
 func (a *Human) generate(cmd interface{}) ([]ast.Inst, error) {
 
-	var insts []ast.Inst
+	var insts ast.Insts
 
 	switch cmd := cmd.(type) {
 
 	case *wtype.LHInstruction:
 		insts = append(insts, &target.Manual{
 			Dev:     a,
+			Inst:    cmd,
 			Label:   "mix",
 			Details: prettyMixDetails(cmd),
 		})
@@ -76,10 +92,37 @@ func (a *Human) generate(cmd interface{}) ([]ast.Inst, error) {
 	case *ast.IncubateInst:
 		insts = append(insts, &target.Manual{
 			Dev:     a,
+			Inst:    cmd,
 			Label:   "incubate",
 			Details: fmt.Sprintf("incubate at %s for %s", cmd.Temp.ToString(), cmd.Time.ToString()),
 		})
 
+	case *ast.CentrifugeInst:
+		insts = append(insts, &target.Manual{
+			Dev:     a,
+			Inst:    cmd,
+			Label:   "centrifuge",
+			Details: cmd.String(),
+		})
+
+	case *ast.ThermocycleInst:
+		insts = append(insts, &target.Manual{
+			Dev:     a,
+			Inst:    cmd,
+			Label:   "thermocycle",
+			Details: fmt.Sprintf("thermocycle %s: %s", cmd.ComponentIn.CName, cmd.Programme),
+		}, &target.TimedWait{
+			Duration: cmd.Programme.Duration(),
+		})
+
+	case *ast.CoverInst:
+		insts = append(insts, &target.Manual{
+			Dev:     a,
+			Inst:    cmd,
+			Label:   string(cmd.Action),
+			Details: cmd.String(),
+		})
+
 	case *ast.PromptInst:
 		insts = append(insts, &target.Prompt{
 			Message: cmd.Message,
@@ -88,6 +131,7 @@ func (a *Human) generate(cmd interface{}) ([]ast.Inst, error) {
 	case *wtype.PRInstruction:
 		insts = append(insts, &target.Manual{
 			Dev:     a,
+			Inst:    cmd,
 			Label:   "plate-read",
 			Details: fmt.Sprintf("plate-read instruction. Options:'%s'", cmd.Options),
 		})
@@ -95,6 +139,7 @@ func (a *Human) generate(cmd interface{}) ([]ast.Inst, error) {
 	case *ast.QPCRInstruction:
 		insts = append(insts, &target.Manual{
 			Dev:     a,
+			Inst:    cmd,
 			Label:   "QPCR",
 			Details: fmt.Sprintf("QPCR request, definition %s, barcode %s", cmd.Definition, cmd.Barcode),
 		})
@@ -103,6 +148,7 @@ func (a *Human) generate(cmd interface{}) ([]ast.Inst, error) {
 		return nil, fmt.Errorf("unknown inst %T", cmd)
 	}
 
+	insts.SequentialOrder()
 	return insts, nil
 }
 
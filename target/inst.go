@@ -87,6 +87,7 @@ type SetupIncubator struct {
 
 var (
 	_ TimeEstimator = (*Mix)(nil)
+	_ TimeEstimator = (*TimedWait)(nil)
 	_ Initializer   = (*Mix)(nil)
 )
 
@@ -149,10 +150,12 @@ type MixSummary struct {
 	Actions []byte
 }
 
-// NewMixSummary construct a new MixSummary object from the instructions and initial and final robot states
+// NewMixSummary construct a new MixSummary object from the instructions and initial and final robot states of the named device
+// along with any predicted evaporation losses, the placement of mixes by output layouts and the rearrangement of plates
+// to reduce head movement
 // an error is returned if the parameters are invalid of if either summary object fails JSON-schema validation
-func NewMixSummary(itree *liquidhandling.ITree, initial *liquidhandling.LHProperties, final *liquidhandling.LHProperties, idMap map[string]string) (*MixSummary, error) {
-	layout, layoutErr := lh.SummarizeLayout(initial, final, idMap)
+func NewMixSummary(device string, itree *liquidhandling.ITree, initial *liquidhandling.LHProperties, final *liquidhandling.LHProperties, idMap map[string]string, evaporation []lh.EvaporationLoss, outputLayouts []lh.OutputLayoutRecord, layoutOptimization *liquidhandling.LayoutOptimization) (*MixSummary, error) {
+	layout, layoutErr := lh.SummarizeDeviceLayout(device, initial, final, idMap, evaporation, outputLayouts, layoutOptimization)
 	actions, actionsErr := lh.SummarizeActions(initial, itree)
 	return &MixSummary{
 		Layout:  layout,
@@ -160,6 +163,24 @@ func NewMixSummary(itree *liquidhandling.ITree, initial *liquidhandling.LHProper
 	}, utils.ErrorSlice{layoutErr, actionsErr}.Pack()
 }
 
+// A Move is a transfer of a plate between the nests of two devices by a plate
+// mover
+type Move struct {
+	dependsMixin
+
+	Dev       ast.Device
+	PlateID   string
+	PlateName string
+	Labware   string // Labware type of the plate
+	From      string // Name of the nest to take the plate from
+	To        string // Name of the nest to put the plate in
+}
+
+// Device implements an Inst
+func (a *Move) Device() ast.Device {
+	return a.Dev
+}
+
 // A Manual is human-aided interaction
 type Manual struct {
 	dependsMixin
@@ -167,6 +188,9 @@ type Manual struct {
 	Dev     ast.Device
 	Label   string
 	Details string
+	// Command that the instruction carries out, if any, e.g., an
+	// *ast.IncubateInst
+	Inst interface{}
 }
 
 // Device implements an Inst
@@ -216,6 +240,15 @@ type Prompt struct {
 	noDeviceMixin
 
 	Message string
+	// Transfer is set if the prompt asks for plates to be put into or taken
+	// out of a device, so that it can be dropped when a plate mover does so
+	Transfer *PlateTransfer
+}
+
+// A PlateTransfer is a manual transfer of plates into or out of a device
+type PlateTransfer struct {
+	Unload   bool // Whether the plates are taken out of the device
+	PlateIDs []string
 }
 
 // Wait is a virtual instruction to hang dependencies on. A better name might
@@ -232,3 +265,8 @@ type TimedWait struct {
 
 	Duration time.Duration
 }
+
+// GetTimeEstimate implements a TimeEstimator
+func (a *TimedWait) GetTimeEstimate() float64 {
+	return a.Duration.Seconds()
+}
@@ -20,7 +20,8 @@ import (
 )
 
 var (
-	_ ast.Device = &Mixer{}
+	_ ast.Device          = &Mixer{}
+	_ target.MixEstimator = &Mixer{}
 )
 
 // A Mixer is a device plugin for mixer devices
@@ -28,12 +29,22 @@ type Mixer struct {
 	driver     driver.LiquidhandlingDriver
 	properties *driver.LHProperties // Prototype to create fresh properties
 	opt        Opt
+	name       string
 }
 
 func (a *Mixer) String() string {
+	if len(a.name) != 0 {
+		return a.name
+	}
 	return "Mixer"
 }
 
+// SetName sets the name that distinguishes the mixer from other mixers, e.g.,
+// in layout summaries
+func (a *Mixer) SetName(name string) {
+	a.name = name
+}
+
 // CanCompile implements a Device
 func (a *Mixer) CanCompile(req ast.Request) bool {
 	// TODO: Add specific volume constraints
@@ -49,6 +60,32 @@ func (a *Mixer) CanCompile(req ast.Request) bool {
 	return can.Contains(req)
 }
 
+// EstimateMixTime implements a target.MixEstimator. Each input is assumed to
+// take a fresh tip, an aspirate and a dispense.
+func (a *Mixer) EstimateMixTime(mixes []*wtype.LHInstruction) float64 {
+	var transfer time.Duration
+	if timer := a.properties.GetTimer(); timer != nil {
+		for _, ins := range []driver.RobotInstruction{
+			driver.NewLoadTipsInstruction(),
+			driver.NewAspirateInstruction(),
+			driver.NewDispenseInstruction(),
+			driver.NewUnloadTipsInstruction(),
+		} {
+			transfer += timer.TimeFor(ins)
+		}
+	}
+	// Without a timer, count transfers so that mixes can still be compared
+	if transfer <= 0 {
+		transfer = time.Second
+	}
+
+	var est time.Duration
+	for _, mix := range mixes {
+		est += time.Duration(len(mix.Inputs)) * transfer
+	}
+	return est.Seconds()
+}
+
 // FileType returns the file type for generated files
 func (a *Mixer) FileType() (ftype string) {
 	if m := a.properties.Mnfr; len(m) != 0 {
@@ -209,6 +246,20 @@ func (a *Mixer) makeLhreq(ctx context.Context) (*lhreq, error) {
 
 	req.Options.IgnorePhysicalSimulation = a.opt.IgnorePhysicalSimulation
 
+	// peel sealed plates
+
+	req.Options.PeelSealedPlates = a.opt.PeelSealedPlates
+
+	// rearrange plates to reduce head movement
+
+	req.Options.OptimizeLayout = a.opt.OptimizeLayout
+
+	// where to put mixes which don't specify a well
+
+	if a.opt.OutputLayout != nil {
+		req.Options.OutputLayout = *a.opt.OutputLayout
+	}
+
 	return &lhreq{
 		LHRequest:     req,
 		LHProperties:  prop,
@@ -234,7 +285,26 @@ func (a *Mixer) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, erro
 		return nil, err
 	}
 
-	return []ast.Inst{mix}, nil
+	// Seals and lids come off before the mix. Seals are peeled by the sealer
+	// of the target, if there is one, and otherwise by hand, as are lids.
+	var peeler target.Peeler
+	if t, err := target.GetTarget(ctx); err == nil {
+		peeler = t.Peeler()
+	}
+	var insts ast.Insts
+	for _, u := range mix.Request.Uncoverings {
+		if u.Cover == wtype.Sealed && peeler != nil {
+			insts = append(insts, peeler.Peel(u.Plate)...)
+			continue
+		}
+		insts = append(insts, &target.Prompt{
+			Message: u.String(),
+		})
+	}
+	insts = append(insts, mix)
+	insts.SequentialOrder()
+
+	return insts, nil
 }
 
 func (a *Mixer) saveFile(name string) ([]byte, error) {
@@ -439,7 +509,7 @@ func (a *Mixer) makeMix(ctx context.Context, mixes []*wtype.LHInstruction) (*tar
 		return nil, err
 	}
 
-	summary, err := target.NewMixSummary(r.LHRequest.InstructionTree, r.LHProperties, r.Liquidhandler.FinalProperties, r.Liquidhandler.PlateIDMap())
+	summary, err := target.NewMixSummary(a.String(), r.LHRequest.InstructionTree, r.LHProperties, r.Liquidhandler.FinalProperties, r.Liquidhandler.PlateIDMap(), r.LHRequest.Evaporation, r.LHRequest.OutputLayouts, r.LHRequest.LayoutOptimization)
 
 	return &target.Mix{
 		Dev:             a,
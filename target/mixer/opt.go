@@ -49,13 +49,18 @@ type Opt struct {
 	DriverSpecificTipWastePreferences []string `json:"driverSpecificTipWastePreferences,omitempty"`
 	DriverSpecificWashPreferences     []string `json:"driverSpecificWashPreferences,omitempty"`
 
-	ModelEvaporation         bool `json:"modelEvaporation"`
+	ModelEvaporation         bool `json:"modelEvaporation"` // Allow for liquid lost from open wells
 	OutputSort               bool `json:"outputSort"`
 	PrintInstructions        bool `json:"printInstructions"`
 	UseDriverTipTracking     bool `json:"useDriverTipTracking"`
 	LegacyVolume             bool `json:"legacyVolume"`             // Don't track volumes for intermediates
 	FixVolumes               bool `json:"fixVolumes"`               // Aim to revise requested volumes to service requirements
 	IgnorePhysicalSimulation bool `json:"ignorePhysicalSimulation"` //ignore errors in physical simulation
+	PeelSealedPlates         bool `json:"peelSealedPlates"`         // Peel sealed plates rather than rejecting plans that use them
+	OptimizeLayout           bool `json:"optimizeLayout"`           // Rearrange plates to reduce head movement
+
+	// How to place mixes on output plates when no well is given
+	OutputLayout *wtype.OutputLayout `json:"outputLayout,omitempty"`
 
 	// Two ways to set user liquid policies rule set
 	CustomPolicyData    map[string]wtype.LHPolicy `json:"customPolicyData,omitempty"`    // Set rule set from policies
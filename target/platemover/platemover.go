@@ -0,0 +1,84 @@
+package platemover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/target"
+)
+
+// A Nest is a position on a device that a plate mover can put plates in and
+// take plates from
+type Nest struct {
+	Name string
+	// Driver type of the device the nest belongs to
+	Device string
+	// Labware types that the nest accepts; any if empty
+	Labware []string
+}
+
+// Accepts returns true if the nest accepts plates of the given labware type
+func (a Nest) Accepts(labware string) bool {
+	if len(a.Labware) == 0 {
+		return true
+	}
+	for _, l := range a.Labware {
+		if l == labware {
+			return true
+		}
+	}
+	return false
+}
+
+// A PlateMover is a robot arm or track that moves plates between devices
+type PlateMover struct {
+	Nests []Nest
+}
+
+var (
+	_ ast.Device        = (*PlateMover)(nil)
+	_ target.PlateMover = (*PlateMover)(nil)
+)
+
+// New returns a new plate mover that can reach the given nests
+func New(nests []Nest) *PlateMover {
+	return &PlateMover{Nests: nests}
+}
+
+// CanCompile implements a Device
+func (a *PlateMover) CanCompile(req ast.Request) bool {
+	can := ast.Request{}
+	can.Selector = append(can.Selector, target.DriverSelectorV1PlateMover)
+	return can.Contains(req)
+}
+
+// Compile implements a Device. Moves are generated by codegen between the
+// runs of other devices rather than requested by commands.
+func (a *PlateMover) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	return nil, fmt.Errorf("plate mover cannot compile commands")
+}
+
+// Nest implements a target.PlateMover
+func (a *PlateMover) Nest(d ast.Device, labware string) (string, error) {
+	var names []string
+	for _, n := range a.Nests {
+		if !d.CanCompile(ast.Request{
+			Selector: []ast.NameValue{
+				{Name: target.DriverSelectorV1Name, Value: n.Device},
+			},
+		}) {
+			continue
+		}
+		if n.Accepts(labware) {
+			return n.Name, nil
+		}
+		names = append(names, n.Name)
+	}
+
+	if len(names) == 0 {
+		return "", target.ErrNoNest
+	}
+	return "", fmt.Errorf("no nest accepts labware %q: nests %s do not", labware, strings.Join(names, ", "))
+}
@@ -4,14 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/antha-lang/antha/ast"
 	"github.com/antha-lang/antha/driver"
-	framework "github.com/antha-lang/antha/driver/antha_framework_v1"
 	quantstudio "github.com/antha-lang/antha/driver/antha_quantstudio_v1"
 	"github.com/antha-lang/antha/target"
-	"github.com/golang/protobuf/proto"
 )
 
 // QPCRDevice defines the state of a qpcr device device
@@ -39,50 +36,36 @@ func (dev *QPCRDevice) transform(inst *ast.QPCRInstruction) (ast.Inst, error) {
 		return nil, errors.New("Blank experiment file for qPCR instruction.")
 	}
 
-	message := &quantstudio.TemplatedRequest{
-		SessionInstrument: &quantstudio.SessionInstrument{
-			Session:    &quantstudio.Session{},
-			Instrument: &quantstudio.Instrument{},
-		},
-		TemplateFile: &quantstudio.ExperimentFile{
-			Url: inst.Definition,
-		},
-		Barcode: &quantstudio.Barcode{
-			Barcode: inst.Barcode,
-		},
+	sessionInstrument := &quantstudio.SessionInstrument{
+		Session:    &quantstudio.Session{},
+		Instrument: &quantstudio.Instrument{},
 	}
-
-	messageBytes, err := proto.Marshal(message)
-	if err != nil {
-		return nil, err
-	}
-
-	jobID := inst.TagAs
-	if len(jobID) == 0 {
-		jobID = os.Getenv("METADATA_JOB_ID")
+	experimentFile := &quantstudio.ExperimentFile{
+		Url: inst.Definition,
 	}
-
-	instID := ""
-	if len(inst.ComponentIn) > 0 {
-		instID = inst.ComponentIn[0].ID
+	barcode := &quantstudio.Barcode{
+		Barcode: inst.Barcode,
 	}
 
 	call := driver.Call{
-		Method: "RunFramework",
-		Args: &framework.CommandRequest{
-			Description: &framework.CommandDescription{
-				CommandName: inst.Command,
-				DeviceId:    "QPCRDevice",
-				CommandId:   instID,
-			},
-			Data: &framework.CommandData{
-				Data: messageBytes,
-			},
-			Metadata: &framework.CommandMetadata{
-				JobId: jobID,
-			},
-		},
-		Reply: &framework.CommandResponse{},
+		Method: "/antha.quantstudio.v1.QuantStudioService/" + inst.Command,
+		Reply:  &quantstudio.OptionalError{},
+	}
+	switch inst.Command {
+	case "RunExperimentFromTemplate":
+		call.Args = &quantstudio.TemplatedRequest{
+			SessionInstrument: sessionInstrument,
+			TemplateFile:      experimentFile,
+			Barcode:           barcode,
+		}
+	case "RunExperiment":
+		call.Args = &quantstudio.ExperimentRequest{
+			SessionInstrument: sessionInstrument,
+			ExperimentFile:    experimentFile,
+			Barcode:           barcode,
+		}
+	default:
+		return nil, fmt.Errorf("unknown qPCR command %q", inst.Command)
 	}
 
 	return &target.Run{
@@ -0,0 +1,66 @@
+package qpcrdevice
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/ast"
+	quantstudio "github.com/antha-lang/antha/driver/antha_quantstudio_v1"
+	"github.com/antha-lang/antha/target"
+)
+
+func transformCall(t *testing.T, command string) (*target.Run, error) {
+	inst, err := New().transform(&ast.QPCRInstruction{
+		Definition: "experiment.edt",
+		Barcode:    "bc1",
+		Command:    command,
+	})
+	if err != nil {
+		return nil, err
+	}
+	run, ok := inst.(*target.Run)
+	if !ok {
+		t.Fatalf("expecting %T found %T", run, inst)
+	}
+	if len(run.Calls) != 1 {
+		t.Fatalf("expecting 1 call found %d", len(run.Calls))
+	}
+	return run, nil
+}
+
+func TestTransform(t *testing.T) {
+	run, err := transformCall(t, "RunExperiment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := run.Calls[0]
+	if e, f := "/antha.quantstudio.v1.QuantStudioService/RunExperiment", c.Method; e != f {
+		t.Errorf("expecting method %q found %q", e, f)
+	}
+	req, ok := c.Args.(*quantstudio.ExperimentRequest)
+	if !ok {
+		t.Fatalf("expecting %T found %T", req, c.Args)
+	}
+	if e, f := "experiment.edt", req.GetExperimentFile().GetUrl(); e != f {
+		t.Errorf("expecting experiment file %q found %q", e, f)
+	}
+	if e, f := "bc1", req.GetBarcode().GetBarcode(); e != f {
+		t.Errorf("expecting barcode %q found %q", e, f)
+	}
+	if _, ok := c.Reply.(*quantstudio.OptionalError); !ok {
+		t.Errorf("expecting %T reply found %T", &quantstudio.OptionalError{}, c.Reply)
+	}
+
+	run, err = transformCall(t, "RunExperimentFromTemplate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr, ok := run.Calls[0].Args.(*quantstudio.TemplatedRequest); !ok {
+		t.Errorf("expecting %T found %T", tr, run.Calls[0].Args)
+	} else if e, f := "experiment.edt", tr.GetTemplateFile().GetUrl(); e != f {
+		t.Errorf("expecting template file %q found %q", e, f)
+	}
+
+	if _, err := transformCall(t, "Unknown"); err == nil {
+		t.Error("expecting error for unknown command")
+	}
+}
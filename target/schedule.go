@@ -0,0 +1,184 @@
+package target
+
+import (
+	"sort"
+	"time"
+
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/graph"
+)
+
+// A Job is an instruction placed in time
+type Job struct {
+	Inst ast.Inst
+	// Device that is busy for the duration of the job, if any
+	Device ast.Device
+	// Start and End are relative to the start of the schedule
+	Start time.Duration
+	End   time.Duration
+	// Job that determines the start of this job, either a dependency or the
+	// previous job on the same device, or nil if the job starts immediately
+	After *Job
+	// True if the job is on the critical path
+	Critical bool
+}
+
+// A Schedule assigns start and end times to instructions such that each
+// instruction starts after its dependencies end and each device does one job
+// at a time
+type Schedule struct {
+	// Jobs in order of start time
+	Jobs []*Job
+	// Time until the last job ends
+	Makespan time.Duration
+	// Chain of jobs, in order, that determines the makespan. Delaying any of
+	// them delays the end of the schedule.
+	CriticalPath []*Job
+
+	jobs map[ast.Inst]*Job
+}
+
+// Job returns the job of an instruction or nil if the instruction is not
+// scheduled
+func (a *Schedule) Job(inst ast.Inst) *Job {
+	return a.jobs[inst]
+}
+
+// Estimate returns the estimated time to run an instruction or zero if it is
+// not known
+func Estimate(inst ast.Inst) time.Duration {
+	te, ok := inst.(TimeEstimator)
+	if !ok {
+		return 0
+	}
+	return time.Duration(te.GetTimeEstimate() * float64(time.Second))
+}
+
+// busyDevice returns the device that is busy while an instruction runs.
+// Timed waits do not have devices of their own but hold the device that they
+// wait on, e.g., an incubator during an incubation.
+func busyDevice(inst ast.Inst) ast.Device {
+	if d := inst.Device(); d != nil {
+		return d
+	}
+	if _, ok := inst.(*TimedWait); !ok {
+		return nil
+	}
+	var dev ast.Device
+	for _, dep := range inst.DependsOn() {
+		d := busyDevice(dep)
+		if d == nil || dev != nil && d != dev {
+			return nil
+		}
+		dev = d
+	}
+	return dev
+}
+
+// NewSchedule schedules a set of instructions using their time estimates.
+// Among the instructions that are ready to run, the one that can start
+// earliest is placed first, preferring instructions with the most work after
+// them.
+func NewSchedule(insts []ast.Inst) (*Schedule, error) {
+	order := make(map[ast.Inst]int)
+	for i, inst := range insts {
+		order[inst] = i
+	}
+
+	dependents := graph.Reverse(&Graph{Insts: insts})
+	rest, err := graph.LongestPath(graph.LongestPathOpt{
+		Graph: dependents,
+		Weight: func(n graph.Node) int64 {
+			return int64(Estimate(n.(ast.Inst)))
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Schedule{
+		jobs: make(map[ast.Inst]*Job),
+	}
+	last := make(map[ast.Device]*Job)
+
+	// earliest returns the earliest start of an instruction and the job
+	// that determines it
+	earliest := func(inst ast.Inst, dev ast.Device) (start time.Duration, after *Job) {
+		for _, dep := range inst.DependsOn() {
+			if j := s.jobs[dep]; j != nil && (after == nil || j.End > start) {
+				start = j.End
+				after = j
+			}
+		}
+		if j := last[dev]; dev != nil && j != nil && (after == nil || j.End > start) {
+			start = j.End
+			after = j
+		}
+		return
+	}
+
+	dag := graph.Schedule(dependents)
+	ready := dag.Roots
+	for len(ready) != 0 {
+		best := -1
+		var bestStart time.Duration
+		var bestAfter *Job
+		for i, n := range ready {
+			inst := n.(ast.Inst)
+			start, after := earliest(inst, busyDevice(inst))
+			if best >= 0 {
+				other := ready[best].(ast.Inst)
+				if start > bestStart {
+					continue
+				} else if start == bestStart {
+					if r, ro := rest.Dist[n], rest.Dist[other]; r < ro || r == ro && order[inst] > order[other] {
+						continue
+					}
+				}
+			}
+			best, bestStart, bestAfter = i, start, after
+		}
+
+		n := ready[best]
+		ready = append(ready[:best], ready[best+1:]...)
+
+		inst := n.(ast.Inst)
+		j := &Job{
+			Inst:   inst,
+			Device: busyDevice(inst),
+			Start:  bestStart,
+			End:    bestStart + Estimate(inst),
+			After:  bestAfter,
+		}
+		s.jobs[inst] = j
+		s.Jobs = append(s.Jobs, j)
+		if j.Device != nil {
+			last[j.Device] = j
+		}
+		if j.End > s.Makespan {
+			s.Makespan = j.End
+		}
+
+		ready = append(ready, dag.Visit(n)...)
+	}
+
+	sort.SliceStable(s.Jobs, func(i, j int) bool {
+		return s.Jobs[i].Start < s.Jobs[j].Start
+	})
+
+	// The critical path ends with the last job to end last, so that it
+	// includes any instantaneous jobs, e.g., prompts, that follow the job
+	// that they depend on
+	var end *Job
+	for _, j := range s.Jobs {
+		if end == nil || j.End >= end.End {
+			end = j
+		}
+	}
+	for j := end; j != nil; j = j.After {
+		j.Critical = true
+		s.CriticalPath = append([]*Job{j}, s.CriticalPath...)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,108 @@
+package target
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/antha-lang/antha/ast"
+)
+
+type device struct {
+	name string
+}
+
+func (a *device) CanCompile(req ast.Request) bool {
+	return false
+}
+
+func (a *device) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	return nil, nil
+}
+
+func TestSchedule(t *testing.T) {
+	incubator := &device{name: "incubator"}
+	centrifuge := &device{name: "centrifuge"}
+
+	r1 := &Run{Dev: incubator}
+	w1 := &TimedWait{Duration: 10 * time.Minute}
+	w1.SetDependsOn(r1)
+	r2 := &Run{Dev: incubator}
+	w2 := &TimedWait{Duration: 5 * time.Minute}
+	w2.SetDependsOn(r2)
+	r3 := &Run{Dev: centrifuge}
+	w3 := &TimedWait{Duration: 20 * time.Minute}
+	w3.SetDependsOn(r3)
+	p := &Prompt{}
+	p.SetDependsOn(w1, w2, w3)
+
+	s, err := NewSchedule([]ast.Inst{r1, w1, r2, w2, r3, w3, p})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, f := 20*time.Minute, s.Makespan; e != f {
+		t.Errorf("expected makespan %s found %s", e, f)
+	}
+
+	// Waits hold the device that they wait on
+	if j := s.Job(w1); j.Device != incubator {
+		t.Errorf("expected wait to hold incubator")
+	}
+	for _, x := range s.Jobs {
+		for _, y := range s.Jobs {
+			if x == y || x.Device == nil || x.Device != y.Device {
+				continue
+			}
+			if x.Start < y.End && y.Start < x.End {
+				t.Errorf("jobs overlap on same device: %v and %v", x, y)
+			}
+		}
+	}
+	if j := s.Job(w2); j.Start != 10*time.Minute || j.End != 15*time.Minute {
+		t.Errorf("expected second incubation from %s to %s found %s to %s", 10*time.Minute, 15*time.Minute, j.Start, j.End)
+	}
+
+	var path []ast.Inst
+	for _, j := range s.CriticalPath {
+		path = append(path, j.Inst)
+	}
+	expected := []ast.Inst{r3, w3, p}
+	if len(path) != len(expected) {
+		t.Fatalf("expected critical path of %d found %d", len(expected), len(path))
+	}
+	for i := range expected {
+		if path[i] != expected[i] {
+			t.Errorf("expected %T at %d of critical path found %T", expected[i], i, path[i])
+		}
+	}
+}
+
+func TestScheduleInstantaneousTail(t *testing.T) {
+	incubator := &device{name: "incubator"}
+
+	r := &Run{Dev: incubator}
+	w := &TimedWait{Duration: 10 * time.Minute}
+	w.SetDependsOn(r)
+	p := &Prompt{}
+	p.SetDependsOn(w)
+	m := &Manual{}
+	m.SetDependsOn(p)
+
+	s, err := NewSchedule([]ast.Inst{r, w, p, m})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, f := 10*time.Minute, s.Makespan; e != f {
+		t.Errorf("expected makespan %s found %s", e, f)
+	}
+	if n := len(s.CriticalPath); n == 0 || s.CriticalPath[n-1].Inst != m {
+		t.Errorf("expected critical path to end with manual step")
+	}
+	for _, inst := range []ast.Inst{w, p, m} {
+		if !s.Job(inst).Critical {
+			t.Errorf("expected %T to be critical", inst)
+		}
+	}
+}
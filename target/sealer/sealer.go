@@ -0,0 +1,131 @@
+package sealer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/driver"
+	sealer "github.com/antha-lang/antha/driver/antha_sealer_v1"
+	"github.com/antha-lang/antha/microArch/sampletracker"
+	"github.com/antha-lang/antha/target"
+)
+
+// A Sealer is a device that seals plates and peels seals from them
+type Sealer struct{}
+
+// Ensure satisfies Device and Peeler interfaces
+var (
+	_ ast.Device    = (*Sealer)(nil)
+	_ target.Peeler = (*Sealer)(nil)
+)
+
+// New returns a new sealer
+func New() *Sealer {
+	return &Sealer{}
+}
+
+// CanCompile implements a Device
+func (a *Sealer) CanCompile(req ast.Request) bool {
+	can := ast.Request{}
+	can.Selector = append(can.Selector, target.DriverSelectorV1Sealer)
+	return can.Contains(req)
+}
+
+func (a *Sealer) call(action ast.CoverAction, plateID string) (driver.Call, error) {
+	var method string
+	switch action {
+	case ast.SealAction:
+		method = "/antha.sealer.v1.Sealer/Seal"
+	case ast.PeelAction:
+		method = "/antha.sealer.v1.Sealer/Peel"
+	default:
+		return driver.Call{}, fmt.Errorf("sealer cannot %s plates", action)
+	}
+
+	return driver.Call{
+		Method: method,
+		Args: &sealer.SealRequest{
+			PlateId: plateID,
+		},
+		Reply: &sealer.BoolReply{},
+	}, nil
+}
+
+// Compile implements a Device
+func (a *Sealer) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	st := sampletracker.FromContext(ctx)
+
+	// Components on the same plate are sealed or peeled together
+	seen := make(map[string]bool)
+	var insts ast.Insts
+	for _, node := range nodes {
+		cmd, ok := node.(*ast.Command)
+		if !ok {
+			return nil, fmt.Errorf("expected Command. Got: %T", node)
+		}
+		inst, ok := cmd.Inst.(*ast.CoverInst)
+		if !ok {
+			return nil, fmt.Errorf("expected CoverInst. Got: %T", cmd.Inst)
+		}
+
+		plateID, _ := st.GetPlateOf(inst.ComponentIn.ID)
+		key := string(inst.Action) + ":" + plateID
+		if plateID == "" {
+			key += inst.ComponentIn.ID
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		call, err := a.call(inst.Action, plateID)
+		if err != nil {
+			return nil, err
+		}
+
+		insts = append(insts,
+			&target.Prompt{
+				Message:  fmt.Sprintf("put the plate holding %s into the sealer", inst.ComponentIn.CName),
+				Transfer: &target.PlateTransfer{PlateIDs: []string{plateID}},
+			},
+			&target.Run{
+				Dev:     a,
+				Label:   string(inst.Action),
+				Details: inst.String(),
+				Calls:   []driver.Call{call},
+			},
+			&target.Prompt{
+				Message:  fmt.Sprintf("remove the plate holding %s from the sealer", inst.ComponentIn.CName),
+				Transfer: &target.PlateTransfer{Unload: true, PlateIDs: []string{plateID}},
+			},
+		)
+	}
+
+	insts.SequentialOrder()
+	return insts, nil
+}
+
+// Peel implements a target.Peeler. The plate is peeled during the run of
+// another device, which the plate mover moves plates to and from, so moving
+// the plate into and out of the sealer is always left to the user.
+func (a *Sealer) Peel(plate *wtype.Plate) []ast.Inst {
+	call, _ := a.call(ast.PeelAction, plate.ID)
+	insts := ast.Insts{
+		&target.Prompt{
+			Message: fmt.Sprintf("put plate %q into the sealer", plate.PlateName),
+		},
+		&target.Run{
+			Dev:     a,
+			Label:   string(ast.PeelAction),
+			Details: fmt.Sprintf("peel the seal from plate %q", plate.PlateName),
+			Calls:   []driver.Call{call},
+		},
+		&target.Prompt{
+			Message: fmt.Sprintf("remove plate %q from the sealer", plate.PlateName),
+		},
+	}
+	insts.SequentialOrder()
+	return insts
+}
@@ -0,0 +1,50 @@
+package sealer
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	sealer "github.com/antha-lang/antha/driver/antha_sealer_v1"
+	"github.com/antha-lang/antha/target"
+	"github.com/antha-lang/antha/target/human"
+)
+
+func TestPeel(t *testing.T) {
+	machine := target.New()
+	machine.AddDevice(human.New(human.Opt{CanSeal: true}))
+	if p := machine.Peeler(); p != nil {
+		t.Fatalf("expecting no peeler found %v", p)
+	}
+	machine.AddDevice(New())
+	p := machine.Peeler()
+	if p == nil {
+		t.Fatal("expecting sealer to peel plates")
+	}
+
+	plate := &wtype.Plate{ID: "plate1", PlateName: "samples"}
+	insts := p.Peel(plate)
+	if len(insts) != 3 {
+		t.Fatalf("expecting %d instructions found %d", 3, len(insts))
+	}
+	for _, inst := range []int{0, 2} {
+		if prompt, ok := insts[inst].(*target.Prompt); !ok {
+			t.Errorf("expecting prompt found %T", insts[inst])
+		} else if prompt.Transfer != nil {
+			t.Errorf("expecting transfer to be left to the user found %v", prompt.Transfer)
+		}
+	}
+
+	run, ok := insts[1].(*target.Run)
+	if !ok {
+		t.Fatalf("expecting run found %T", insts[1])
+	}
+	if e, f := "/antha.sealer.v1.Sealer/Peel", run.Calls[0].Method; e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+	if req, ok := run.Calls[0].Args.(*sealer.SealRequest); !ok || req.PlateId != plate.ID {
+		t.Errorf("expecting request to peel %q found %v", plate.ID, run.Calls[0].Args)
+	}
+	if deps := run.DependsOn(); len(deps) != 1 || deps[0] != insts[0] {
+		t.Errorf("expecting peel to follow loading the plate found %v", deps)
+	}
+}
@@ -12,14 +12,23 @@ import (
 	"github.com/antha-lang/antha/target/handler"
 )
 
+// An Opt is the range of settings that a shaker incubator can reach. Limits
+// that are not set are not checked.
+type Opt struct {
+	MinTemp      wunit.Temperature
+	MaxTemp      wunit.Temperature
+	MaxShakeRate wunit.Rate
+}
+
 // A ShakerIncubator is a device that can shake and incubate things
 type ShakerIncubator struct {
 	handler.GenericHandler
+	opt Opt
 }
 
 // New returns a new shaker incubator
-func New() *ShakerIncubator {
-	ret := &ShakerIncubator{}
+func New(opt Opt) *ShakerIncubator {
+	ret := &ShakerIncubator{opt: opt}
 	ret.GenericHandler = handler.GenericHandler{
 		Labels: []ast.NameValue{
 			target.DriverSelectorV1ShakerIncubator,
@@ -29,6 +38,14 @@ func New() *ShakerIncubator {
 	return ret
 }
 
+// Capabilities implements a target.Capable
+func (a *ShakerIncubator) Capabilities() []ast.Capability {
+	return []ast.Capability{
+		ast.RangeCapability{Name: target.CapabilityTemperature, Min: a.opt.MinTemp, Max: a.opt.MaxTemp},
+		ast.RangeCapability{Name: target.CapabilityShakeRate, Max: a.opt.MaxShakeRate},
+	}
+}
+
 func (a *ShakerIncubator) carrierOpen() driver.Call {
 	return driver.Call{
 		Method: "/antha.shakerincubator.v1.ShakerIncubator/CarrierOpen",
@@ -5,12 +5,17 @@ package target
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
 	"github.com/antha-lang/antha/ast"
 )
 
 var (
 	errNoTarget = errors.New("no target configuration found")
+	// ErrNoNest is returned by a PlateMover when a device has no nests
+	ErrNoNest = errors.New("device has no nests")
 )
 
 const (
@@ -29,6 +34,22 @@ var (
 		Name:  DriverSelectorV1Name,
 		Value: "antha.shakerincubator.v1.ShakerIncubator",
 	}
+	DriverSelectorV1Centrifuge = ast.NameValue{
+		Name:  DriverSelectorV1Name,
+		Value: "antha.centrifuge.v1.Centrifuge",
+	}
+	DriverSelectorV1Thermocycler = ast.NameValue{
+		Name:  DriverSelectorV1Name,
+		Value: "antha.thermocycler.v1.Thermocycler",
+	}
+	DriverSelectorV1Sealer = ast.NameValue{
+		Name:  DriverSelectorV1Name,
+		Value: "antha.sealer.v1.Sealer",
+	}
+	DriverSelectorV1PlateMover = ast.NameValue{
+		Name:  DriverSelectorV1Name,
+		Value: "antha.platemover.v1.PlateMover",
+	}
 	DriverSelectorV1Mixer = ast.NameValue{
 		Name:  DriverSelectorV1Name,
 		Value: "antha.mixer.v1.Mixer",
@@ -51,6 +72,18 @@ var (
 	}
 )
 
+// Names of well known device capabilities
+const (
+	// CapabilityTemperature is the range of temperatures that a device can
+	// reach
+	CapabilityTemperature = "temperature"
+	// CapabilityShakeRate is the range of rates at which a device can shake
+	CapabilityShakeRate = "shake rate"
+	// CapabilityForce is the range of accelerations at which a device can
+	// spin
+	CapabilityForce = "force"
+)
+
 type targetKey int
 
 const theTargetKey targetKey = 0
@@ -69,14 +102,23 @@ func WithTarget(parent context.Context, t *Target) context.Context {
 	return context.WithValue(parent, theTargetKey, t)
 }
 
+// A Capable is a device that advertises its capabilities
+type Capable interface {
+	ast.Device
+	Capabilities() []ast.Capability
+}
+
 // Target for execution (collection of devices).
 type Target struct {
-	devices []ast.Device
+	devices      []ast.Device
+	capabilities map[ast.Device][]ast.Capability
 }
 
 // New creates a new target
 func New() *Target {
-	return &Target{}
+	return &Target{
+		capabilities: make(map[ast.Device][]ast.Capability),
+	}
 }
 
 func (a *Target) canCompile(d ast.Device, reqs ...ast.Request) bool {
@@ -88,17 +130,131 @@ func (a *Target) canCompile(d ast.Device, reqs ...ast.Request) bool {
 	return true
 }
 
+// satisfies returns an error if a device does not have the capabilities
+// needed by the given set of requests
+func (a *Target) satisfies(d ast.Device, reqs ...ast.Request) error {
+	return ast.Satisfies(a.Capabilities(d), ast.Meet(reqs...))
+}
+
 // CanCompile returns the devices that can compile the given set of requests
 func (a *Target) CanCompile(reqs ...ast.Request) (r []ast.Device) {
 	for _, d := range a.devices {
-		if a.canCompile(d, reqs...) {
+		if a.canCompile(d, reqs...) && a.satisfies(d, reqs...) == nil {
 			r = append(r, d)
 		}
 	}
 	return
 }
 
+// Unsatisfiable returns an error describing why no device can compile the
+// given set of requests or nil if some device can
+func (a *Target) Unsatisfiable(reqs ...ast.Request) error {
+	var errs []string
+	for _, d := range a.devices {
+		if !a.canCompile(d, reqs...) {
+			continue
+		}
+		err := a.satisfies(d, reqs...)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", a.Name(d), err))
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no device can handle constraints %v", ast.Meet(reqs...).Selector)
+	}
+	return fmt.Errorf("no device has the capabilities needed: %s", strings.Join(errs, "; "))
+}
+
 // AddDevice adds a device to the target configuration
 func (a *Target) AddDevice(d ast.Device) {
 	a.devices = append(a.devices, d)
 }
+
+// Advertise adds capabilities to a device in addition to those that it
+// advertises itself, e.g., from configuration
+func (a *Target) Advertise(d ast.Device, caps ...ast.Capability) {
+	a.capabilities[d] = append(a.capabilities[d], caps...)
+}
+
+// Capabilities returns the capabilities of a device
+func (a *Target) Capabilities(d ast.Device) []ast.Capability {
+	var caps []ast.Capability
+	if c, ok := d.(Capable); ok {
+		caps = append(caps, c.Capabilities()...)
+	}
+	return append(caps, a.capabilities[d]...)
+}
+
+// A PlateMover is a device that moves plates between the nests of other
+// devices
+type PlateMover interface {
+	ast.Device
+	// Nest returns the name of a nest on the device that accepts plates of
+	// the given labware type. Returns ErrNoNest if the device has no nests.
+	Nest(d ast.Device, labware string) (string, error)
+}
+
+// PlateMover returns the device that moves plates between other devices or
+// nil if there is none
+func (a *Target) PlateMover() PlateMover {
+	for _, d := range a.devices {
+		if m, ok := d.(PlateMover); ok {
+			return m
+		}
+	}
+	return nil
+}
+
+// A Peeler is a device that peels the seals from plates for other devices,
+// e.g., so that a liquid handler can access their wells
+type Peeler interface {
+	ast.Device
+	// Peel returns the instructions to peel the seal from a plate
+	Peel(plate *wtype.Plate) []ast.Inst
+}
+
+// Peeler returns the device that peels seals from plates or nil if there is
+// none
+func (a *Target) Peeler() Peeler {
+	for _, d := range a.devices {
+		if p, ok := d.(Peeler); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// A MixEstimator is a device that can estimate the time in seconds to
+// perform a set of mixes before they are planned
+type MixEstimator interface {
+	EstimateMixTime(mixes []*wtype.LHInstruction) float64
+}
+
+// Name returns a name for a device that distinguishes it from the other
+// devices of the target. Devices with the same description are numbered in
+// the order they were added.
+func (a *Target) Name(d ast.Device) string {
+	describe := func(d ast.Device) string {
+		if s, ok := d.(fmt.Stringer); ok {
+			return s.String()
+		}
+		return fmt.Sprintf("%T", d)
+	}
+
+	name := describe(d)
+	var idx, count int
+	for _, dev := range a.devices {
+		if describe(dev) != name {
+			continue
+		}
+		count++
+		if dev == d {
+			idx = count
+		}
+	}
+	if count > 1 && idx > 0 {
+		return fmt.Sprintf("%s %d", name, idx)
+	}
+	return name
+}
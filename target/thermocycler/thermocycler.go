@@ -0,0 +1,284 @@
+package thermocycler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	"github.com/antha-lang/antha/driver"
+	thermocycler "github.com/antha-lang/antha/driver/antha_thermocycler_v1"
+	"github.com/antha-lang/antha/target"
+)
+
+// An Opt is the range of temperatures that a thermocycler can reach. Limits
+// that are not set are not checked.
+type Opt struct {
+	MinTemp wunit.Temperature
+	MaxTemp wunit.Temperature
+}
+
+// A Thermocycler is a device that runs thermocycling programmes, e.g., for
+// PCR
+type Thermocycler struct {
+	opt Opt
+}
+
+// Ensure satisfies Device interface
+var _ ast.Device = (*Thermocycler)(nil)
+
+// New returns a new thermocycler
+func New(opt Opt) *Thermocycler {
+	return &Thermocycler{opt: opt}
+}
+
+// Capabilities implements a target.Capable
+func (a *Thermocycler) Capabilities() []ast.Capability {
+	return []ast.Capability{
+		ast.RangeCapability{Name: target.CapabilityTemperature, Min: a.opt.MinTemp, Max: a.opt.MaxTemp},
+	}
+}
+
+// CanCompile implements a Device
+func (a *Thermocycler) CanCompile(req ast.Request) bool {
+	can := ast.Request{}
+	can.Selector = append(can.Selector, target.DriverSelectorV1Thermocycler)
+	return can.Contains(req)
+}
+
+// A location is where a component is on a plate
+type location struct {
+	PlateID   string
+	PlateName string
+	Well      string
+}
+
+// Compile implements a Device
+func (a *Thermocycler) Compile(ctx context.Context, nodes []ast.Node) ([]ast.Inst, error) {
+	var tcInsts []*ast.ThermocycleInst
+	ids := make(map[string]bool)
+	for _, node := range nodes {
+		cmd, ok := node.(*ast.Command)
+		if !ok {
+			return nil, fmt.Errorf("expected Command. Got: %T", node)
+		}
+		inst, ok := cmd.Inst.(*ast.ThermocycleInst)
+		if !ok {
+			return nil, fmt.Errorf("expected ThermocycleInst. Got: %T", cmd.Inst)
+		}
+		tcInsts = append(tcInsts, inst)
+		ids[inst.ComponentIn.GetID()] = true
+	}
+
+	// Components are renamed as they are mixed, so find the wells laid out
+	// by earlier mixes whose contents descend from them
+	locs := make(map[string]location)
+	for _, cmd := range ast.FindReachingCommands(nodes) {
+		for _, inst := range cmd.Output {
+			mix, ok := inst.(*target.Mix)
+			if !ok {
+				continue
+			}
+			for _, plate := range mix.FinalProperties.Plates {
+				for _, well := range plate.Wellcoords {
+					for id := range ids {
+						if strings.Contains(well.WContents.ParentID, id) {
+							locs[id] = location{
+								PlateID:   plate.ID,
+								PlateName: plate.PlateName,
+								Well:      well.Crds.FormatA1(),
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return a.mergeInsts(tcInsts, locs)
+}
+
+// A group is a set of components on the same plate that can be thermocycled
+// together
+type group struct {
+	Programme wtype.ThermocycleProgramme
+	Plate     location
+	Names     []string
+	Wells     []string
+}
+
+// ThermocycleInsts with the same programme on the same plate can be run
+// together
+func tcKey(inst *ast.ThermocycleInst, loc location) (string, error) {
+	bs, err := json.Marshal(inst.Programme)
+	if err != nil {
+		return "", err
+	}
+	return loc.PlateID + "\x00" + string(bs), nil
+}
+
+// mergeInsts groups compatible instructions into single runs of the
+// thermocycler
+func (a *Thermocycler) mergeInsts(tcInsts []*ast.ThermocycleInst, locs map[string]location) ([]ast.Inst, error) {
+	groups := make(map[string]*group)
+	var keys []string
+	for _, inst := range tcInsts {
+		loc := locs[inst.ComponentIn.GetID()]
+		key, err := tcKey(inst, loc)
+		if err != nil {
+			return nil, err
+		}
+		g, seen := groups[key]
+		if !seen {
+			g = &group{
+				Programme: inst.Programme,
+				Plate:     location{PlateID: loc.PlateID, PlateName: loc.PlateName},
+			}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.Names = append(g.Names, inst.ComponentIn.CName)
+		if loc.Well != "" {
+			g.Wells = append(g.Wells, loc.Well)
+		}
+	}
+
+	var insts ast.Insts
+	for _, key := range keys {
+		insts = append(insts, a.run(groups[key])...)
+	}
+	insts.SequentialOrder()
+	return insts, nil
+}
+
+// items returns a description of what is thermocycled by a group
+func (g *group) items() string {
+	if g.Plate.PlateID != "" {
+		return fmt.Sprintf("plate %q", g.Plate.PlateName)
+	}
+	names := append([]string(nil), g.Names...)
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func (a *Thermocycler) lidOpen() driver.Call {
+	return driver.Call{
+		Method: "/antha.thermocycler.v1.Thermocycler/LidOpen",
+		Args:   &thermocycler.Blank{},
+		Reply:  &thermocycler.BoolReply{},
+	}
+}
+
+func (a *Thermocycler) lidClose() driver.Call {
+	return driver.Call{
+		Method: "/antha.thermocycler.v1.Thermocycler/LidClose",
+		Args:   &thermocycler.Blank{},
+		Reply:  &thermocycler.BoolReply{},
+	}
+}
+
+func (a *Thermocycler) reset() []driver.Call {
+	return []driver.Call{
+		{
+			Method: "/antha.thermocycler.v1.Thermocycler/ProgrammeStop",
+			Args:   &thermocycler.Blank{},
+			Reply:  &thermocycler.BoolReply{},
+		},
+		a.lidOpen(),
+	}
+}
+
+// toProgramme converts a programme to its driver representation
+func toProgramme(prog wtype.ThermocycleProgramme) *thermocycler.Programme {
+	ret := &thermocycler.Programme{}
+	if !prog.LidTemp.IsNil() {
+		ret.HasLidTemperature = true
+		ret.LidTemperature = prog.LidTemp.SIValue() // in C
+	}
+	if !prog.HoldTemp.IsNil() {
+		ret.HasHoldTemperature = true
+		ret.HoldTemperature = prog.HoldTemp.SIValue() // in C
+	}
+	for _, stage := range prog.Stages {
+		s := &thermocycler.Stage{
+			Cycles: int32(stage.Cycles),
+		}
+		if s.Cycles <= 0 {
+			s.Cycles = 1
+		}
+		for _, step := range stage.Steps {
+			s.Steps = append(s.Steps, &thermocycler.Step{
+				Temperature: step.Temp.SIValue(), // in C
+				Time:        driver.Seconds(step.Time),
+				RampRate:    step.RampRate,
+			})
+		}
+		ret.Stages = append(ret.Stages, s)
+	}
+	return ret
+}
+
+func (a *Thermocycler) programmeStart(g *group) driver.Call {
+	return driver.Call{
+		Method: "/antha.thermocycler.v1.Thermocycler/ProgrammeStart",
+		Args: &thermocycler.ProgrammeRequest{
+			PlateId:   g.Plate.PlateID,
+			Wells:     g.Wells,
+			Programme: toProgramme(g.Programme),
+		},
+		Reply: &thermocycler.BoolReply{},
+	}
+}
+
+// run returns the instructions to run the programme of a group
+func (a *Thermocycler) run(g *group) []ast.Inst {
+	return []ast.Inst{
+		&target.Prompt{
+			Message:  fmt.Sprintf("put %s into the thermocycler", g.items()),
+			Transfer: &target.PlateTransfer{PlateIDs: []string{g.Plate.PlateID}},
+		},
+		&target.Run{
+			Dev:     a,
+			Label:   "thermocycle",
+			Details: g.Programme.String(),
+			Calls: []driver.Call{
+				a.lidClose(),
+				a.programmeStart(g),
+			},
+			Initializers: []ast.Inst{
+				&target.Run{
+					Dev:   a,
+					Label: "open thermocycler lid",
+					Calls: []driver.Call{
+						a.lidOpen(),
+					},
+				},
+			},
+			Finalizers: []ast.Inst{
+				&target.Run{
+					Dev:   a,
+					Label: "turn off thermocycler",
+					Calls: a.reset(),
+				},
+			},
+		},
+		&target.TimedWait{
+			Duration: g.Programme.Duration(),
+		},
+		&target.Run{
+			Dev:   a,
+			Label: "open thermocycler lid",
+			Calls: []driver.Call{
+				a.lidOpen(),
+			},
+		},
+		&target.Prompt{
+			Message:  fmt.Sprintf("remove %s from the thermocycler", g.items()),
+			Transfer: &target.PlateTransfer{Unload: true, PlateIDs: []string{g.Plate.PlateID}},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package thermocycler
+
+import (
+	"testing"
+
+	"github.com/antha-lang/antha/antha/anthalib/wtype"
+	"github.com/antha-lang/antha/antha/anthalib/wunit"
+	"github.com/antha-lang/antha/ast"
+	thermocycler "github.com/antha-lang/antha/driver/antha_thermocycler_v1"
+	"github.com/antha-lang/antha/target"
+)
+
+func makeProgramme(temp float64) wtype.ThermocycleProgramme {
+	return wtype.ThermocycleProgramme{
+		Stages: []wtype.ThermocycleStage{
+			{
+				Cycles: 30,
+				Steps: []wtype.ThermocycleStep{
+					{Temp: wunit.NewTemperature(95, "C"), Time: wunit.NewTime(10, "s")},
+					{Temp: wunit.NewTemperature(temp, "C"), Time: wunit.NewTime(30, "s")},
+				},
+			},
+		},
+	}
+}
+
+func TestMergeInsts(t *testing.T) {
+	var insts []*ast.ThermocycleInst
+	locs := make(map[string]location)
+	for idx, temp := range []float64{55, 55, 60} {
+		c := wtype.NewLHComponent()
+		c.CName = "reaction"
+		insts = append(insts, &ast.ThermocycleInst{
+			ComponentIn: c,
+			Programme:   makeProgramme(temp),
+		})
+		locs[c.ID] = location{
+			PlateID:   "plate1",
+			PlateName: "pcr",
+			Well:      []string{"A1", "B1", "C1"}[idx],
+		}
+	}
+
+	a := New(Opt{})
+	out, err := a.mergeInsts(insts, locs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var starts []*target.Run
+	for _, inst := range out {
+		if run, ok := inst.(*target.Run); ok && run.Label == "thermocycle" {
+			starts = append(starts, run)
+		}
+	}
+	if len(starts) != 2 {
+		t.Fatalf("expected 2 runs found %d", len(starts))
+	}
+
+	req := starts[0].Calls[1].Args.(*thermocycler.ProgrammeRequest)
+	if expected, got := []string{"A1", "B1"}, req.Wells; len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected wells %v got %v", expected, got)
+	}
+	if req.PlateId != "plate1" {
+		t.Errorf("expected plate %q got %q", "plate1", req.PlateId)
+	}
+	if n := len(req.Programme.Stages); n != 1 || req.Programme.Stages[0].Cycles != 30 {
+		t.Errorf("unexpected programme %v", req.Programme)
+	}
+}
@@ -105,7 +105,11 @@ func (a *WOPlateReader) mergePRInsts(prInsts []*wtype.PRInstruction, wellLocs ma
 
 	// Check for only 1 plate (for now)
 	plateLocUnique := make(map[string]bool)
+	var plateIDs []string
 	for _, plateID := range plateLocs {
+		if !plateLocUnique[plateID] {
+			plateIDs = append(plateIDs, plateID)
+		}
 		plateLocUnique[plateID] = true
 	}
 	if len(plateLocUnique) > 1 {
@@ -134,7 +138,7 @@ func (a *WOPlateReader) mergePRInsts(prInsts []*wtype.PRInstruction, wellLocs ma
 		plateID := plateLocs[cmpID]
 
 		call := driver.Call{
-			Method: "PRRunProtocolByName",
+			Method: "/antha.platereader.v1.PlateReader/PRRunProtocolByName",
 			Args: &platereader.ProtocolRunRequest{
 				ProtocolName:    "Custom",
 				PlateID:         plateID,
@@ -148,7 +152,8 @@ func (a *WOPlateReader) mergePRInsts(prInsts []*wtype.PRInstruction, wellLocs ma
 
 	insts := ast.Insts{
 		&target.Prompt{
-			Message: "Please put plate(s) into plate reader and click ok to start plate reader",
+			Message:  "Please put plate(s) into plate reader and click ok to start plate reader",
+			Transfer: &target.PlateTransfer{PlateIDs: plateIDs},
 		},
 		&target.Run{
 			Dev:   a,
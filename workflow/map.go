@@ -0,0 +1,165 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/antha-lang/antha/inject"
+)
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// InstanceName returns the name of the run of a map process for the item at
+// index idx
+func InstanceName(process string, idx int) string {
+	return fmt.Sprintf("%s[%d]", process, idx)
+}
+
+// SplitInstanceName returns the map process and item index of the name of a
+// run of a map process. If name is not the name of such a run, returns name
+// and -1.
+func SplitInstanceName(name string) (string, int) {
+	if !strings.HasSuffix(name, "]") {
+		return name, -1
+	}
+	open := strings.LastIndex(name, "[")
+	if open < 0 {
+		return name, -1
+	}
+	idx, err := strconv.Atoi(name[open+1 : len(name)-1])
+	if err != nil || idx < 0 {
+		return name, -1
+	}
+	return name[:open], idx
+}
+
+// mapItems returns the parameters of each run of a map process
+func mapItems(n *node) ([]inject.Value, error) {
+	length := -1
+	var first string
+	for _, port := range n.MapOver {
+		v := reflect.ValueOf(n.Params[port])
+		if k := v.Kind(); k != reflect.Slice && k != reflect.Array {
+			return nil, fmt.Errorf("cannot map over %q: value of type %T is not a slice", endpoint{Port: port, Node: n}, n.Params[port])
+		} else if length < 0 {
+			length = v.Len()
+			first = port
+		} else if v.Len() != length {
+			return nil, fmt.Errorf("cannot map over %q: length %d differs from length %d of %q",
+				endpoint{Port: port, Node: n}, v.Len(), length, endpoint{Port: first, Node: n})
+		}
+	}
+
+	items := make([]inject.Value, length)
+	for idx := range items {
+		item := make(inject.Value, len(n.Params))
+		for name, value := range n.Params {
+			item[name] = value
+		}
+		for _, port := range n.MapOver {
+			item[port] = reflect.ValueOf(n.Params[port]).Index(idx).Interface()
+		}
+		items[idx] = item
+	}
+	return items, nil
+}
+
+// gather collects the outputs of each run of a map process into slices. If
+// the function is typed, the slices have the types of its outputs;
+// otherwise, they are []interface{}.
+func gather(ctx context.Context, n *node, query inject.NameQuery, outs []inject.Value) (inject.Value, error) {
+	types := make(map[string]reflect.Type)
+	if runner, err := inject.Find(ctx, query); err != nil {
+		return nil, err
+	} else if tr, ok := runner.(inject.TypedRunner); ok {
+		if types, err = inject.FieldTypes(tr.Output()); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make(map[string]bool)
+	for name := range types {
+		names[name] = true
+	}
+	for name := range n.Outs {
+		names[name] = true
+	}
+	for _, out := range outs {
+		for name := range out {
+			names[name] = true
+		}
+	}
+
+	r := make(inject.Value)
+	for name := range names {
+		typ, ok := types[name]
+		if !ok {
+			typ = interfaceType
+		}
+		s := reflect.MakeSlice(reflect.SliceOf(typ), 0, len(outs))
+		for idx, out := range outs {
+			v := reflect.ValueOf(out[name])
+			if !v.IsValid() {
+				v = reflect.Zero(typ)
+			} else if !v.Type().AssignableTo(typ) {
+				return nil, fmt.Errorf("output %q of %q of type %s not assignable to type %s",
+					name, InstanceName(n.Process, idx), v.Type(), typ)
+			}
+			s = reflect.Append(s, v)
+		}
+		r[name] = s.Interface()
+	}
+	return r, nil
+}
+
+// callMap runs the function of a map process once per item, running up to
+// maxConcurrency items at a time. If any run fails, no further runs are
+// started and the errors of the failed runs are returned as ProcessErrors.
+func (a *Workflow) callMap(ctx context.Context, n *node, query inject.NameQuery, maxConcurrency int) (inject.Value, error) {
+	items, err := mapItems(n)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	outs := make([]inject.Value, len(items))
+	errs := make([]error, len(items))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for idx, params := range items {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(idx int, params inject.Value) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if outs[idx], errs[idx] = a.callProcess(ctx, InstanceName(n.Process, idx), query, params); errs[idx] != nil {
+				cancel()
+			}
+		}(idx, params)
+	}
+	wg.Wait()
+
+	var perrs ProcessErrors
+	for idx, err := range errs {
+		if err != nil {
+			perrs = append(perrs, &ProcessError{Process: InstanceName(n.Process, idx), Err: err})
+		}
+	}
+	if len(perrs) > 0 {
+		return nil, perrs
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return gather(ctx, n, query, outs)
+}
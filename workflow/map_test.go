@@ -0,0 +1,193 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+)
+
+func TestSplitInstanceName(t *testing.T) {
+	for name, golden := range map[string]struct {
+		Process string
+		Index   int
+	}{
+		InstanceName("A", 0):           {"A", 0},
+		InstanceName("A/B", 12):        {"A/B", 12},
+		"A":                            {"A", -1},
+		"A[x]":                         {"A[x]", -1},
+		"A]":                           {"A]", -1},
+		InstanceName("A", 1) + "[bad]": {"A[1][bad]", -1},
+	} {
+		if process, idx := SplitInstanceName(name); process != golden.Process || idx != golden.Index {
+			t.Errorf("%q: expecting %q, %d but got %q, %d", name, golden.Process, golden.Index, process, idx)
+		}
+	}
+}
+
+func TestRunMap(t *testing.T) {
+	ctx, err := createTypedContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type upperIn struct {
+		In, Suffix string
+	}
+	type upperOut struct {
+		Out string
+	}
+	var lock sync.Mutex
+	var seen []string
+	if err := inject.Add(ctx, inject.Name{Repo: "Upper", Stage: api.ElementStage_STEPS}, &inject.CheckedRunner{
+		RunFunc: func(ctx context.Context, value inject.Value) (inject.Value, error) {
+			lock.Lock()
+			seen = append(seen, ProcessFromContext(ctx))
+			lock.Unlock()
+			return inject.Value{"Out": strings.ToUpper(value["In"].(string)) + value["Suffix"].(string)}, nil
+		},
+		In:  &upperIn{},
+		Out: &upperOut{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var desc Desc
+	if err := json.Unmarshal([]byte(`{
+  "Processes": {
+    "Upper": {"component": "Upper", "map": ["In"]},
+    "Sink": {"component": "Untyped"}
+  },
+  "connections": [
+    {"source": {"process": "Upper", "port": "Out"}, "target": {"process": "Sink", "port": "In"}}
+  ]
+}`), &desc); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{FromDesc: &desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Upper", Port: "In"}, []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Upper", Port: "Suffix"}, "!"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := []string{"A!", "B!", "C!"}
+	if out := w.Outputs[Port{Process: "Sink", Port: "In"}]; !reflect.DeepEqual(out, golden) {
+		t.Errorf("expecting %v but got %v", golden, out)
+	}
+
+	sort.Strings(seen)
+	if golden := []string{"Upper[0]", "Upper[1]", "Upper[2]"}; !reflect.DeepEqual(seen, golden) {
+		t.Errorf("expecting processes %v but got %v", golden, seen)
+	}
+}
+
+func TestRunMapErrors(t *testing.T) {
+	ctx, err := createTypedContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "FailOnB", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			if value["In"] == "b" {
+				panic("b")
+			}
+			return inject.Value{"Out": value["In"]}, nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	newWorkflow := func(a, b interface{}) *Workflow {
+		w, err := New(Opt{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.AddMapNode("Map", "FailOnB", []string{"In", "Other"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.SetParam(Port{Process: "Map", Port: "In"}, a); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.SetParam(Port{Process: "Map", Port: "Other"}, b); err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+
+	w := newWorkflow([]string{"a", "b"}, []int{1})
+	if err := w.Run(ctx); err == nil || !strings.Contains(err.Error(), "length 1 differs") {
+		t.Errorf("expecting length error but got %v", err)
+	}
+
+	w = newWorkflow("a", []int{1})
+	if err := w.Run(ctx); err == nil || !strings.Contains(err.Error(), "is not a slice") {
+		t.Errorf("expecting slice error but got %v", err)
+	}
+
+	w = newWorkflow([]string{"a", "b"}, []int{1, 2})
+	err = w.Run(ctx)
+	errs, ok := err.(ProcessErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("expecting one process error but got %v", err)
+	}
+	inner, ok := errs[0].Err.(ProcessErrors)
+	if !ok || len(inner) != 1 {
+		t.Fatalf("expecting one item error but got %v", errs[0].Err)
+	} else if inner[0].Process != "Map[1]" {
+		t.Errorf("expecting error from %q but got %q", "Map[1]", inner[0].Process)
+	} else if _, ok := inner[0].Err.(*PanicError); !ok {
+		t.Errorf("expecting panic error but got %T", inner[0].Err)
+	}
+
+	w = newWorkflow([]string{}, []int{})
+	if err := w.Run(ctx); err != nil {
+		t.Error(err)
+	} else if out := w.Outputs[Port{Process: "Map", Port: "Out"}]; out != nil {
+		t.Errorf("expecting no outputs but got %v", out)
+	}
+}
+
+func TestValidateMap(t *testing.T) {
+	ctx, err := createTypedContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddMapNode("Map", "Copy", []string{"In"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddNode("Copy", "Copy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddEdge(Port{Process: "Map", Port: "Out"}, Port{Process: "Copy", Port: "In"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Map", Port: "In"}, []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Validate(ctx)
+	if err == nil || !strings.Contains(err.Error(), `port "Copy.In": type []string`) {
+		t.Errorf("expecting error connecting []string to string but got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/utils"
+)
+
+var (
+	errUnconnected = errors.New("parameter is neither connected nor assigned")
+	errNoTypeInfo  = errors.New("no type information")
+)
+
+// A PortError is an error with a port of a process or, if Port.Port is empty,
+// with the process itself
+type PortError struct {
+	Port Port
+	Err  error
+}
+
+// Error satisfies the error interface
+func (a *PortError) Error() string {
+	if a.Port.Port == "" {
+		return fmt.Sprintf("process %q: %s", a.Port.Process, a.Err)
+	}
+	return fmt.Sprintf("port %q: %s", a.Port, a.Err)
+}
+
+type portTypes struct {
+	In  map[string]reflect.Type
+	Out map[string]reflect.Type
+}
+
+func findTypes(ctx context.Context, n *node) (*portTypes, error) {
+	runner, err := inject.Find(ctx, n.query())
+	if err != nil {
+		return nil, fmt.Errorf("unknown component %q: %s", n.FuncName, err)
+	}
+	tr, ok := runner.(inject.TypedRunner)
+	if !ok {
+		return nil, fmt.Errorf("component %q: %s", n.FuncName, errNoTypeInfo)
+	}
+	in, err := inject.FieldTypes(tr.Input())
+	if err != nil {
+		return nil, fmt.Errorf("component %q input: %s", n.FuncName, err)
+	}
+	out, err := inject.FieldTypes(tr.Output())
+	if err != nil {
+		return nil, fmt.Errorf("component %q output: %s", n.FuncName, err)
+	}
+	return &portTypes{In: in, Out: out}, nil
+}
+
+// mapTypes returns the port types of a map process given the port types of
+// its function
+func mapTypes(n *node, pt *portTypes) *portTypes {
+	r := &portTypes{
+		In:  make(map[string]reflect.Type),
+		Out: make(map[string]reflect.Type),
+	}
+	for name, typ := range pt.In {
+		r.In[name] = typ
+	}
+	for _, name := range n.MapOver {
+		if typ, ok := pt.In[name]; ok {
+			r.In[name] = reflect.SliceOf(typ)
+		}
+	}
+	for name, typ := range pt.Out {
+		r.Out[name] = reflect.SliceOf(typ)
+	}
+	return r
+}
+
+// compatible returns if values of type from may be assigned to type to. If
+// from is an interface, the check is deferred to when the value is known.
+func compatible(from, to reflect.Type) bool {
+	if from.AssignableTo(to) {
+		return true
+	}
+	if from.Kind() == reflect.Interface {
+		return to.Kind() == reflect.Interface || to.Implements(from)
+	}
+	return false
+}
+
+// Validate checks a workflow before running it. It checks that each process
+// refers to a known, typed component; that each connection joins ports of
+// compatible types; and that each input of a process is either connected to
+// another process or has been assigned with SetParam. Errors are returned as
+// a utils.ErrorSlice of *PortError.
+func (a *Workflow) Validate(ctx context.Context) error {
+	var errs utils.ErrorSlice
+	add := func(process, port string, err error) {
+		errs = append(errs, &PortError{Port: Port{Process: process, Port: port}, Err: err})
+	}
+
+	types := make(map[string]*portTypes)
+	for name, n := range a.nodes {
+		pt, err := findTypes(ctx, n)
+		if err != nil {
+			add(name, "", err)
+			continue
+		}
+		if len(n.MapOver) > 0 {
+			pt = mapTypes(n, pt)
+		}
+		types[name] = pt
+	}
+
+	for name, n := range a.nodes {
+		pt := types[name]
+		if pt == nil {
+			continue
+		}
+
+		for port, value := range n.Params {
+			if typ, ok := pt.In[port]; !ok {
+				add(name, port, errUnknownPort)
+			} else if value != nil && !compatible(reflect.TypeOf(value), typ) {
+				add(name, port, fmt.Errorf("value of type %T not assignable to type %s", value, typ))
+			}
+		}
+
+		for _, port := range n.MapOver {
+			if _, ok := pt.In[port]; !ok {
+				add(name, port, errUnknownPort)
+			}
+		}
+
+		for port := range n.Ins {
+			if _, ok := pt.In[port]; !ok {
+				add(name, port, errUnknownPort)
+			}
+		}
+
+		for port := range pt.In {
+			if _, assigned := n.Params[port]; !assigned && !n.Ins[port] {
+				add(name, port, errUnconnected)
+			}
+		}
+
+		for port, eps := range n.Outs {
+			from, ok := pt.Out[port]
+			if !ok {
+				add(name, port, errUnknownPort)
+				continue
+			}
+			for _, ep := range eps {
+				tt := types[ep.Node.Process]
+				if tt == nil {
+					continue
+				}
+				if to, ok := tt.In[ep.Port]; ok && !compatible(from, to) {
+					add(ep.Node.Process, ep.Port, fmt.Errorf("type %s of connected port %q not assignable to type %s", from, endpoint{Port: port, Node: n}, to))
+				}
+			}
+		}
+	}
+
+	SortPortErrors(errs)
+
+	return errs.Pack()
+}
+
+// SortPortErrors sorts a slice of *PortError by process and then by port
+func SortPortErrors(errs utils.ErrorSlice) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		pi, pj := errs[i].(*PortError).Port, errs[j].(*PortError).Port
+		if pi.Process != pj.Process {
+			return pi.Process < pj.Process
+		}
+		return pi.Port < pj.Port
+	})
+}
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	api "github.com/antha-lang/antha/api/v1"
+	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/utils"
+)
+
+func createTypedContext() (context.Context, error) {
+	ctx := inject.NewContext(context.Background())
+
+	type equalsIn struct {
+		A, B string
+	}
+	type equalsOut struct {
+		Out bool
+	}
+	type copyIn struct {
+		In string
+	}
+	type copyOut struct {
+		Out string
+	}
+
+	if err := inject.Add(ctx, inject.Name{Repo: "Equals", Stage: api.ElementStage_STEPS}, &inject.CheckedRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			return inject.Value{"Out": value["A"] == value["B"]}, nil
+		},
+		In:  &equalsIn{},
+		Out: &equalsOut{},
+	}); err != nil {
+		return nil, err
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "Copy", Stage: api.ElementStage_STEPS}, &inject.CheckedRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			return inject.Value{"Out": value["In"]}, nil
+		},
+		In:  &copyIn{},
+		Out: &copyOut{},
+	}); err != nil {
+		return nil, err
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "Untyped", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+		RunFunc: func(_ context.Context, value inject.Value) (inject.Value, error) {
+			return value, nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+func TestValidate(t *testing.T) {
+	ctx, err := createTypedContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for process, funcName := range map[string]string{
+		"Equals": "Equals",
+		"Copy":   "Copy",
+		"Copy2":  "Copy",
+	} {
+		if err := w.AddNode(process, funcName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.AddEdge(Port{Process: "Copy", Port: "Out"}, Port{Process: "Equals", Port: "A"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddEdge(Port{Process: "Equals", Port: "Out"}, Port{Process: "Copy2", Port: "In"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Equals", Port: "B"}, "B"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Copy", Port: "In"}, "In"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Validate(ctx); err == nil {
+		t.Fatal("expecting error connecting bool to string")
+	} else if errs, ok := err.(utils.ErrorSlice); !ok || len(errs) != 1 {
+		t.Fatalf("expecting one error but got %v", err)
+	} else if perr := errs[0].(*PortError); perr.Port != (Port{Process: "Copy2", Port: "In"}) {
+		t.Errorf("expecting error on port %q but got %q", Port{Process: "Copy2", Port: "In"}, perr.Port)
+	}
+}
+
+func TestValidateParams(t *testing.T) {
+	ctx, err := createTypedContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.AddNode("Equals", "Equals"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddNode("Copy", "Copy"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddNode("Untyped", "Untyped"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Equals", Port: "A"}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Copy", Port: "In"}, "In"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetParam(Port{Process: "Copy", Port: "Missing"}, "Missing"); err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Validate(ctx)
+	errs, ok := err.(utils.ErrorSlice)
+	if !ok {
+		t.Fatalf("expecting errors but got %v", err)
+	}
+
+	expected := []string{
+		`port "Copy.Missing": unknown port`,
+		`port "Equals.A": value of type int not assignable to type string`,
+		`port "Equals.B": parameter is neither connected nor assigned`,
+		`process "Untyped": component "Untyped": no type information`,
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expecting %d errors but got %d:\n%s", len(expected), len(errs), err)
+	}
+	for i, e := range expected {
+		if !strings.HasPrefix(errs[i].Error(), e) {
+			t.Errorf("expecting error %q but got %q", e, errs[i])
+		}
+	}
+}
@@ -7,14 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
 
 	api "github.com/antha-lang/antha/api/v1"
 	"github.com/antha-lang/antha/inject"
+	"github.com/antha-lang/antha/profile"
 )
 
-// TODO: deterministic node name/order
-
 var (
 	errCyclicWorkflow  = errors.New("cyclic workflow")
 	errUnknownPort     = errors.New("unknown port")
@@ -34,10 +36,31 @@ func (a Port) String() string {
 	return fmt.Sprintf("%s.%s", a.Process, a.Port)
 }
 
+// ProcessSep separates the names of composite processes and their inner
+// processes in qualified process names
+const ProcessSep = "/"
+
 // A Process is an instance of a component / element execution
 type Process struct {
-	Component string         `json:"component"`
-	Metadata  screenPosition `json:"metadata"`
+	Component string `json:"component"`
+	// Version constraint on the component, e.g., "1.2.3" or "^1.2.0" (see
+	// inject.Constraint). If empty, the latest version is used.
+	Version string `json:"version,omitempty"`
+	// If not empty, the component is run by the runner added for this host,
+	// e.g., the address of a remote element server (see inject/remote).
+	// Otherwise, a runner of any host may be used.
+	Host string `json:"host,omitempty"`
+	// If not nil, this process is a composite of the processes of another
+	// workflow and Component is ignored. Inner processes are named by their
+	// name in the composite workflow qualified by the name of this process,
+	// e.g., "Process/Inner".
+	Workflow *Desc `json:"workflow,omitempty"`
+	// If not empty, the component is run once per item of these input
+	// ports, which must be slices of the same length. The outputs of each
+	// run are gathered into slices in item order. Each run is named by this
+	// process and the index of its item, e.g., "Process[0]".
+	Map      []string       `json:"map,omitempty"`
+	Metadata screenPosition `json:"metadata"`
 }
 
 type screenPosition struct {
@@ -55,6 +78,12 @@ type Connection struct {
 type Desc struct {
 	Processes   map[string]Process `json:"Processes"`
 	Connections []Connection       `json:"connections"`
+	// Named input ports of this workflow when used as a composite process.
+	// Maps each name to a port of a process of this workflow.
+	Inputs map[string]Port `json:"inputs,omitempty"`
+	// Named output ports of this workflow when used as a composite process.
+	// Maps each name to a port of a process of this workflow.
+	Outputs map[string]Port `json:"outputs,omitempty"`
 }
 
 type endpoint struct {
@@ -70,9 +99,12 @@ type node struct {
 	lock     sync.Mutex            // Lock on Params and Ins during Execute
 	Process  string                // Name of this instance
 	FuncName string                // Function that should be called
+	Tag      string                // Version constraint on function
+	Host     string                // Host of function, if any
 	Params   inject.Value          // Parameters to this function
 	Outs     map[string][]endpoint // Out edges
 	Ins      map[string]bool       // In edges
+	MapOver  []string              // Ports to map function over, if any
 }
 
 func (a *node) removeIn(port string) error {
@@ -101,8 +133,135 @@ func (a *node) setParam(port string, value interface{}) error {
 
 // Workflow is the state to execute a workflow
 type Workflow struct {
-	nodes   map[string]*node
-	Outputs map[Port]interface{} // Values generated that were not connected to another process
+	nodes          map[string]*node
+	order          []string
+	completed      []string
+	maxConcurrency int
+	checkpoint     func(*State) error
+	call           func(context.Context, inject.NameQuery, inject.Value) (inject.Value, error)
+	inputs         map[Port]Port // Ports of composite processes to inner input ports
+	outputs        map[Port]Port // Ports of composite processes to inner output ports
+	// Values generated that were not connected to another process. Outputs
+	// of processes within composite processes use qualified process names.
+	Outputs map[Port]interface{}
+}
+
+// State is a snapshot of the progress of running a workflow
+type State struct {
+	Completed []string                // Processes that have completed, in order of completion
+	Params    map[string]inject.Value // Parameters assigned to processes that have not completed
+	Outputs   map[Port]interface{}    // Values generated that were not connected to another process
+}
+
+// State returns a snapshot of the progress of running a workflow. The
+// snapshot shares parameter and output values with the workflow.
+func (a *Workflow) State() *State {
+	s := &State{
+		Completed: append([]string(nil), a.completed...),
+		Params:    make(map[string]inject.Value),
+		Outputs:   make(map[Port]interface{}),
+	}
+	for name, n := range a.nodes {
+		n.lock.Lock()
+		params := make(inject.Value)
+		for k, v := range n.Params {
+			params[k] = v
+		}
+		n.lock.Unlock()
+		s.Params[name] = params
+	}
+	for k, v := range a.Outputs {
+		s.Outputs[k] = v
+	}
+	return s
+}
+
+// Restore resumes a workflow from a previous snapshot of its state. Completed
+// processes are removed from the workflow and will not be run again.
+func (a *Workflow) Restore(state *State) error {
+	if a.order == nil {
+		a.order = makeOrder(a.nodes)
+	}
+
+	for _, process := range state.Completed {
+		n := a.nodes[process]
+		if n == nil {
+			return fmt.Errorf("cannot restore process %q: %s", process, errUnknownProcess)
+		}
+		for _, eps := range n.Outs {
+			for _, ep := range eps {
+				if err := ep.Node.removeIn(ep.Port); err != nil {
+					return fmt.Errorf("error removing in edge on %q: %s", ep, err)
+				}
+			}
+		}
+		delete(a.nodes, process)
+		a.completed = append(a.completed, process)
+	}
+
+	for process, params := range state.Params {
+		for name, value := range params {
+			if err := a.SetParam(Port{Process: process, Port: name}, value); err != nil {
+				return fmt.Errorf("cannot restore parameter %q of process %q: %s", name, process, err)
+			}
+		}
+	}
+
+	for port, value := range state.Outputs {
+		a.Outputs[port] = value
+	}
+
+	return nil
+}
+
+// A ProcessError is an error raised while running a process
+type ProcessError struct {
+	Process string
+	Err     error
+}
+
+// Error satisfies the error interface
+func (a *ProcessError) Error() string {
+	return fmt.Sprintf("cannot run process %q: %s", a.Process, a.Err)
+}
+
+// ProcessErrors are the errors of every process that failed during a run
+type ProcessErrors []*ProcessError
+
+// Error satisfies the error interface
+func (a ProcessErrors) Error() string {
+	var msgs []string
+	for _, err := range a {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// A PanicError is returned when a process panics
+type PanicError struct {
+	Value    interface{}            // Value passed to panic
+	Stack    string                 // Stack trace at the point of the panic
+	Location *inject.SourceLocation // Element source line that panicked, if known
+}
+
+// Error satisfies the error interface
+func (a *PanicError) Error() string {
+	return fmt.Sprintf("%s\n%s", a.Value, a.Stack)
+}
+
+type processKey int
+
+const theProcessKey processKey = 0
+
+func withProcess(parent context.Context, process string) context.Context {
+	return context.WithValue(parent, theProcessKey, process)
+}
+
+// ProcessFromContext returns the name of the process being run in the given
+// context or the empty string if the context is not within a workflow run.
+func ProcessFromContext(ctx context.Context) string {
+	process, _ := ctx.Value(theProcessKey).(string)
+	return process
 }
 
 // FuncName gets the function to be called for the given process name
@@ -114,8 +273,37 @@ func (a *Workflow) FuncName(process string) (string, error) {
 	return n.FuncName, nil
 }
 
+// Query returns the query for the function to be called for the given
+// process name
+func (a *Workflow) Query(process string) (inject.NameQuery, error) {
+	n, ok := a.nodes[process]
+	if !ok {
+		return inject.NameQuery{}, errUnknownProcess
+	}
+	return n.query(), nil
+}
+
+// ResolveInput returns the input port of the process that is run for the
+// given port. If port is an input of a composite process, this is the port
+// of the inner process that the input is mapped to. Otherwise, this is port
+// itself.
+func (a *Workflow) ResolveInput(port Port) Port {
+	if p, ok := a.inputs[port]; ok {
+		return p
+	}
+	return port
+}
+
+func (a *Workflow) resolveOutput(port Port) Port {
+	if p, ok := a.outputs[port]; ok {
+		return p
+	}
+	return port
+}
+
 // SetParam sets initial parameter values before executing
 func (a *Workflow) SetParam(port Port, value interface{}) error {
+	port = a.ResolveInput(port)
 	n := a.nodes[port.Process]
 	if n == nil {
 		return errUnknownPort
@@ -152,17 +340,60 @@ func updateOutParams(n *node, out inject.Value, unmatched map[Port]interface{})
 	return nil
 }
 
-func (a *Workflow) run(ctx context.Context, n *node) ([]*node, error) {
-	query := inject.NameQuery{
-		Repo:  n.FuncName,
+type result struct {
+	Node *node
+	Out  inject.Value
+	Err  error
+}
+
+// callProcess calls a function on behalf of the named process, converting
+// any panic into an error
+func (a *Workflow) callProcess(ctx context.Context, process string, query inject.NameQuery, params inject.Value) (out inject.Value, err error) {
+	ctx = profile.WithTrack(withProcess(ctx, process), process)
+	defer profile.Start(ctx, "process", process).End()
+
+	defer func() {
+		if v := recover(); v != nil {
+			out = nil
+			err = &PanicError{
+				Value:    v,
+				Stack:    inject.ElementStackTrace(),
+				Location: inject.ElementCaller(),
+			}
+		}
+	}()
+
+	if a.call != nil {
+		return a.call(ctx, query, params)
+	}
+	return inject.Call(ctx, query, params)
+}
+
+func (a *node) query() inject.NameQuery {
+	return inject.NameQuery{
+		Host:  a.Host,
+		Repo:  a.FuncName,
+		Tag:   a.Tag,
 		Stage: api.ElementStage_STEPS,
 	}
-	out, err := inject.Call(ctx, query, n.Params)
+}
 
-	if err != nil {
-		return nil, err
+// callNode runs the function of a process. It is safe to call concurrently for
+// different nodes.
+func (a *Workflow) callNode(ctx context.Context, n *node, maxConcurrency int) (res result) {
+	res.Node = n
+	query := n.query()
+	if len(n.MapOver) > 0 {
+		res.Out, res.Err = a.callMap(ctx, n, query, maxConcurrency)
+	} else {
+		res.Out, res.Err = a.callProcess(ctx, n.Process, query, n.Params)
 	}
+	return
+}
 
+// complete propagates the outputs of a process and returns the processes that
+// are now ready to run. Not safe to call concurrently.
+func (a *Workflow) complete(n *node, out inject.Value) ([]*node, error) {
 	if err := updateOutParams(n, out, a.Outputs); err != nil {
 		return nil, err
 	}
@@ -178,6 +409,7 @@ func (a *Workflow) run(ctx context.Context, n *node) ([]*node, error) {
 		}
 	}
 	delete(a.nodes, n.Process)
+	a.completed = append(a.completed, n.Process)
 	return roots, nil
 }
 
@@ -194,23 +426,116 @@ func makeRoots(nodes map[string]*node) ([]*node, error) {
 	return roots, nil
 }
 
-// Run a workflow
+// makeOrder returns the processes of a workflow in topological order, breaking
+// ties by process name.
+func makeOrder(nodes map[string]*node) []string {
+	ins := make(map[string]int)
+	var ready []string
+	for name, n := range nodes {
+		ins[name] = len(n.Ins)
+		if ins[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+
+	var order []string
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, eps := range nodes[name].Outs {
+			for _, ep := range eps {
+				ins[ep.Node.Process]--
+				if ins[ep.Node.Process] == 0 {
+					ready = append(ready, ep.Node.Process)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// Order returns the names of the processes of a workflow in a deterministic
+// topological order. Processes that run concurrently can use this order to
+// serialize their side-effects. After Run or Restore, Order includes
+// processes that have already completed.
+func (a *Workflow) Order() []string {
+	if a.order != nil {
+		return a.order
+	}
+	return makeOrder(a.nodes)
+}
+
+// Run a workflow. Processes whose inputs are all available are run
+// concurrently, up to the concurrency limit of the workflow. If any process
+// fails, no new processes are started and the errors of all the processes that
+// failed are returned as ProcessErrors.
 func (a *Workflow) Run(ctx context.Context) error {
 	worklist, err := makeRoots(a.nodes)
 	if err != nil {
 		return err
 	}
 
-	for len(worklist) > 0 {
-		n := worklist[0]
-		worklist = worklist[1:]
+	if a.order == nil {
+		a.order = makeOrder(a.nodes)
+	}
+	rank := make(map[string]int)
+	for idx, name := range a.order {
+		rank[name] = idx
+	}
 
-		if moreWork, err := a.run(ctx, n); err != nil {
-			return fmt.Errorf("cannot run process %q: %s", n.Process, err)
-		} else {
+	maxConcurrency := a.maxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan result)
+	running := 0
+	var errs ProcessErrors
+	for len(worklist) > 0 || running > 0 {
+		sort.Slice(worklist, func(i, j int) bool {
+			return rank[worklist[i].Process] < rank[worklist[j].Process]
+		})
+		for len(errs) == 0 && len(worklist) > 0 && running < maxConcurrency {
+			n := worklist[0]
+			worklist = worklist[1:]
+			running++
+			go func() {
+				results <- a.callNode(ctx, n, maxConcurrency)
+			}()
+		}
+		if running == 0 {
+			break
+		}
+
+		res := <-results
+		running--
+		if res.Err == nil && len(errs) == 0 {
+			var moreWork []*node
+			moreWork, res.Err = a.complete(res.Node, res.Out)
 			worklist = append(worklist, moreWork...)
+			if res.Err == nil && a.checkpoint != nil {
+				if err := a.checkpoint(a.State()); err != nil {
+					res.Err = fmt.Errorf("cannot save checkpoint: %s", err)
+				}
+			}
+		}
+		if res.Err != nil {
+			errs = append(errs, &ProcessError{Process: res.Node.Process, Err: res.Err})
+			cancel()
 		}
 	}
+
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Process < errs[j].Process
+		})
+		return errs
+	}
 	if len(a.nodes) > 0 { // by definition, len(worklist) == 0
 		return errCyclicWorkflow
 	}
@@ -234,8 +559,32 @@ func (a *Workflow) AddNode(process, funcName string) error {
 	return nil
 }
 
+// AddMapNode adds a process to a workflow that executes funcName once per
+// item of the given input ports
+func (a *Workflow) AddMapNode(process, funcName string, over []string) error {
+	if len(over) == 0 {
+		return fmt.Errorf("process %q maps over no ports", process)
+	}
+	if err := a.AddNode(process, funcName); err != nil {
+		return err
+	}
+	a.nodes[process].MapOver = append([]string(nil), over...)
+	return nil
+}
+
+// MapOver returns the input ports that a process maps over or nil if the
+// process is not a map process
+func (a *Workflow) MapOver(process string) []string {
+	if n := a.nodes[process]; n != nil {
+		return n.MapOver
+	}
+	return nil
+}
+
 // AddEdge connects an output of one process to an input of another
 func (a *Workflow) AddEdge(src, tgt Port) error {
+	src = a.resolveOutput(src)
+	tgt = a.ResolveInput(tgt)
 	snode := a.nodes[src.Process]
 	if snode == nil {
 		return fmt.Errorf("unknown source port %q", src)
@@ -258,13 +607,27 @@ func (a *Workflow) AddEdge(src, tgt Port) error {
 // Opt are options for creating a new Workflow
 type Opt struct {
 	FromDesc *Desc
+	// Maximum number of processes to run concurrently. If zero, defaults to
+	// runtime.GOMAXPROCS.
+	MaxConcurrency int
+	// If not nil, called with the state of the workflow after each process
+	// completes
+	Checkpoint func(*State) error
+	// If not nil, called instead of inject.Call to run the function of each
+	// process
+	Call func(ctx context.Context, query inject.NameQuery, value inject.Value) (inject.Value, error)
 }
 
 // New creates a new Workflow
 func New(opt Opt) (*Workflow, error) {
 	w := &Workflow{
-		nodes:   make(map[string]*node),
-		Outputs: make(map[Port]interface{}),
+		nodes:          make(map[string]*node),
+		maxConcurrency: opt.MaxConcurrency,
+		checkpoint:     opt.Checkpoint,
+		call:           opt.Call,
+		inputs:         make(map[Port]Port),
+		outputs:        make(map[Port]Port),
+		Outputs:        make(map[Port]interface{}),
 	}
 
 	var desc *Desc
@@ -274,16 +637,104 @@ func New(opt Opt) (*Workflow, error) {
 		desc = &Desc{}
 	}
 
+	if err := w.addDesc("", desc, make(map[*Desc]bool)); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func qualify(prefix string, port Port) Port {
+	return Port{Process: prefix + port.Process, Port: port.Port}
+}
+
+// addDesc adds the processes and connections of a workflow description,
+// flattening any composite processes. The names of processes are qualified by
+// prefix.
+func (a *Workflow) addDesc(prefix string, desc *Desc, seen map[*Desc]bool) error {
+	if seen[desc] {
+		return fmt.Errorf("composite process %q contains itself", strings.TrimSuffix(prefix, ProcessSep))
+	}
+	seen[desc] = true
+	defer delete(seen, desc)
+
+	for name, process := range desc.Processes {
+		qname := prefix + name
+		if process.Workflow != nil && len(process.Map) > 0 {
+			return fmt.Errorf("composite process %q cannot be a map process", qname)
+		} else if len(process.Map) > 0 {
+			if err := a.AddMapNode(qname, process.Component, process.Map); err != nil {
+				return err
+			}
+		} else if process.Workflow == nil {
+			if err := a.AddNode(qname, process.Component); err != nil {
+				return err
+			}
+		} else if err := a.addDesc(qname+ProcessSep, process.Workflow, seen); err != nil {
+			return err
+		}
+	}
+
+	for name, process := range desc.Processes {
+		if process.Version == "" {
+			continue
+		} else if process.Workflow != nil {
+			return fmt.Errorf("composite process %q cannot have a version", prefix+name)
+		} else if _, err := inject.ParseConstraint(process.Version); err != nil {
+			return fmt.Errorf("process %q: %s", prefix+name, err)
+		}
+		a.nodes[prefix+name].Tag = process.Version
+	}
+
+	for name, process := range desc.Processes {
+		if process.Host == "" {
+			continue
+		} else if process.Workflow != nil {
+			return fmt.Errorf("composite process %q cannot have a host", prefix+name)
+		}
+		a.nodes[prefix+name].Host = process.Host
+	}
+
 	for name, process := range desc.Processes {
-		if err := w.AddNode(name, process.Component); err != nil {
-			return nil, err
+		if process.Workflow != nil {
+			if err := a.addPorts(prefix+name, process.Workflow); err != nil {
+				return err
+			}
 		}
 	}
 
 	for _, c := range desc.Connections {
-		if err := w.AddEdge(c.Src, c.Tgt); err != nil {
-			return nil, err
+		if err := a.AddEdge(qualify(prefix, c.Src), qualify(prefix, c.Tgt)); err != nil {
+			return err
 		}
 	}
-	return w, nil
+	return nil
+}
+
+// addPorts adds the named ports of a composite process
+func (a *Workflow) addPorts(process string, desc *Desc) error {
+	prefix := process + ProcessSep
+	add := func(ports map[Port]Port, resolve func(Port) Port, name string, inner Port) error {
+		if _, seen := desc.Inputs[name]; seen {
+			if _, seen := desc.Outputs[name]; seen {
+				return fmt.Errorf("port %q of composite process %q is both an input and an output", name, process)
+			}
+		}
+		if _, seen := desc.Processes[inner.Process]; !seen {
+			return fmt.Errorf("port %q of composite process %q refers to unknown process %q", name, process, inner.Process)
+		}
+		ports[Port{Process: process, Port: name}] = resolve(qualify(prefix, inner))
+		return nil
+	}
+
+	for name, inner := range desc.Inputs {
+		if err := add(a.inputs, a.ResolveInput, name, inner); err != nil {
+			return err
+		}
+	}
+	for name, inner := range desc.Outputs {
+		if err := add(a.outputs, a.resolveOutput, name, inner); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -31,3 +31,36 @@ var condCopyEqualsJSON = `
     ]
 }
 `
+
+var compositeJSON = `
+{
+    "processes": {
+        "Equals": { "component": "Equals" },
+        "CondCopy": {
+            "workflow": {
+                "processes": {
+                    "Cond": { "component": "Cond" },
+                    "Copy": { "component": "Copy" }
+                },
+                "connections": [
+                    {
+                        "source": { "process": "Cond", "port": "Out" },
+                        "target": { "process": "Copy", "port": "In" }
+                    }
+                ],
+                "inputs": {
+                    "Cond": { "process": "Cond", "port": "Cond" },
+                    "True": { "process": "Cond", "port": "True" },
+                    "False": { "process": "Cond", "port": "False" }
+                }
+            }
+        }
+    },
+    "connections": [
+        {
+            "source": { "process": "Equals", "port": "Out" },
+            "target": { "process": "CondCopy", "port": "Cond" }
+        }
+    ]
+}
+`
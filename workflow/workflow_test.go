@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	api "github.com/antha-lang/antha/api/v1"
 	"github.com/antha-lang/antha/inject"
@@ -168,3 +171,351 @@ func TestDuplicateIns(t *testing.T) {
 		t.Errorf("expecting error setting in port")
 	}
 }
+
+func TestRunConcurrent(t *testing.T) {
+	const numProcesses = 8
+	const maxConcurrency = 3
+
+	var lock sync.Mutex
+	running := 0
+	maxRunning := 0
+
+	ctx := inject.NewContext(context.Background())
+	if err := inject.Add(ctx, inject.Name{Repo: "Sleep", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+		RunFunc: func(ctx context.Context, value inject.Value) (inject.Value, error) {
+			lock.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			lock.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			lock.Lock()
+			running--
+			lock.Unlock()
+			return inject.Value{"Out": ProcessFromContext(ctx)}, nil
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{MaxConcurrency: maxConcurrency})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < numProcesses; i++ {
+		if err := w.AddNode(fmt.Sprintf("Sleep%d", i), "Sleep"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if maxRunning != maxConcurrency {
+		t.Errorf("expecting %d concurrent processes but got %d", maxConcurrency, maxRunning)
+	}
+	for i := 0; i < numProcesses; i++ {
+		process := fmt.Sprintf("Sleep%d", i)
+		if out := w.Outputs[Port{Process: process, Port: "Out"}]; out != process {
+			t.Errorf("expecting output %q but got %q", process, out)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	ctx := inject.NewContext(context.Background())
+	if err := inject.Add(ctx, inject.Name{Repo: "Fail", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+		RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+			return nil, fmt.Errorf("failed")
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := inject.Add(ctx, inject.Name{Repo: "Panic", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+		RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+			panic("panicked")
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{MaxConcurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []struct{ Process, FuncName string }{
+		{Process: "A", FuncName: "Fail"},
+		{Process: "B", FuncName: "Panic"},
+		{Process: "C", FuncName: "Fail"},
+	} {
+		if err := w.AddNode(n.Process, n.FuncName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.AddEdge(Port{Process: "A", Port: "Out"}, Port{Process: "C", Port: "In"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = w.Run(ctx)
+	errs, ok := err.(ProcessErrors)
+	if !ok {
+		t.Fatalf("expecting ProcessErrors but got %T: %v", err, err)
+	}
+
+	var processes []string
+	for _, err := range errs {
+		processes = append(processes, err.Process)
+	}
+	if e := []string{"A", "B"}; !reflect.DeepEqual(e, processes) {
+		t.Errorf("expecting failed processes %q but got %q", e, processes)
+	}
+	if p, ok := errs[1].Err.(*PanicError); !ok {
+		t.Errorf("expecting PanicError but got %T", errs[1].Err)
+	} else if p.Value != "panicked" {
+		t.Errorf("expecting panic value %q but got %q", "panicked", p.Value)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	var desc *Desc
+	if err := json.Unmarshal([]byte(condCopyEqualsJSON), &desc); err != nil {
+		t.Fatal(err)
+	}
+	desc.Processes["Aardvark"] = Process{Component: "Copy"}
+
+	w, err := New(Opt{FromDesc: desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"Aardvark", "Equals", "Cond", "Copy"}
+	if order := w.Order(); !reflect.DeepEqual(expected, order) {
+		t.Errorf("expecting order %q but got %q", expected, order)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	var desc *Desc
+	if err := json.Unmarshal([]byte(condCopyEqualsJSON), &desc); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := createContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var states []*State
+	w, err := New(Opt{
+		FromDesc: desc,
+		Checkpoint: func(s *State) error {
+			states = append(states, s)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for port, value := range map[Port]interface{}{
+		{Process: "Equals", Port: "A"}:   "A",
+		{Process: "Equals", Port: "B"}:   "B",
+		{Process: "Cond", Port: "True"}:  "True",
+		{Process: "Cond", Port: "False"}: "False",
+	} {
+		if err := w.SetParam(port, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if l := len(states); l != 3 {
+		t.Fatalf("expecting %d checkpoints but got %d", 3, l)
+	}
+	state := states[0]
+	if e := []string{"Equals"}; !reflect.DeepEqual(e, state.Completed) {
+		t.Errorf("expecting completed processes %q but got %q", e, state.Completed)
+	} else if c, ok := state.Params["Cond"]["Cond"].(bool); !ok || c {
+		t.Errorf("expecting assigned parameter %v but got %v", false, state.Params["Cond"]["Cond"])
+	}
+
+	resumed, err := New(Opt{FromDesc: desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := resumed.Restore(state); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := resumed.FuncName("Equals"); err == nil {
+		t.Errorf("expecting completed process to be removed")
+	}
+	if err := resumed.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if out, ok := resumed.Outputs[Port{Process: "Copy", Port: "Out"}].(string); !ok {
+		t.Errorf("cannot read parameter Out")
+	} else if out != "False" {
+		t.Errorf("expecting output %q but got %q", "False", out)
+	}
+	if e, order := w.Order(), resumed.Order(); !reflect.DeepEqual(e, order) {
+		t.Errorf("expecting order %q but got %q", e, order)
+	}
+}
+
+func TestRunComposite(t *testing.T) {
+	var desc *Desc
+	if err := json.Unmarshal([]byte(compositeJSON), &desc); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{FromDesc: desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := createContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for port, value := range map[Port]interface{}{
+		{Process: "Equals", Port: "A"}:       "A",
+		{Process: "Equals", Port: "B"}:       "B",
+		{Process: "CondCopy", Port: "True"}:  "True",
+		{Process: "CondCopy", Port: "False"}: "False",
+	} {
+		if err := w.SetParam(port, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if out, ok := w.Outputs[Port{Process: "CondCopy/Copy", Port: "Out"}].(string); !ok {
+		t.Errorf("cannot read parameter Out")
+	} else if out != "False" {
+		t.Errorf("expecting output %q but got %q", "False", out)
+	}
+}
+
+func TestCompositePortCollision(t *testing.T) {
+	desc := &Desc{
+		Processes: map[string]Process{
+			"Composite": {
+				Workflow: &Desc{
+					Processes: map[string]Process{
+						"Copy": {Component: "Copy"},
+					},
+					Inputs: map[string]Port{
+						"X": {Process: "Copy", Port: "In"},
+					},
+					Outputs: map[string]Port{
+						"X": {Process: "Copy", Port: "Out"},
+					},
+				},
+			},
+		},
+	}
+	if _, err := New(Opt{FromDesc: desc}); err == nil {
+		t.Errorf("expecting error on port name collision")
+	}
+}
+
+func TestRunVersion(t *testing.T) {
+	ctx := inject.NewContext(context.Background())
+	for _, tag := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		tag := tag
+		if err := inject.Add(ctx, inject.Name{Repo: "Version", Tag: tag, Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+			RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+				return inject.Value{"Out": tag}, nil
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var desc Desc
+	if err := json.Unmarshal([]byte(`{
+  "Processes": {
+    "Latest": {"component": "Version"},
+    "Caret": {"component": "Version", "version": "^1.0.0"},
+    "Exact": {"component": "Version", "version": "1.0.0"}
+  }
+}`), &desc); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{FromDesc: &desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	for process, golden := range map[string]string{
+		"Latest": "2.0.0",
+		"Caret":  "1.1.0",
+		"Exact":  "1.0.0",
+	} {
+		if out := w.Outputs[Port{Process: process, Port: "Out"}]; out != golden {
+			t.Errorf("%s: expecting version %q but got %v", process, golden, out)
+		}
+	}
+
+	desc.Processes["Bad"] = Process{Component: "Version", Version: "^bad"}
+	if _, err := New(Opt{FromDesc: &desc}); err == nil {
+		t.Error("expecting error on invalid version constraint")
+	}
+}
+
+func TestRunHost(t *testing.T) {
+	ctx := inject.NewContext(context.Background())
+	for _, host := range []string{"", "remote"} {
+		host := host
+		if err := inject.Add(ctx, inject.Name{Host: host, Repo: "Host", Stage: api.ElementStage_STEPS}, &inject.FuncRunner{
+			RunFunc: func(context.Context, inject.Value) (inject.Value, error) {
+				return inject.Value{"Out": host}, nil
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var desc Desc
+	if err := json.Unmarshal([]byte(`{
+  "Processes": {
+    "Remote": {"component": "Host", "host": "remote"}
+  }
+}`), &desc); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Opt{FromDesc: &desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if out := w.Outputs[Port{Process: "Remote", Port: "Out"}]; out != "remote" {
+		t.Errorf("expecting host %q but got %v", "remote", out)
+	}
+
+	desc.Processes["Missing"] = Process{Component: "Host", Host: "missing"}
+	w, err = New(Opt{FromDesc: &desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Run(ctx); err == nil {
+		t.Error("expecting error running component of unknown host")
+	}
+}